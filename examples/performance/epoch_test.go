@@ -0,0 +1,60 @@
+package performance
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"testing"
+)
+
+// The size of population to benchmark epoch execution
+const benchmarkPopSize = 1000
+
+// benchmarkNextEpoch is to benchmark the epoch turnover of the large population spawned from the big genome
+// by provided epoch executor
+func benchmarkNextEpoch(b *testing.B, executor genetics.PopulationEpochExecutor) {
+	opts, err := neat.ReadNeatOptionsFromFile("../../data/xor_test.neat.yml")
+	require.NoError(b, err, "failed to read NEAT options")
+	opts.PopSize = benchmarkPopSize
+	neat.LogLevel = neat.LogLevelWarning
+
+	reader, err := genetics.NewGenomeReader(bytes.NewBufferString(genomeStr), genetics.PlainGenomeEncoding)
+	require.NoError(b, err, "failed to create genome reader")
+	genome, err := reader.Read()
+	require.NoError(b, err, "failed to read genome")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		ctx := neat.NewRandContext(opts.NeatContext(), neat.NewRand(42))
+		pop, err := genetics.NewPopulationContext(ctx, genome)
+		require.NoError(b, err, "failed to create population")
+		rng := neat.RandFromContext(ctx)
+		for _, org := range pop.Organisms {
+			org.Fitness = rng.Float64()
+		}
+		b.StartTimer()
+
+		err = executor.NextEpoch(ctx, 1, pop)
+		require.NoError(b, err, "failed to execute epoch")
+	}
+}
+
+// The benchmarks of epoch executors with population of 1000 organisms, run by `make bench-epoch` on single CPU:
+//
+//	                                   ns/op        B/op   allocs/op
+//	Sequential                      ~ 75 000 000  31 559 900     205 333
+//	Parallel (babies gob-encoded)   ~460 000 000 165 806 680   1 197 222
+//	Parallel (babies handed back)   ~ 78 000 000  31 560 140     205 339
+//
+// Handing the babies back to the parallel executor without gob encoding makes it about 6 times faster and about
+// 5 times less memory hungry, which is on par with sequential executor on single CPU. With more CPUs, the parallel
+// executor gains from concurrent reproduction of species.
+func BenchmarkSequentialPopulationEpochExecutor_NextEpoch(b *testing.B) {
+	benchmarkNextEpoch(b, &genetics.SequentialPopulationEpochExecutor{})
+}
+
+func BenchmarkParallelPopulationEpochExecutor_NextEpoch(b *testing.B) {
+	benchmarkNextEpoch(b, &genetics.ParallelPopulationEpochExecutor{})
+}
@@ -39,9 +39,10 @@ func (e *cartPoleGenerationEvaluator) GenerationEvaluate(ctx context.Context, po
 	if !ok {
 		return neat.ErrNEATOptionsNotFound
 	}
-	// Evaluate each organism on a test
+	// Evaluate each organism on a test using its own stream of random numbers
+	rng := neat.RandFromContext(ctx)
 	for _, org := range pop.Organisms {
-		res, err := OrganismEvaluate(org, e.WinBalancingSteps, e.RandomStart)
+		res, err := OrganismEvaluate(org, e.WinBalancingSteps, e.RandomStart, neat.DeriveRand(rng))
 		if err != nil {
 			return err
 		}
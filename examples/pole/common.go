@@ -11,15 +11,16 @@ import (
 
 const twelveDegrees = 12.0 * math.Pi / 180.0
 
-// OrganismEvaluate evaluates provided organism for cart pole balancing task
-func OrganismEvaluate(organism *genetics.Organism, winnerBalancingSteps int, randomStart bool) (bool, error) {
+// OrganismEvaluate evaluates provided organism for cart pole balancing task. The random start state is generated by
+// provided random numbers generator.
+func OrganismEvaluate(organism *genetics.Organism, winnerBalancingSteps int, randomStart bool, rng *rand.Rand) (bool, error) {
 	phenotype, err := organism.Phenotype()
 	if err != nil {
 		return false, err
 	}
 
 	// Try to balance a pole now
-	if fitness, err := runCart(phenotype, winnerBalancingSteps, randomStart); err != nil {
+	if fitness, err := runCart(phenotype, winnerBalancingSteps, randomStart, rng); err != nil {
 		return false, nil
 	} else {
 		organism.Fitness = float64(fitness)
@@ -52,17 +53,17 @@ func OrganismEvaluate(organism *genetics.Organism, winnerBalancingSteps int, ran
 }
 
 // runCart runs the cart emulation and return number of emulation steps pole was balanced
-func runCart(net *network.Network, winnerBalancingSteps int, randomStart bool) (steps int, err error) {
+func runCart(net *network.Network, winnerBalancingSteps int, randomStart bool, rng *rand.Rand) (steps int, err error) {
 	var x float64        /* cart position, meters */
 	var xDot float64     /* cart velocity */
 	var theta float64    /* pole angle, radians */
 	var thetaDot float64 /* pole angular velocity */
 	if randomStart {
 		/*set up random start state*/
-		x = float64(rand.Int31()%4800)/1000.0 - 2.4
-		xDot = float64(rand.Int31()%2000)/1000.0 - 1
-		theta = float64(rand.Int31()%400)/1000.0 - .2
-		thetaDot = float64(rand.Int31()%3000)/1000.0 - 1.5
+		x = float64(rng.Int31()%4800)/1000.0 - 2.4
+		xDot = float64(rng.Int31()%2000)/1000.0 - 1
+		theta = float64(rng.Int31()%400)/1000.0 - .2
+		thetaDot = float64(rng.Int31()%3000)/1000.0 - 1.5
 	}
 
 	netDepth, err := net.MaxActivationDepthWithCap(0) // The max depth of the network to be activated
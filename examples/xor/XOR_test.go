@@ -136,3 +136,62 @@ func TestXOR_disconnected(t *testing.T) {
 	meanAge /= count
 	t.Logf("Mean best organisms: complexity=%.1f, diversity=%.1f, age=%.1f", meanComplexity, meanDiversity, meanAge)
 }
+
+// The XOR integration test with island model running over multiple iterations in order to detect if any random errors occur.
+func TestXOR_islandModel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short Unit Test mode.")
+	}
+
+	// the numbers will be different every time we run.
+	rand.Seed(time.Now().Unix())
+
+	outDirPath, contextPath, genomePath := "../../out/XOR_island_model_test", "../../data/xor.neat", "../../data/xorstartgenes"
+
+	fmt.Println("Loading start genome for XOR island model experiment")
+	opts, startGenome, err := utils.LoadOptionsAndGenome(contextPath, genomePath)
+	neat.LogLevel = neat.LogLevelInfo
+	require.NoError(t, err)
+
+	// Check if output dir exists
+	err = utils.CreateOutputDir(outDirPath)
+	require.NoError(t, err, "Failed to create output directory")
+
+	// The 40 runs XOR experiment with three islands exchanging their champions
+	opts.NumRuns = 40
+	opts.PopSize = 50
+	opts.IslandModel = &neat.IslandModelOptions{
+		IslandsCount:      3,
+		MigrationInterval: 5,
+		MigrantsCount:     2,
+		Topology:          neat.MigrationTopologyRing,
+	}
+	experiment := experiment2.Experiment{
+		Id:     0,
+		Trials: make(experiment2.Trials, opts.NumRuns),
+	}
+	err = experiment.Execute(opts.NeatContext(), startGenome, NewXORGenerationEvaluator(outDirPath), nil)
+	require.NoError(t, err, "Failed to perform XOR island model experiment")
+
+	// Find winner statistics
+	avgNodes, avgGenes, avgEvals, _ := experiment.AvgWinnerStatistics()
+
+	// check results
+	if avgNodes < 5 {
+		t.Error("avg_nodes < 5", avgNodes)
+	} else if avgNodes > 15 {
+		t.Error("avg_nodes > 15", avgNodes)
+	}
+
+	if avgGenes < 7 {
+		t.Error("avg_genes < 7", avgGenes)
+	} else if avgGenes > 20 {
+		t.Error("avg_genes > 20", avgGenes)
+	}
+
+	maxEvals := float64(opts.PopSize * opts.NumGenerations * opts.IslandModel.IslandsCount)
+	assert.True(t, avgEvals < maxEvals)
+
+	t.Logf("avg_nodes: %.1f, avg_genes: %.1f, avg_evals: %.1f, success rate: %.2f\n", avgNodes, avgGenes, avgEvals,
+		experiment.SuccessRate())
+}
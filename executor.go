@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
@@ -10,10 +11,12 @@ import (
 	"github.com/yaricom/goNEAT/v4/experiment"
 	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"io"
 	"log"
 	"math/rand"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 )
@@ -28,6 +31,9 @@ func main() {
 	var logLevel = flag.String("log_level", "", "The logger level to be used. Overrides the one set in configuration.")
 	var randSeed = flag.Int64("seed", 0, "The seed for random number generator")
 	var maxWorkers = flag.Int("max_workers", 50, "The maximum number of concurrent workers")
+	var checkpointInterval = flag.Int("checkpoint_interval", 0, "The interval in generations between population checkpoints. If zero, checkpoints are not written.")
+	var resumePath = flag.String("resume", "", "The population checkpoint file to resume the trial from.")
+	var resumeTrial = flag.Int("resume_trial", 0, "The ID of the trial to be resumed from the population checkpoint.")
 
 	flag.Parse()
 
@@ -57,6 +63,14 @@ func main() {
 	}
 	fmt.Println(startGenome)
 
+	// Load population checkpoint before output directory backup
+	var checkpoint []byte
+	if len(*resumePath) > 0 {
+		if checkpoint, err = os.ReadFile(*resumePath); err != nil {
+			log.Fatalf("Failed to read population checkpoint, reason: '%s'", err)
+		}
+	}
+
 	// Check if output dir exists
 	outDir := *outDirPath
 	if _, err := os.Stat(outDir); err == nil {
@@ -90,6 +104,12 @@ func main() {
 		Trials:   make(experiment.Trials, neatOptions.NumRuns),
 		RandSeed: seed,
 	}
+	if *checkpointInterval > 0 {
+		exp.CheckpointInterval = *checkpointInterval
+		exp.CheckpointWriter = func(trialId, generation int) (io.WriteCloser, error) {
+			return os.Create(filepath.Join(outDir, fmt.Sprintf("checkpoint_%d_%d", trialId, generation)))
+		}
+	}
 	var generationEvaluator experiment.GenerationEvaluator
 	switch *experimentName {
 	case "XOR":
@@ -119,7 +139,14 @@ func main() {
 
 	// run experiment in the separate GO routine
 	go func() {
-		if err = exp.Execute(neat.NewContext(ctx, neatOptions), startGenome, generationEvaluator, nil); err != nil {
+		// the seeded random numbers generator makes the run reproducible regardless of the executor type
+		execCtx := neat.NewRandSourceContext(neat.NewContext(ctx, neatOptions), neat.NewSource(seed))
+		if checkpoint != nil {
+			err = exp.Resume(execCtx, bytes.NewReader(checkpoint), *resumeTrial, generationEvaluator, nil)
+		} else {
+			err = exp.Execute(execCtx, startGenome, generationEvaluator, nil)
+		}
+		if err != nil {
 			errChan <- err
 		} else {
 			errChan <- nil
@@ -0,0 +1,169 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"math/rand"
+	"time"
+)
+
+// ErrCoevolutionOptionsNotFound The error to be raised when coevolution options are missing in the NEAT options
+var ErrCoevolutionOptionsNotFound = errors.New("coevolution options not found in the NEAT options")
+
+// CoevolutionEvaluator the interface describing evaluator for one generation of the competitive coevolution, where
+// the fitness of organisms is estimated by competing with opponents rather than by solving the static task.
+type CoevolutionEvaluator interface {
+	// GenerationEvaluate Invoked to evaluate one generation of population of organisms against given opponents within
+	// given execution context. The opponents are sampled from the competing population and from its hall of fame.
+	// The evaluator should not change the fitness of opponents, but it can use their phenotypes.
+	GenerationEvaluate(ctx context.Context, pop *genetics.Population, opponents []*genetics.Organism, epoch *Generation) error
+}
+
+// Coevolution is the competitive coevolution of two populations against each other or of one population against
+// itself. Each generation, the organisms of each population are evaluated against opponents sampled from the competing
+// population and from the hall of fame of its past champions.
+type Coevolution struct {
+	// The competing populations, the single population competes against itself
+	Populations []*genetics.Population
+	// The halls of fame of champions per population
+	HallsOfFame []*genetics.HallOfFame
+	// The options of the coevolution
+	Options *neat.CoevolutionOptions
+
+	// The executors of population epochs
+	epochExecutors []genetics.PopulationEpochExecutor
+	// The plasticity rule of phenotypes
+	plasticityRule neat.PlasticityRule
+	// The evaluator of populations generations
+	evaluator CoevolutionEvaluator
+}
+
+// NewCoevolution Creates new competitive coevolution with populations spawned from the given start genomes. If one
+// start genome provided, the single population competes against itself, if two - the populations compete against
+// each other. The NEAT options with coevolution options expected in the context.
+func NewCoevolution(ctx context.Context, startGenomes []*genetics.Genome, evaluator CoevolutionEvaluator) (*Coevolution, error) {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return nil, neat.ErrNEATOptionsNotFound
+	}
+	if opts.Coevolution == nil {
+		return nil, ErrCoevolutionOptionsNotFound
+	}
+	if len(startGenomes) != 1 && len(startGenomes) != 2 {
+		return nil, fmt.Errorf("coevolution expects one or two start genomes, but got: %d", len(startGenomes))
+	}
+
+	co := &Coevolution{
+		Populations:    make([]*genetics.Population, len(startGenomes)),
+		HallsOfFame:    make([]*genetics.HallOfFame, len(startGenomes)),
+		Options:        opts.Coevolution,
+		epochExecutors: make([]genetics.PopulationEpochExecutor, len(startGenomes)),
+		plasticityRule: opts.PlasticityRule,
+		evaluator:      evaluator,
+	}
+	for i, startGenome := range startGenomes {
+		pop, err := spawnPopulation(ctx, startGenome)
+		if err != nil {
+			return nil, err
+		}
+		co.Populations[i] = pop
+		co.HallsOfFame[i] = genetics.NewHallOfFame(opts.Coevolution.HallOfFameSize)
+		if co.epochExecutors[i], err = epochExecutorForContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return co, nil
+}
+
+// Opponents Returns the opponents for the population with given index sampled by provided random numbers generator
+// from the competing population and from the hall of fame of its past champions
+func (c *Coevolution) Opponents(population int, rng *rand.Rand) []*genetics.Organism {
+	competitor := c.competitor(population)
+	opponents := c.Populations[competitor].RandomOrganisms(c.Options.OpponentsCount, rng)
+	return append(opponents, c.HallsOfFame[competitor].Sample(c.Options.HallOfFameOpponents, rng)...)
+}
+
+func (c *Coevolution) evaluate(ctx context.Context, generation *Generation) error {
+	// sample opponents before evaluation to give each population the same chances
+	rng := neat.RandFromContext(ctx)
+	opponents := make([][]*genetics.Organism, len(c.Populations))
+	for i := range c.Populations {
+		opponents[i] = c.Opponents(i, rng)
+	}
+
+	populations := make(Generations, len(c.Populations))
+	for i, pop := range c.Populations {
+		popGen := Generation{
+			Id:      generation.Id,
+			TrialId: generation.TrialId,
+		}
+		genStartTime := time.Now()
+		if err := applyPlasticityRule(pop, c.plasticityRule); err != nil {
+			return err
+		}
+		if err := c.evaluator.GenerationEvaluate(ctx, pop, opponents[i], &popGen); err != nil {
+			return err
+		}
+		popGen.Executed = time.Now()
+		popGen.Duration = popGen.Executed.Sub(genStartTime)
+		populations[i] = popGen
+	}
+
+	// store champions into the halls of fame
+	for i, pop := range c.Populations {
+		champion := populations[i].Champion
+		if champion == nil {
+			champion = fittestOrganism(pop)
+		}
+		if err := c.HallsOfFame[i].Add(champion); err != nil {
+			return err
+		}
+	}
+
+	generation.FillPopulationsStatistics(populations)
+	return nil
+}
+
+func (c *Coevolution) nextEpoch(ctx context.Context, generation int) error {
+	for i, pop := range c.Populations {
+		if err := c.epochExecutors[i].NextEpoch(ctx, generation, pop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// competitor Returns the index of the population competing with the population with given index
+func (c *Coevolution) competitor(population int) int {
+	return (population + 1) % len(c.Populations)
+}
+
+// ExecuteCoevolution is to run the competitive coevolution experiment with populations spawned from the given start
+// genomes (see NewCoevolution) and the evaluator estimating fitness of organisms against opponents. The NEAT options
+// with coevolution options expected in the context.
+func (e *Experiment) ExecuteCoevolution(ctx context.Context, startGenomes []*genetics.Genome, evaluator CoevolutionEvaluator, trialObserver TrialRunObserver) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	if opts.Coevolution == nil {
+		return ErrCoevolutionOptionsNotFound
+	}
+	return e.execute(ctx, opts, func() (evolution, error) {
+		return NewCoevolution(ctx, startGenomes, evaluator)
+	}, trialObserver)
+}
+
+// fittestOrganism Returns the organism with the highest fitness in the population
+func fittestOrganism(pop *genetics.Population) *genetics.Organism {
+	var best *genetics.Organism
+	for _, org := range pop.Organisms {
+		if best == nil || org.Fitness > best.Fitness {
+			best = org
+		}
+	}
+	return best
+}
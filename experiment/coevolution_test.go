@@ -0,0 +1,152 @@
+package experiment
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"testing"
+)
+
+// complexityGameEvaluator scores each organism by the number of opponents with smaller genome
+type complexityGameEvaluator struct {
+	// The number of opponents received per call
+	opponents []int
+}
+
+func (c *complexityGameEvaluator) GenerationEvaluate(_ context.Context, pop *genetics.Population, opponents []*genetics.Organism, epoch *Generation) error {
+	c.opponents = append(c.opponents, len(opponents))
+	for _, org := range pop.Organisms {
+		wins := 0
+		for _, opponent := range opponents {
+			if org.Genotype.Complexity() > opponent.Genotype.Complexity() {
+				wins++
+			}
+		}
+		org.Fitness = float64(wins + 1)
+	}
+	epoch.FillPopulationStatistics(pop)
+	return nil
+}
+
+func readCoevolutionTestOptions(t *testing.T) *neat.Options {
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.NumRuns = 2
+	opts.NumGenerations = 5
+	opts.PopSize = 30
+	opts.Coevolution = &neat.CoevolutionOptions{
+		OpponentsCount:      4,
+		HallOfFameOpponents: 3,
+		HallOfFameSize:      3,
+	}
+	return opts
+}
+
+func TestNewCoevolution(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts := readCoevolutionTestOptions(t)
+	ctx := neat.NewContext(context.Background(), opts)
+
+	co, err := NewCoevolution(ctx, []*genetics.Genome{genome, genome}, &complexityGameEvaluator{})
+	require.NoError(t, err)
+	assert.Len(t, co.Populations, 2)
+	assert.Len(t, co.HallsOfFame, 2)
+	assert.Equal(t, 1, co.competitor(0))
+	assert.Equal(t, 0, co.competitor(1))
+
+	// opponents sampled from competing population only as hall of fame is empty
+	opponents := co.Opponents(0, neat.GlobalRand())
+	require.Len(t, opponents, opts.Coevolution.OpponentsCount)
+	for _, opponent := range opponents {
+		assert.Contains(t, co.Populations[1].Organisms, opponent)
+	}
+
+	// single population competes against itself
+	co, err = NewCoevolution(ctx, []*genetics.Genome{genome}, &complexityGameEvaluator{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, co.competitor(0))
+}
+
+func TestNewCoevolution_error(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts := readCoevolutionTestOptions(t)
+	ctx := neat.NewContext(context.Background(), opts)
+
+	_, err = NewCoevolution(ctx, []*genetics.Genome{}, &complexityGameEvaluator{})
+	assert.Error(t, err)
+	_, err = NewCoevolution(ctx, []*genetics.Genome{genome, genome, genome}, &complexityGameEvaluator{})
+	assert.Error(t, err)
+
+	_, err = NewCoevolution(context.Background(), []*genetics.Genome{genome}, &complexityGameEvaluator{})
+	assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+
+	opts.Coevolution = nil
+	_, err = NewCoevolution(ctx, []*genetics.Genome{genome}, &complexityGameEvaluator{})
+	assert.ErrorIs(t, err, ErrCoevolutionOptionsNotFound)
+}
+
+func TestExperiment_ExecuteCoevolution(t *testing.T) {
+	testCases := []struct {
+		name        string
+		populations int
+	}{
+		{name: "self_play", populations: 1},
+		{name: "two_populations", populations: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			genome, err := readTestGenome()
+			require.NoError(t, err, "failed to read XOR genome")
+			opts := readCoevolutionTestOptions(t)
+			ctx := neat.NewContext(context.Background(), opts)
+
+			startGenomes := make([]*genetics.Genome, tc.populations)
+			for i := range startGenomes {
+				startGenomes[i] = genome
+			}
+			evaluator := &complexityGameEvaluator{}
+			exp := Experiment{Id: 0}
+			err = exp.ExecuteCoevolution(ctx, startGenomes, evaluator, nil)
+			require.NoError(t, err, "failed to execute coevolution")
+
+			assert.Len(t, exp.Trials, opts.NumRuns)
+			assert.EqualValues(t, opts.NumGenerations, exp.AvgGenerationsPerTrial())
+			require.Len(t, evaluator.opponents, opts.NumRuns*opts.NumGenerations*tc.populations)
+
+			// the hall of fame grows with each generation until full
+			for i, count := range evaluator.opponents {
+				generation := i / tc.populations % opts.NumGenerations
+				hofOpponents := generation
+				if hofOpponents > opts.Coevolution.HallOfFameOpponents {
+					hofOpponents = opts.Coevolution.HallOfFameOpponents
+				}
+				assert.Equal(t, opts.Coevolution.OpponentsCount+hofOpponents, count, "wrong opponents number at: %d", i)
+			}
+
+			for _, trial := range exp.Trials {
+				for _, generation := range trial.Generations {
+					assert.Len(t, generation.Populations, tc.populations)
+					assert.NotNil(t, generation.Champion)
+				}
+			}
+		})
+	}
+}
+
+func TestExperiment_ExecuteCoevolution_noOptions(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	exp := Experiment{Id: 0}
+
+	err = exp.ExecuteCoevolution(context.Background(), []*genetics.Genome{genome}, &complexityGameEvaluator{}, nil)
+	assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	err = exp.ExecuteCoevolution(opts.NeatContext(), []*genetics.Genome{genome}, &complexityGameEvaluator{}, nil)
+	assert.ErrorIs(t, err, ErrCoevolutionOptionsNotFound)
+}
@@ -0,0 +1,510 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/experiment"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultTimeout The default timeout of one organism evaluation by worker
+	DefaultTimeout = time.Minute
+	// DefaultRetries The default number of retries of failed organism evaluation
+	DefaultRetries = 3
+	// DefaultHeartbeatInterval The default interval between heartbeats of workers
+	DefaultHeartbeatInterval = 5 * time.Second
+	// DefaultHeartbeatTimeout The default timeout of one heartbeat request to the worker
+	DefaultHeartbeatTimeout = 5 * time.Second
+	// DefaultMaxMissedHeartbeats The default number of consecutive missed heartbeats to consider worker dead
+	DefaultMaxMissedHeartbeats = 3
+	// DefaultRetryDelay The default delay before worker takes next task after failed evaluation
+	DefaultRetryDelay = time.Second
+)
+
+var (
+	// ErrNoWorkers The error to be raised when no worker addresses provided
+	ErrNoWorkers = errors.New("no workers provided")
+	// ErrNoAliveWorkers The error to be raised when all workers are dead longer than the timeout of organism evaluation
+	ErrNoAliveWorkers = errors.New("no alive workers left")
+)
+
+// Options The options of distributed evaluation
+type Options struct {
+	// The type of data shipped to the workers, genome if omitted
+	Payload Payload
+	// The timeout of one organism evaluation by worker, DefaultTimeout if omitted
+	Timeout time.Duration
+	// The number of retries of organism evaluation failed due to network errors, timeouts, or dead worker.
+	// DefaultRetries if zero, no retries if negative.
+	Retries int
+	// The delay before worker takes next task after failed evaluation, so that failed task can be retried
+	// by other workers. DefaultRetryDelay if omitted.
+	RetryDelay time.Duration
+	// The interval between heartbeats of workers, DefaultHeartbeatInterval if omitted
+	HeartbeatInterval time.Duration
+	// The timeout of one heartbeat request to the worker, DefaultHeartbeatTimeout if omitted. The heartbeats are sent
+	// independently of evaluation requests, so that the worker busy with long evaluations is still considered alive.
+	HeartbeatTimeout time.Duration
+	// The number of consecutive missed heartbeats to consider worker dead, DefaultMaxMissedHeartbeats if omitted.
+	// The evaluations running by the dead worker are canceled and retried by other workers. If all workers stay dead
+	// longer than Timeout, the evaluation fails with ErrNoAliveWorkers.
+	MaxMissedHeartbeats int
+	// The number of organisms evaluated by each worker concurrently, one if omitted
+	Concurrency int
+	// The HTTP client to send requests to workers, http.DefaultClient if omitted
+	Client *http.Client
+}
+
+// Evaluator The generation evaluator which distributes evaluation of organisms among the worker processes. The workers
+// are expected to serve the Worker handler at provided base URLs, e.g. http://localhost:8080.
+type Evaluator struct {
+	// The base URLs of workers
+	Workers []string
+	// The options of distributed evaluation
+	Options Options
+}
+
+// NewEvaluator Creates new distributed evaluator with workers at provided base URLs. The zero values of options are
+// replaced with defaults.
+func NewEvaluator(workers []string, opts Options) (*Evaluator, error) {
+	if len(workers) == 0 {
+		return nil, ErrNoWorkers
+	}
+	if err := opts.Payload.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Payload == "" {
+		opts.Payload = PayloadGenome
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Retries == 0 {
+		opts.Retries = DefaultRetries
+	} else if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if opts.HeartbeatTimeout <= 0 {
+		opts.HeartbeatTimeout = DefaultHeartbeatTimeout
+	}
+	if opts.MaxMissedHeartbeats <= 0 {
+		opts.MaxMissedHeartbeats = DefaultMaxMissedHeartbeats
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	addresses := make([]string, len(workers))
+	for i, address := range workers {
+		addresses[i] = strings.TrimSuffix(address, "/")
+	}
+	return &Evaluator{Workers: addresses, Options: opts}, nil
+}
+
+func (e *Evaluator) GenerationEvaluate(ctx context.Context, pop *genetics.Population, epoch *experiment.Generation) error {
+	options, ok := neat.FromContext(ctx)
+	if !ok {
+		return neat.ErrNEATOptionsNotFound
+	}
+
+	if options.PlasticityRule != "" && e.Options.Payload == PayloadModel {
+		return errors.Wrap(network.ErrPlasticityNotSupported, "plastic networks can not be shipped as models")
+	}
+
+	// prepare evaluation requests
+	tasks := make([]*evaluationTask, len(pop.Organisms))
+	for i, org := range pop.Organisms {
+		body, err := e.encodeRequest(i, org, epoch.Id, options.PlasticityRule)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode organism [%d]", org.Genotype.Id)
+		}
+		tasks[i] = &evaluationTask{index: i, genomeId: org.Genotype.Id, body: body}
+	}
+
+	results, err := e.dispatch(ctx, tasks)
+	if err != nil {
+		return err
+	}
+
+	// apply results in order of organisms
+	winners := make([]bool, len(pop.Organisms))
+	for i, org := range pop.Organisms {
+		res := results[i]
+		org.Fitness = res.Fitness
+		org.Error = res.Error
+		winners[i] = res.Winner
+	}
+	epoch.FillWinner(pop, winners, options.PopSize)
+
+	// Fill statistics about current epoch
+	epoch.FillPopulationStatistics(pop)
+	return nil
+}
+
+// encodeRequest is to encode the request to evaluate given organism with provided plasticity rule of its phenotype
+func (e *Evaluator) encodeRequest(index int, org *genetics.Organism, generation int, rule neat.PlasticityRule) ([]byte, error) {
+	req := EvaluationRequest{
+		Index:          index,
+		GenomeId:       org.Genotype.Id,
+		Generation:     generation,
+		Payload:        e.Options.Payload,
+		PlasticityRule: rule,
+	}
+	var buf bytes.Buffer
+	if e.Options.Payload == PayloadModel {
+		phenotype, err := org.Phenotype()
+		if err != nil {
+			return nil, err
+		}
+		solver, err := phenotype.FastNetworkSolver()
+		if err != nil {
+			return nil, err
+		}
+		fmns, ok := solver.(*network.FastModularNetworkSolver)
+		if !ok {
+			return nil, errors.Errorf("unsupported network solver: %T", solver)
+		}
+		if err = fmns.WriteModel(&buf); err != nil {
+			return nil, err
+		}
+		req.Model = buf.Bytes()
+	} else {
+		writer, err := genetics.NewGenomeWriter(&buf, genetics.YAMLGenomeEncoding)
+		if err != nil {
+			return nil, err
+		}
+		if err = writer.WriteGenome(org.Genotype); err != nil {
+			return nil, err
+		}
+		req.Genome = buf.String()
+	}
+	return json.Marshal(&req)
+}
+
+// evaluationTask The task to evaluate one organism by the workers
+type evaluationTask struct {
+	// The index of organism in the population
+	index int
+	// The ID of genome of organism
+	genomeId int
+	// The encoded evaluation request
+	body []byte
+	// The number of failed attempts to evaluate
+	attempts int
+}
+
+// workerState The state of the worker tracked by heartbeats
+type workerState struct {
+	// The base URL of worker
+	address string
+	// The mutex to guard the state
+	mutex sync.Mutex
+	// The number of consecutive missed heartbeats
+	missed int
+	// The flag to indicate whether worker is dead
+	dead bool
+	// The context of requests to the worker, which is canceled when worker considered dead
+	ctx    context.Context
+	cancel context.CancelFunc
+	// The channel closed when the worker is revived after being dead
+	revived chan struct{}
+}
+
+// requestContext Returns the context of requests to the worker or the channel to wait for worker revival if it's dead
+func (w *workerState) requestContext() (context.Context, <-chan struct{}) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.dead {
+		return nil, w.revived
+	}
+	return w.ctx, nil
+}
+
+// heartbeat is to update the state of the worker by the result of heartbeat. Returns true if worker is alive.
+func (w *workerState) heartbeat(ctx context.Context, success bool, maxMissed int) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if success {
+		w.missed = 0
+		if w.dead {
+			neat.InfoLog(fmt.Sprintf("DISTRIBUTED: Worker [%s] is alive again", w.address))
+			w.dead = false
+			w.ctx, w.cancel = context.WithCancel(ctx)
+			close(w.revived)
+		}
+		return true
+	}
+	w.missed++
+	if !w.dead && w.missed >= maxMissed {
+		neat.WarnLog(fmt.Sprintf("DISTRIBUTED: Worker [%s] missed %d heartbeats and considered dead", w.address, w.missed))
+		w.dead = true
+		w.cancel()
+		w.revived = make(chan struct{})
+	}
+	return !w.dead
+}
+
+// dispatcher The dispatcher of evaluation tasks among workers for one generation
+type dispatcher struct {
+	evaluator *Evaluator
+	// The queue of tasks to be evaluated
+	tasks chan *evaluationTask
+	// The results of evaluation by index of organism
+	results []*EvaluationResponse
+	// The number of tasks pending evaluation
+	pending int32
+	// The context of dispatching, which is canceled when all tasks are done or failed
+	ctx    context.Context
+	cancel context.CancelFunc
+	// The first error which failed evaluation
+	err     error
+	errOnce sync.Once
+}
+
+// fail is to abort the evaluation with given error
+func (d *dispatcher) fail(err error) {
+	d.errOnce.Do(func() {
+		d.err = err
+		d.cancel()
+	})
+}
+
+// dispatch is to evaluate provided tasks by the workers and to collect the results
+func (e *Evaluator) dispatch(ctx context.Context, tasks []*evaluationTask) ([]*EvaluationResponse, error) {
+	d := &dispatcher{
+		evaluator: e,
+		tasks:     make(chan *evaluationTask, len(tasks)),
+		results:   make([]*EvaluationResponse, len(tasks)),
+	}
+	d.ctx, d.cancel = context.WithCancel(ctx)
+	defer d.cancel()
+
+	workers := make([]*workerState, len(e.Workers))
+	for i, address := range e.Workers {
+		workers[i] = &workerState{address: address, revived: make(chan struct{})}
+		workers[i].ctx, workers[i].cancel = context.WithCancel(d.ctx)
+	}
+
+	if len(tasks) == 0 {
+		return d.results, nil
+	}
+	d.pending = int32(len(tasks))
+	for _, task := range tasks {
+		d.tasks <- task
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.monitorHeartbeats(workers)
+	}()
+	for _, worker := range workers {
+		for i := 0; i < e.Options.Concurrency; i++ {
+			wg.Add(1)
+			go func(worker *workerState) {
+				defer wg.Done()
+				d.runWorker(worker)
+			}(worker)
+		}
+	}
+
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		d.fail(err)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return d.results, nil
+}
+
+// monitorHeartbeats is to periodically check that workers are alive until dispatching is done. The dispatching fails
+// if none of the workers is alive during the timeout of organism evaluation.
+func (d *dispatcher) monitorHeartbeats(workers []*workerState) {
+	opts := d.evaluator.Options
+	ticker := time.NewTicker(opts.HeartbeatInterval)
+	defer ticker.Stop()
+	lastAlive := time.Now()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		alive := make([]bool, len(workers))
+		var wg sync.WaitGroup
+		for i, worker := range workers {
+			wg.Add(1)
+			go func(i int, worker *workerState) {
+				defer wg.Done()
+				success := d.sendHeartbeat(worker.address) == nil
+				alive[i] = worker.heartbeat(d.ctx, success, opts.MaxMissedHeartbeats)
+			}(i, worker)
+		}
+		wg.Wait()
+
+		anyAlive := false
+		for _, a := range alive {
+			anyAlive = anyAlive || a
+		}
+		if anyAlive {
+			lastAlive = time.Now()
+		} else if time.Since(lastAlive) >= opts.Timeout {
+			d.fail(ErrNoAliveWorkers)
+			return
+		}
+	}
+}
+
+// sendHeartbeat is to send heartbeat request to the worker at given address
+func (d *dispatcher) sendHeartbeat(address string) error {
+	opts := d.evaluator.Options
+	ctx, cancel := context.WithTimeout(d.ctx, opts.HeartbeatTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address+HeartbeatPath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected heartbeat status: %s", resp.Status)
+	}
+	return nil
+}
+
+// runWorker is to send tasks from the queue to the worker until dispatching is done
+func (d *dispatcher) runWorker(worker *workerState) {
+	for {
+		var task *evaluationTask
+		select {
+		case <-d.ctx.Done():
+			return
+		case task = <-d.tasks:
+		}
+
+		workerCtx, revived := worker.requestContext()
+		if workerCtx == nil {
+			// return task to other workers and wait until this one revived
+			d.tasks <- task
+			select {
+			case <-d.ctx.Done():
+				return
+			case <-revived:
+				continue
+			}
+		}
+
+		res, err := d.evaluate(workerCtx, worker.address, task)
+		if err == nil {
+			d.results[task.index] = res
+			if atomic.AddInt32(&d.pending, -1) == 0 {
+				// all tasks done
+				d.cancel()
+			}
+			continue
+		}
+		if d.ctx.Err() != nil {
+			return
+		}
+		var fatal *fatalError
+		if errors.As(err, &fatal) {
+			d.fail(err)
+			return
+		}
+		task.attempts++
+		if task.attempts > d.evaluator.Options.Retries {
+			d.fail(errors.Wrapf(err, "failed to evaluate organism [%d] after %d attempts", task.genomeId, task.attempts))
+			return
+		}
+		neat.WarnLog(fmt.Sprintf("DISTRIBUTED: Retrying evaluation of organism [%d] after failure at worker [%s], reason: %s",
+			task.genomeId, worker.address, err))
+		d.tasks <- task
+
+		// the worker waits to give the task to other workers
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-time.After(d.evaluator.Options.RetryDelay):
+		}
+	}
+}
+
+// fatalError The error of evaluation that should not be retried
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fatalError) Unwrap() error {
+	return e.err
+}
+
+// evaluate is to send evaluation request of the task to the worker at given address
+func (d *dispatcher) evaluate(ctx context.Context, address string, task *evaluationTask) (*EvaluationResponse, error) {
+	opts := d.evaluator.Options
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+EvaluatePath, bytes.NewReader(task.body))
+	if err != nil {
+		return nil, &fatalError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		var res EvaluationResponse
+		if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			return nil, errors.Wrap(err, "failed to decode evaluation response")
+		}
+		if res.Index != task.index || res.GenomeId != task.genomeId {
+			return nil, &fatalError{err: errors.Errorf("unexpected evaluation response for organism [%d] at: %d, expected organism [%d] at: %d",
+				res.GenomeId, res.Index, task.genomeId, task.index)}
+		}
+		return &res, nil
+	case resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		// the request can not be processed by any worker
+		var res errorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&res)
+		return nil, &fatalError{err: errors.Errorf("worker [%s] failed to evaluate organism [%d], status: %s, reason: %s",
+			address, task.genomeId, resp.Status, res.Message)}
+	default:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, errors.Errorf("worker [%s] unexpected status: %s", address, resp.Status)
+	}
+}
@@ -0,0 +1,359 @@
+package distributed
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/experiment"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testEvaluate is the fitness function used by test workers
+func testEvaluate(_ context.Context, task *Task) (*Result, error) {
+	return &Result{Fitness: solverOutput(task.Solver), Error: 1}, nil
+}
+
+// solverOutput Returns the output of solver activated with fixed inputs
+func solverOutput(solver network.Solver) float64 {
+	if err := solver.LoadSensors([]float64{1.0, 0.0}); err != nil {
+		return -1
+	}
+	if _, err := solver.ForwardSteps(3); err != nil {
+		return -1
+	}
+	return solver.ReadOutputs()[0]
+}
+
+// startTestWorkers is to start in-process workers evaluating organisms by provided function. The workers are stopped
+// when test is finished.
+func startTestWorkers(t *testing.T, count int, evaluate EvaluateFunc) []string {
+	addresses := make([]string, count)
+	for i := range addresses {
+		server := httptest.NewServer(NewWorker(evaluate))
+		t.Cleanup(server.Close)
+		addresses[i] = server.URL
+	}
+	return addresses
+}
+
+// startTestServer is to start in-process server with provided handler, which is stopped when test is finished
+func startTestServer(t *testing.T, handler http.HandlerFunc) string {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// buildTestPopulation is to create test population with mutated genomes
+func buildTestPopulation(t *testing.T) (*genetics.Population, *neat.Options) {
+	opts, err := neat.ReadNeatOptionsFromFile("../../data/xor_test.neat.yml")
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.PopSize = 20
+
+	reader, err := genetics.NewGenomeReaderFromFile("../../data/xorstartgenes")
+	require.NoError(t, err, "failed to open genome file")
+	genome, err := reader.Read()
+	require.NoError(t, err, "failed to read genome")
+
+	ctx := neat.NewRandContext(opts.NeatContext(), neat.NewRand(42))
+	pop, err := genetics.NewPopulationContext(ctx, genome)
+	require.NoError(t, err, "failed to create population")
+	return pop, opts
+}
+
+func fastTestOptions() Options {
+	return Options{
+		Timeout:             time.Second,
+		RetryDelay:          time.Millisecond,
+		HeartbeatInterval:   50 * time.Millisecond,
+		HeartbeatTimeout:    time.Second,
+		MaxMissedHeartbeats: 5,
+	}
+}
+
+func TestNewEvaluator(t *testing.T) {
+	e, err := NewEvaluator([]string{"http://localhost:8080/"}, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://localhost:8080"}, e.Workers)
+	assert.Equal(t, PayloadGenome, e.Options.Payload)
+	assert.Equal(t, DefaultTimeout, e.Options.Timeout)
+	assert.Equal(t, DefaultRetries, e.Options.Retries)
+	assert.Equal(t, DefaultRetryDelay, e.Options.RetryDelay)
+	assert.Equal(t, DefaultHeartbeatInterval, e.Options.HeartbeatInterval)
+	assert.Equal(t, DefaultHeartbeatTimeout, e.Options.HeartbeatTimeout)
+	assert.Equal(t, DefaultMaxMissedHeartbeats, e.Options.MaxMissedHeartbeats)
+	assert.Equal(t, 1, e.Options.Concurrency)
+	assert.NotNil(t, e.Options.Client)
+
+	e, err = NewEvaluator([]string{"http://localhost:8080"}, Options{Retries: -1})
+	require.NoError(t, err)
+	assert.Zero(t, e.Options.Retries)
+
+	_, err = NewEvaluator(nil, Options{})
+	assert.ErrorIs(t, err, ErrNoWorkers)
+
+	_, err = NewEvaluator([]string{"http://localhost:8080"}, Options{Payload: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestEvaluator_GenerationEvaluate(t *testing.T) {
+	testCases := map[Payload]func(org *genetics.Organism) (network.Solver, error){
+		PayloadGenome: func(org *genetics.Organism) (network.Solver, error) {
+			return org.Phenotype()
+		},
+		PayloadModel: func(org *genetics.Organism) (network.Solver, error) {
+			phenotype, err := org.Phenotype()
+			if err != nil {
+				return nil, err
+			}
+			return phenotype.FastNetworkSolver()
+		},
+	}
+	for payload, localSolver := range testCases {
+		t.Run(string(payload), func(t *testing.T) {
+			pop, opts := buildTestPopulation(t)
+			opts2 := fastTestOptions()
+			opts2.Payload = payload
+			opts2.Concurrency = 2
+			evaluator, err := NewEvaluator(startTestWorkers(t, 3, testEvaluate), opts2)
+			require.NoError(t, err)
+
+			epoch := experiment.Generation{Id: 1}
+			err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+			require.NoError(t, err)
+
+			for _, org := range pop.Organisms {
+				solver, err := localSolver(org)
+				require.NoError(t, err)
+				assert.Equal(t, solverOutput(solver), org.Fitness, "wrong fitness of organism [%d]", org.Genotype.Id)
+				assert.Equal(t, 1.0, org.Error)
+			}
+			assert.False(t, epoch.Solved)
+			assert.NotNil(t, epoch.Champion)
+			assert.Equal(t, len(pop.Species), epoch.Diversity)
+		})
+	}
+}
+
+func TestEvaluator_GenerationEvaluate_winner(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	winnerId := pop.Organisms[len(pop.Organisms)/2].Genotype.Id
+	evaluate := func(ctx context.Context, task *Task) (*Result, error) {
+		if task.GenomeId == winnerId {
+			return &Result{Fitness: 100, Winner: true}, nil
+		}
+		return testEvaluate(ctx, task)
+	}
+	evaluator, err := NewEvaluator(startTestWorkers(t, 2, evaluate), fastTestOptions())
+	require.NoError(t, err)
+
+	epoch := experiment.Generation{Id: 1}
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	require.NoError(t, err)
+	assert.True(t, epoch.Solved)
+	require.NotNil(t, epoch.Champion)
+	assert.Equal(t, winnerId, epoch.Champion.Genotype.Id)
+	assert.True(t, epoch.Champion.IsWinner)
+	assert.Equal(t, opts.PopSize+winnerId, epoch.WinnerEvals)
+}
+
+func TestEvaluator_GenerationEvaluate_plasticity(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	opts.PlasticityRule = neat.PlasticityRuleHebbian
+	evaluate := func(ctx context.Context, task *Task) (*Result, error) {
+		phenotype, ok := task.Solver.(*network.Network)
+		if !ok || phenotype.PlasticityRule != neat.PlasticityRuleHebbian {
+			return nil, errors.New("plasticity rule is not applied")
+		}
+		return testEvaluate(ctx, task)
+	}
+	evaluator, err := NewEvaluator(startTestWorkers(t, 2, evaluate), fastTestOptions())
+	require.NoError(t, err)
+
+	epoch := experiment.Generation{Id: 1}
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	require.NoError(t, err)
+
+	// the plastic networks can not be shipped as models
+	opts2 := fastTestOptions()
+	opts2.Payload = PayloadModel
+	evaluator, err = NewEvaluator(startTestWorkers(t, 1, testEvaluate), opts2)
+	require.NoError(t, err)
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	assert.ErrorIs(t, err, network.ErrPlasticityNotSupported)
+}
+
+func TestNewTask_plasticity(t *testing.T) {
+	_, err := newTask(&EvaluationRequest{Payload: PayloadModel, PlasticityRule: neat.PlasticityRuleOja})
+	assert.ErrorIs(t, err, network.ErrPlasticityNotSupported)
+
+	_, err = newTask(&EvaluationRequest{Payload: PayloadGenome, PlasticityRule: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestEvaluator_GenerationEvaluate_retries(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	// the worker which fails first requests
+	var requests int32
+	flaky := NewWorker(testEvaluate)
+	address := startTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EvaluatePath && atomic.AddInt32(&requests, 1) <= 5 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		flaky.ServeHTTP(w, r)
+	})
+	opts2 := fastTestOptions()
+	evaluator, err := NewEvaluator([]string{address}, opts2)
+	require.NoError(t, err)
+
+	epoch := experiment.Generation{Id: 1}
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	require.NoError(t, err)
+	for _, org := range pop.Organisms {
+		assert.Equal(t, 1.0, org.Error, "organism [%d] not evaluated", org.Genotype.Id)
+	}
+	assert.True(t, atomic.LoadInt32(&requests) > int32(len(pop.Organisms)), "no retries made")
+
+	// no retries allowed
+	atomic.StoreInt32(&requests, 0)
+	opts2.Retries = -1
+	evaluator, err = NewEvaluator([]string{address}, opts2)
+	require.NoError(t, err)
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	assert.Error(t, err)
+}
+
+func TestEvaluator_GenerationEvaluate_deadWorker(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	// the worker which hangs on evaluation and doesn't respond to heartbeats
+	var hanging int32
+	dead := startTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EvaluatePath {
+			atomic.AddInt32(&hanging, 1)
+			_, _ = io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	opts2 := fastTestOptions()
+	// the heartbeats should detect dead worker long before timeout
+	opts2.Timeout = time.Minute
+	opts2.Retries = len(pop.Organisms)
+	evaluator, err := NewEvaluator(append(startTestWorkers(t, 1, testEvaluate), dead), opts2)
+	require.NoError(t, err)
+
+	epoch := experiment.Generation{Id: 1}
+	start := time.Now()
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	require.NoError(t, err)
+	assert.True(t, time.Since(start) < opts2.Timeout, "dead worker not detected by heartbeats")
+	assert.NotZero(t, atomic.LoadInt32(&hanging), "dead worker got no tasks")
+	for _, org := range pop.Organisms {
+		assert.Equal(t, 1.0, org.Error, "organism [%d] not evaluated", org.Genotype.Id)
+	}
+}
+
+func TestEvaluator_GenerationEvaluate_noAliveWorkers(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	server := httptest.NewServer(NewWorker(testEvaluate))
+	server.Close()
+
+	opts2 := fastTestOptions()
+	opts2.Retries = 1000
+	evaluator, err := NewEvaluator([]string{server.URL}, opts2)
+	require.NoError(t, err)
+
+	epoch := experiment.Generation{Id: 1}
+	start := time.Now()
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	assert.ErrorIs(t, err, ErrNoAliveWorkers)
+	assert.True(t, time.Since(start) >= opts2.Timeout, "failed before timeout")
+}
+
+func TestEvaluator_GenerationEvaluate_workerRevived(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	// the only worker which is down for a while shorter than timeout
+	var down int32 = 1
+	worker := NewWorker(testEvaluate)
+	address := startTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		worker.ServeHTTP(w, r)
+	})
+	opts2 := fastTestOptions()
+	opts2.Timeout = 5 * time.Second
+	opts2.Retries = 1000
+	opts2.RetryDelay = 10 * time.Millisecond
+	evaluator, err := NewEvaluator([]string{address}, opts2)
+	require.NoError(t, err)
+
+	// bring the worker up after it considered dead
+	time.AfterFunc(opts2.HeartbeatInterval*time.Duration(opts2.MaxMissedHeartbeats+2), func() {
+		atomic.StoreInt32(&down, 0)
+	})
+
+	epoch := experiment.Generation{Id: 1}
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	require.NoError(t, err)
+	for _, org := range pop.Organisms {
+		assert.Equal(t, 1.0, org.Error, "organism [%d] not evaluated", org.Genotype.Id)
+	}
+}
+
+func TestEvaluator_GenerationEvaluate_evaluationError(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	var calls int32
+	evaluate := func(_ context.Context, _ *Task) (*Result, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("simulation failed")
+	}
+	evaluator, err := NewEvaluator(startTestWorkers(t, 1, evaluate), fastTestOptions())
+	require.NoError(t, err)
+
+	epoch := experiment.Generation{Id: 1}
+	err = evaluator.GenerationEvaluate(opts.NeatContext(), pop, &epoch)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "simulation failed")
+	// the evaluation errors are not retried
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestEvaluator_GenerationEvaluate_canceled(t *testing.T) {
+	pop, opts := buildTestPopulation(t)
+	evaluate := func(ctx context.Context, _ *Task) (*Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	evaluator, err := NewEvaluator(startTestWorkers(t, 1, evaluate), fastTestOptions())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(opts.NeatContext(), 50*time.Millisecond)
+	defer cancel()
+	epoch := experiment.Generation{Id: 1}
+	err = evaluator.GenerationEvaluate(ctx, pop, &epoch)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// no NEAT options in context
+	err = evaluator.GenerationEvaluate(context.Background(), pop, &epoch)
+	assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+}
+
+func TestWorker_heartbeat(t *testing.T) {
+	address := startTestWorkers(t, 1, testEvaluate)[0]
+	resp, err := http.Get(address + HeartbeatPath)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
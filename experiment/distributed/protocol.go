@@ -0,0 +1,79 @@
+// Package distributed implements the evaluation of organisms by the worker processes, which can run locally or on
+// remote machines. The coordinator ships genomes or models of fast network solvers of organisms to the workers over
+// HTTP and collects the fitness results. The JSON messages of the worker protocol are defined in this file.
+package distributed
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+)
+
+const (
+	// EvaluatePath The path of worker endpoint to evaluate organism
+	EvaluatePath = "/evaluate"
+	// HeartbeatPath The path of worker endpoint to check that worker is alive
+	HeartbeatPath = "/heartbeat"
+)
+
+// Payload The type of data shipped to the workers to evaluate organism
+type Payload string
+
+const (
+	// PayloadGenome The genome of organism encoded as YAML, which is used by the worker to restore the organism
+	PayloadGenome Payload = "genome"
+	// PayloadModel The model of the fast network solver built from the phenotype of organism
+	PayloadModel Payload = "model"
+)
+
+// Validate is to check if this payload type is supported. The empty value is considered as genome payload.
+func (p Payload) Validate() error {
+	if p != "" && p != PayloadGenome && p != PayloadModel {
+		return errors.Errorf("unsupported payload type: [%s]", p)
+	}
+	return nil
+}
+
+// EvaluationRequest The request to evaluate one organism sent by the coordinator to the worker
+type EvaluationRequest struct {
+	// The index of organism in the population
+	Index int `json:"index"`
+	// The ID of genome of organism
+	GenomeId int `json:"genome_id"`
+	// The ID of generation where organism is evaluated
+	Generation int `json:"generation"`
+	// The type of payload
+	Payload Payload `json:"payload"`
+	// The YAML encoded genome if genome payload
+	Genome string `json:"genome,omitempty"`
+	// The model of fast network solver if model payload
+	Model json.RawMessage `json:"model,omitempty"`
+	// The rule of plastic update of the phenotype link weights, if any. It's supported only with genome payload.
+	PlasticityRule neat.PlasticityRule `json:"plasticity_rule,omitempty"`
+}
+
+// EvaluationResponse The response of the worker with the results of organism evaluation
+type EvaluationResponse struct {
+	// The index of organism in the population
+	Index int `json:"index"`
+	// The ID of genome of organism
+	GenomeId int `json:"genome_id"`
+	// The fitness of organism
+	Fitness float64 `json:"fitness"`
+	// The error value of organism
+	Error float64 `json:"error"`
+	// The flag to indicate whether organism solved the task
+	Winner bool `json:"winner"`
+}
+
+// HeartbeatResponse The response of the worker to the heartbeat request
+type HeartbeatResponse struct {
+	// The number of evaluations currently running by the worker
+	InFlight int `json:"in_flight"`
+}
+
+// errorResponse The response of the worker when request failed
+type errorResponse struct {
+	// The error message
+	Message string `json:"message"`
+}
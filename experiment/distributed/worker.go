@@ -0,0 +1,168 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// Task The evaluation task received by the worker
+type Task struct {
+	// The ID of genome of organism
+	GenomeId int
+	// The ID of generation where organism is evaluated
+	Generation int
+	// The organism restored from the genome, nil if model payload received
+	Organism *genetics.Organism
+	// The network solver to evaluate. It's the phenotype of organism if genome payload received, or the fast network
+	// solver restored from the model otherwise.
+	Solver network.Solver
+}
+
+// Result The result of organism evaluation
+type Result struct {
+	// The fitness of organism
+	Fitness float64
+	// The error value of organism
+	Error float64
+	// The flag to indicate whether organism solved the task
+	Winner bool
+}
+
+// EvaluateFunc The function to evaluate the organism of the task. The context is canceled when coordinator
+// aborts the request. If error returned, the coordinator fails evaluation of the whole generation.
+type EvaluateFunc func(ctx context.Context, task *Task) (*Result, error)
+
+// Worker The worker evaluating organisms sent by the coordinator. It implements http.Handler and can be served by
+// any HTTP server, e.g. http.ListenAndServe(address, worker).
+type Worker struct {
+	// The function to evaluate organisms
+	evaluate EvaluateFunc
+	// The number of evaluations currently running
+	inFlight int32
+	// The routing of requests to endpoints
+	mux *http.ServeMux
+}
+
+// NewWorker Creates new worker evaluating organisms by provided function
+func NewWorker(evaluate EvaluateFunc) *Worker {
+	w := &Worker{evaluate: evaluate, mux: http.NewServeMux()}
+	w.mux.HandleFunc(EvaluatePath, w.handleEvaluate)
+	w.mux.HandleFunc(HeartbeatPath, w.handleHeartbeat)
+	return w
+}
+
+func (w *Worker) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mux.ServeHTTP(rw, r)
+}
+
+// InFlight Returns the number of evaluations currently running by this worker
+func (w *Worker) InFlight() int {
+	return int(atomic.LoadInt32(&w.inFlight))
+}
+
+func (w *Worker) handleHeartbeat(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, &HeartbeatResponse{InFlight: w.InFlight()})
+}
+
+func (w *Worker) handleEvaluate(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(rw, http.StatusMethodNotAllowed, &errorResponse{Message: "only POST method allowed"})
+		return
+	}
+	atomic.AddInt32(&w.inFlight, 1)
+	defer atomic.AddInt32(&w.inFlight, -1)
+
+	var req EvaluationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(rw, http.StatusBadRequest, &errorResponse{Message: fmt.Sprintf("failed to decode request: %s", err)})
+		return
+	}
+	// consume the rest of request body, so that the request context is canceled if coordinator aborts the request
+	_, _ = io.Copy(io.Discard, r.Body)
+	task, err := newTask(&req)
+	if err != nil {
+		writeJSON(rw, http.StatusBadRequest, &errorResponse{Message: err.Error()})
+		return
+	}
+
+	res, err := w.evaluate(r.Context(), task)
+	if err != nil {
+		neat.WarnLog(fmt.Sprintf("WORKER: Failed to evaluate organism [%d], reason: %s", req.GenomeId, err))
+		writeJSON(rw, http.StatusUnprocessableEntity, &errorResponse{Message: err.Error()})
+		return
+	}
+	if res == nil {
+		writeJSON(rw, http.StatusUnprocessableEntity, &errorResponse{Message: "no evaluation result returned"})
+		return
+	}
+	writeJSON(rw, http.StatusOK, &EvaluationResponse{
+		Index:    req.Index,
+		GenomeId: req.GenomeId,
+		Fitness:  res.Fitness,
+		Error:    res.Error,
+		Winner:   res.Winner,
+	})
+}
+
+// newTask is to create the evaluation task by restoring the organism or the network solver from the request payload
+func newTask(req *EvaluationRequest) (*Task, error) {
+	if err := req.Payload.Validate(); err != nil {
+		return nil, err
+	}
+	if err := req.PlasticityRule.Validate(); err != nil {
+		return nil, err
+	}
+	task := &Task{
+		GenomeId:   req.GenomeId,
+		Generation: req.Generation,
+	}
+	if req.Payload == PayloadModel {
+		if req.PlasticityRule != "" {
+			return nil, network.ErrPlasticityNotSupported
+		}
+		solver, err := network.ReadFMNSModel(bytes.NewReader(req.Model))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read model")
+		}
+		task.Solver = solver
+		return task, nil
+	}
+
+	reader, err := genetics.NewGenomeReader(strings.NewReader(req.Genome), genetics.YAMLGenomeEncoding)
+	if err != nil {
+		return nil, err
+	}
+	genome, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read genome")
+	}
+	if task.Organism, err = genetics.NewOrganism(0, genome, req.Generation); err != nil {
+		return nil, err
+	}
+	phenotype, err := task.Organism.Phenotype()
+	if err != nil {
+		return nil, err
+	}
+	phenotype.PlasticityRule = req.PlasticityRule
+	task.Solver = phenotype
+	return task, nil
+}
+
+// writeJSON is to write the JSON encoded value as response with given status code
+func writeJSON(rw http.ResponseWriter, status int, value interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	if err := json.NewEncoder(rw).Encode(value); err != nil {
+		neat.WarnLog(fmt.Sprintf("WORKER: Failed to write response, reason: %s", err))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/gob"
 	"fmt"
 	"github.com/sbinet/npyio/npz"
+	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/genetics"
 	"gonum.org/v1/gonum/mat"
 	"io"
@@ -23,6 +24,16 @@ type Experiment struct {
 	// It is used to normalize fitness score value used in efficiency score calculation. If this value
 	// is not set the fitness score will not be normalized during efficiency score estimation.
 	MaxFitnessScore float64
+	// The NEAT options per island, if the island model is used. If not set, all islands use the copies of the NEAT
+	// options of the execution context.
+	IslandsOptions []*neat.Options
+	// The interval in generations between checkpoints of the evolved population, which can be used to resume the trial
+	// with Resume. The checkpoints are written only for the evolution of single population and only if CheckpointWriter
+	// is set.
+	CheckpointInterval int
+	// The function to create the writer of population checkpoint made after the epoch turnover in the given generation
+	// of the given trial. The writer is closed after checkpoint written.
+	CheckpointWriter func(trialId, generation int) (io.WriteCloser, error)
 }
 
 // AvgTrialDuration Calculates average duration of experiment's trial. Returns EmptyDuration for experiment with no trials.
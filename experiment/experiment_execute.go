@@ -3,119 +3,301 @@ package experiment
 import (
 	"context"
 	"fmt"
+	"github.com/pkg/errors"
 	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"io"
 	"time"
 )
 
-// Execute is to run specific experiment using provided startGenome and specific evaluator for each epoch of the experiment
+// evolution is the evolutionary process of one trial run, which can be a single population or populations of the
+// island model
+type evolution interface {
+	// evaluate is to evaluate current generation of organisms
+	evaluate(ctx context.Context, generation *Generation) error
+	// nextEpoch is to turnover the organisms to the next epoch
+	nextEpoch(ctx context.Context, generation int) error
+}
+
+// Execute is to run specific experiment using provided startGenome and specific evaluator for each epoch of the experiment.
+// If the island model options are set in the NEAT options, each trial evolves several populations exchanging their
+// most fit organisms, otherwise the single population is evolved.
 func (e *Experiment) Execute(ctx context.Context, startGenome *genetics.Genome, evaluator GenerationEvaluator, trialObserver TrialRunObserver) error {
 	opts, found := neat.FromContext(ctx)
 	if !found {
 		return neat.ErrNEATOptionsNotFound
 	}
 
+	return e.execute(ctx, opts, func() (evolution, error) {
+		if opts.IslandModel != nil {
+			model, err := NewIslandModel(ctx, startGenome, e.islandsOptions(opts), opts.IslandModel)
+			if err != nil {
+				return nil, err
+			}
+			return &islandModelEvolution{IslandModel: model, evaluator: evaluator}, nil
+		}
+		return newPopulationEvolution(ctx, startGenome, opts, evaluator)
+	}, trialObserver)
+}
+
+// Resume is to resume the trial of experiment from the population checkpoint written by
+// genetics.Population.WriteCheckpoint, e.g., with CheckpointWriter of this experiment. The population is evolved
+// using provided evaluator from the generation next to the one when checkpoint was made until the number of
+// generations set in the NEAT options is reached or the winner is found. If the context carries the random numbers
+// generator created by neat.NewRandSourceContext, its state is restored from the checkpoint as well, so that resumed
+// trial continues the same random sequence. The resumed trial is stored into the trials of this experiment under
+// given ID and holds only generations evaluated after resume. Only the evolution of single population can be
+// resumed.
+func (e *Experiment) Resume(ctx context.Context, checkpoint io.Reader, trialId int, evaluator GenerationEvaluator, trialObserver TrialRunObserver) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	if opts.IslandModel != nil {
+		return errors.New("resume of the island model evolution is not supported")
+	}
+	if trialId < 0 || trialId >= opts.NumRuns {
+		return errors.Errorf("trial ID: %d is out of range of trials: %d", trialId, opts.NumRuns)
+	}
+
+	neat.InfoLog("\n>>>>> Resuming population from checkpoint ")
+	pop, generationId, err := genetics.ReadCheckpoint(ctx, checkpoint)
+	if err != nil {
+		neat.InfoLog("Failed to read population checkpoint")
+		return err
+	}
+	neat.InfoLog(fmt.Sprintf("OK, generation: %d <<<<<", generationId))
+
+	epochExecutor, err := epochExecutorForContext(ctx)
+	if err != nil {
+		return err
+	}
+	evo := &populationEvolution{
+		population:     pop,
+		epochExecutor:  epochExecutor,
+		plasticityRule: opts.PlasticityRule,
+		evaluator:      evaluator,
+	}
+
 	if e.Trials == nil {
 		e.Trials = make(Trials, opts.NumRuns)
 	}
+	return e.executeTrial(ctx, opts, evo, trialId, generationId+1, trialObserver)
+}
 
-	for run := 0; run < opts.NumRuns; run++ {
-		trialStartTime := time.Now()
+// execute is to run the trials of experiment, where each trial evolves the organisms by the evolution created
+// by the provided factory
+func (e *Experiment) execute(ctx context.Context, opts *neat.Options, newEvolution func() (evolution, error), trialObserver TrialRunObserver) error {
+	if e.Trials == nil {
+		e.Trials = make(Trials, opts.NumRuns)
+	}
 
-		neat.InfoLog("\n>>>>> Spawning new population ")
-		pop, err := genetics.NewPopulation(startGenome, opts)
+	for run := 0; run < opts.NumRuns; run++ {
+		evo, err := newEvolution()
 		if err != nil {
-			neat.InfoLog("Failed to spawn new population from start genome")
 			return err
-		} else {
-			neat.InfoLog("OK <<<<<")
 		}
-		neat.InfoLog(">>>>> Verifying spawned population ")
-		_, err = pop.Verify()
-		if err != nil {
-			neat.ErrorLog("\n!!!!! Population verification failed !!!!!")
+		if err = e.executeTrial(ctx, opts, evo, run, 0, trialObserver); err != nil {
 			return err
-		} else {
-			neat.InfoLog("OK <<<<<")
 		}
+	}
 
-		// create appropriate population's epoch executor
-		epochExecutor, err := epochExecutorForContext(ctx)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		// start new trial
-		trial := Trial{
-			Id: run,
-		}
+// executeTrial is to run one trial of experiment, which evolves the organisms by the provided evolution starting
+// from the given generation
+func (e *Experiment) executeTrial(ctx context.Context, opts *neat.Options, evo evolution, run, startGeneration int, trialObserver TrialRunObserver) error {
+	trialStartTime := time.Now()
 
-		if trialObserver != nil {
-			trialObserver.TrialRunStarted(&trial) // optional
+	// start new trial
+	trial := Trial{
+		Id: run,
+	}
+
+	if trialObserver != nil {
+		trialObserver.TrialRunStarted(&trial) // optional
+	}
+
+	for generationId := startGeneration; generationId < opts.NumGenerations; generationId++ {
+		// check if context was canceled
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
 		}
 
-		for generationId := 0; generationId < opts.NumGenerations; generationId++ {
-			// check if context was canceled
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-			}
+		neat.InfoLog(fmt.Sprintf(">>>>> Generation:%3d\tRun: %d\n", generationId, run))
+		generation := Generation{
+			Id:      generationId,
+			TrialId: run,
+		}
+		genStartTime := time.Now()
+		err := evo.evaluate(ctx, &generation)
+		if err != nil {
+			neat.InfoLog(fmt.Sprintf("!!!!! Generation [%d] evaluation failed !!!!!\n", generationId))
+			return err
+		}
+		generation.Executed = time.Now()
 
-			neat.InfoLog(fmt.Sprintf(">>>>> Generation:%3d\tRun: %d\n", generationId, run))
-			generation := Generation{
-				Id:      generationId,
-				TrialId: run,
-			}
-			genStartTime := time.Now()
-			err = evaluator.GenerationEvaluate(ctx, pop, &generation)
+		// Turnover population of organisms to the next epoch if appropriate
+		if !generation.Solved {
+			neat.DebugLog(">>>>> start next generation")
+			err = evo.nextEpoch(ctx, generationId)
 			if err != nil {
-				neat.InfoLog(fmt.Sprintf("!!!!! Generation [%d] evaluation failed !!!!!\n", generationId))
+				neat.InfoLog(fmt.Sprintf("!!!!! Epoch execution failed in generation [%d] !!!!!\n", generationId))
 				return err
 			}
-			generation.Executed = time.Now()
-
-			// Turnover population of organisms to the next epoch if appropriate
-			if !generation.Solved {
-				neat.DebugLog(">>>>> start next generation")
-				err = epochExecutor.NextEpoch(ctx, generationId, pop)
-				if err != nil {
-					neat.InfoLog(fmt.Sprintf("!!!!! Epoch execution failed in generation [%d] !!!!!\n", generationId))
-					return err
-				}
+			if err = e.writeCheckpoint(ctx, evo, run, generationId); err != nil {
+				neat.InfoLog(fmt.Sprintf("!!!!! Checkpoint writing failed in generation [%d] !!!!!\n", generationId))
+				return err
 			}
+		}
+
+		// Set generation duration, which also includes preparation for the next epoch
+		generation.Duration = generation.Executed.Sub(genStartTime)
+		trial.Generations = append(trial.Generations, generation)
 
-			// Set generation duration, which also includes preparation for the next epoch
-			generation.Duration = generation.Executed.Sub(genStartTime)
-			trial.Generations = append(trial.Generations, generation)
+		// notify trial observer
+		if trialObserver != nil {
+			trialObserver.EpochEvaluated(&trial, &generation)
+		}
 
+		if generation.Solved {
+			// stop further evaluation if already solved
+			neat.InfoLog(fmt.Sprintf(">>>>> The winner organism found in [%d] generation, fitness: %f <<<<<\n",
+				generationId, generation.Champion.Fitness))
 			// notify trial observer
 			if trialObserver != nil {
-				trialObserver.EpochEvaluated(&trial, &generation)
-			}
-
-			if generation.Solved {
-				// stop further evaluation if already solved
-				neat.InfoLog(fmt.Sprintf(">>>>> The winner organism found in [%d] generation, fitness: %f <<<<<\n",
-					generationId, generation.Champion.Fitness))
-				// notify trial observer
-				if trialObserver != nil {
-					trialObserver.TrialRunFinished(&trial)
-				}
-				break
+				trialObserver.TrialRunFinished(&trial)
 			}
+			break
 		}
-		// holds trial duration
-		trial.Duration = time.Since(trialStartTime)
+	}
+	// holds trial duration
+	trial.Duration = time.Since(trialStartTime)
 
-		// store trial into experiment
-		e.Trials[run] = trial
+	// store trial into experiment
+	e.Trials[run] = trial
 
-		// notify trial observer
-		if trialObserver != nil {
-			trialObserver.TrialRunFinished(&trial)
-		}
+	// notify trial observer
+	if trialObserver != nil {
+		trialObserver.TrialRunFinished(&trial)
+	}
+	return nil
+}
+
+// writeCheckpoint is to write the checkpoint of the evolved population after the epoch turnover in given generation,
+// if checkpoints are enabled for this experiment and supported by the evolution
+func (e *Experiment) writeCheckpoint(ctx context.Context, evo evolution, run, generation int) error {
+	if e.CheckpointWriter == nil || e.CheckpointInterval <= 0 || (generation+1)%e.CheckpointInterval != 0 {
+		return nil
+	}
+	popEvo, ok := evo.(*populationEvolution)
+	if !ok {
+		return nil
+	}
+	w, err := e.CheckpointWriter(run, generation)
+	if err != nil {
+		return err
+	}
+	if err = popEvo.population.WriteCheckpoint(ctx, w, generation); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// islandsOptions Returns the NEAT options for each island of the island model. If the options per island are not set
+// for this experiment, each island gets its own copy of the provided options.
+func (e *Experiment) islandsOptions(opts *neat.Options) []*neat.Options {
+	if len(e.IslandsOptions) > 0 {
+		return e.IslandsOptions
+	}
+	islandsOptions := make([]*neat.Options, opts.IslandModel.IslandsCount)
+	for i := range islandsOptions {
+		islandOpts := *opts
+		islandsOptions[i] = &islandOpts
 	}
+	return islandsOptions
+}
+
+// populationEvolution is the evolution of the single population of organisms
+type populationEvolution struct {
+	// The evolved population
+	population *genetics.Population
+	// The executor of population epochs
+	epochExecutor genetics.PopulationEpochExecutor
+	// The plasticity rule of phenotypes
+	plasticityRule neat.PlasticityRule
+	// The evaluator of population generations
+	evaluator GenerationEvaluator
+}
+
+// newPopulationEvolution Creates new evolution of the single population spawned from the start genome
+func newPopulationEvolution(ctx context.Context, startGenome *genetics.Genome, opts *neat.Options, evaluator GenerationEvaluator) (*populationEvolution, error) {
+	pop, err := spawnPopulation(ctx, startGenome)
+	if err != nil {
+		return nil, err
+	}
+
+	// create appropriate population's epoch executor
+	epochExecutor, err := epochExecutorForContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &populationEvolution{
+		population:     pop,
+		epochExecutor:  epochExecutor,
+		plasticityRule: opts.PlasticityRule,
+		evaluator:      evaluator,
+	}, nil
+}
+
+func (p *populationEvolution) evaluate(ctx context.Context, generation *Generation) error {
+	if err := applyPlasticityRule(p.population, p.plasticityRule); err != nil {
+		return err
+	}
+	return p.evaluator.GenerationEvaluate(ctx, p.population, generation)
+}
 
+func (p *populationEvolution) nextEpoch(ctx context.Context, generation int) error {
+	return p.epochExecutor.NextEpoch(ctx, generation, p.population)
+}
+
+// spawnPopulation is to spawn new population from the start genome using the NEAT options and the random numbers
+// generator of the context and to verify it
+func spawnPopulation(ctx context.Context, startGenome *genetics.Genome) (*genetics.Population, error) {
+	neat.InfoLog("\n>>>>> Spawning new population ")
+	pop, err := genetics.NewPopulationContext(ctx, startGenome)
+	if err != nil {
+		neat.InfoLog("Failed to spawn new population from start genome")
+		return nil, err
+	} else {
+		neat.InfoLog("OK <<<<<")
+	}
+	neat.InfoLog(">>>>> Verifying spawned population ")
+	_, err = pop.Verify()
+	if err != nil {
+		neat.ErrorLog("\n!!!!! Population verification failed !!!!!")
+		return nil, err
+	} else {
+		neat.InfoLog("OK <<<<<")
+	}
+	return pop, nil
+}
+
+// applyPlasticityRule is to set the plasticity rule of link weights to the phenotypes of all organisms in the population
+func applyPlasticityRule(pop *genetics.Population, rule neat.PlasticityRule) error {
+	if rule == "" {
+		return nil
+	}
+	for _, org := range pop.Organisms {
+		phenotype, err := org.Phenotype()
+		if err != nil {
+			return err
+		}
+		phenotype.PlasticityRule = rule
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package experiment
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"io"
 	"testing"
 )
 
@@ -126,3 +128,67 @@ func TestExperiment_Execute_evaluation_error(t *testing.T) {
 	genEvaluator.AssertNumberOfCalls(t, "GenerationEvaluate", 1)
 	genEvaluator.AssertExpectations(t)
 }
+
+// checkpointBuffer is the in-memory population checkpoint
+type checkpointBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *checkpointBuffer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestExperiment_Resume(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.NumRuns = 1
+	opts.NumGenerations = 6
+
+	checkpoints := make(map[int]*checkpointBuffer)
+	exp := Experiment{
+		CheckpointInterval: 3,
+		CheckpointWriter: func(trialId, generation int) (io.WriteCloser, error) {
+			assert.Equal(t, 0, trialId)
+			checkpoints[generation] = &checkpointBuffer{}
+			return checkpoints[generation], nil
+		},
+	}
+	ctx := neat.NewRandSourceContext(neat.NewContext(context.Background(), opts), neat.NewSource(42))
+	err = exp.Execute(ctx, genome, &fitnessGenerationEvaluator{}, nil)
+	require.NoError(t, err, "failed to execute experiment")
+	require.Len(t, checkpoints, 2)
+	require.Contains(t, checkpoints, 2)
+	require.Contains(t, checkpoints, 5)
+	assert.True(t, checkpoints[2].closed)
+
+	// resume with differently seeded generator
+	resumed := Experiment{}
+	resumeCtx := neat.NewRandSourceContext(neat.NewContext(context.Background(), opts), neat.NewSource(1))
+	err = resumed.Resume(resumeCtx, checkpoints[2], 0, &fitnessGenerationEvaluator{}, nil)
+	require.NoError(t, err, "failed to resume experiment")
+
+	// check that resumed trial is identical to the rest of the original one
+	original := exp.Trials[0].Generations[3:]
+	generations := resumed.Trials[0].Generations
+	require.Len(t, generations, len(original))
+	for i, generation := range generations {
+		assert.Equal(t, original[i].Id, generation.Id)
+		assert.Equal(t, original[i].Diversity, generation.Diversity)
+		assert.Equal(t, original[i].Complexity, generation.Complexity)
+		assert.Equal(t, original[i].Age, generation.Age)
+		assert.Equal(t, original[i].Champion.Genotype.String(), generation.Champion.Genotype.String())
+	}
+}
+
+func TestExperiment_Resume_islandModel(t *testing.T) {
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.IslandModel = &neat.IslandModelOptions{IslandsCount: 2, MigrationInterval: 1}
+	exp := Experiment{}
+	err = exp.Resume(opts.NeatContext(), &bytes.Buffer{}, 0, &fitnessGenerationEvaluator{}, nil)
+	assert.Error(t, err)
+}
@@ -43,6 +43,10 @@ type Generation struct {
 
 	// The ID of Trial this Generation was evaluated in
 	TrialId int
+
+	// The generations of each population, if several populations are evolved, e.g. by the island model or by the
+	// competitive coevolution. It's not persisted by encoding.
+	Populations Generations
 }
 
 // FillPopulationStatistics Collects statistics about given population
@@ -69,6 +73,62 @@ func (g *Generation) FillPopulationStatistics(pop *genetics.Population) {
 	}
 }
 
+// FillPopulationsStatistics Collects statistics about given generations of several populations evolved together,
+// e.g. islands of the island model. The statistics of species are merged among all populations, the champion is
+// the best among champions of populations or the first found winner if any population was solved.
+func (g *Generation) FillPopulationsStatistics(populations Generations) {
+	g.Populations = populations
+	g.Diversity = 0
+	g.Age = make(Floats, 0)
+	g.Complexity = make(Floats, 0)
+	g.Fitness = make(Floats, 0)
+	for _, pop := range populations {
+		g.Age = append(g.Age, pop.Age...)
+		g.Complexity = append(g.Complexity, pop.Complexity...)
+		g.Fitness = append(g.Fitness, pop.Fitness...)
+		g.Diversity += pop.Diversity
+
+		if g.Solved {
+			continue
+		}
+		if pop.Solved {
+			g.Solved = true
+			g.Champion = pop.Champion
+			g.WinnerEvals = pop.WinnerEvals
+			g.WinnerNodes = pop.WinnerNodes
+			g.WinnerGenes = pop.WinnerGenes
+		} else if pop.Champion != nil && (g.Champion == nil || pop.Champion.Fitness > g.Champion.Fitness) {
+			g.Champion = pop.Champion
+		}
+	}
+}
+
+// FillWinner Sets the winner fields of this generation from the organisms of given population which solved the task,
+// as flagged by winners in order of organisms. Among several winners the one with the highest fitness becomes
+// the champion, replacing the champion set before, if any. The size of population is used to estimate the number
+// of evaluations spent to find the winner.
+func (g *Generation) FillWinner(pop *genetics.Population, winners []bool, popSize int) {
+	var best *genetics.Organism
+	for i, org := range pop.Organisms {
+		if winners[i] && (best == nil || org.Fitness > best.Fitness) {
+			best = org
+		}
+	}
+	if best == nil {
+		return
+	}
+	for i, org := range pop.Organisms {
+		if winners[i] {
+			org.IsWinner = true
+		}
+	}
+	g.Solved = true
+	g.WinnerNodes = len(best.Genotype.Nodes)
+	g.WinnerGenes = best.Genotype.Extrons()
+	g.WinnerEvals = popSize*g.Id + best.Genotype.Id
+	g.Champion = best
+}
+
 // Average the average fitness, age, and complexity among the best organisms of each species in the population
 // at the end of this epoch
 func (g *Generation) Average() (fitness, age, complexity float64) {
@@ -45,6 +45,40 @@ func TestGeneration_FillPopulationStatistics(t *testing.T) {
 	assert.Equal(t, maxFitness, gen.Champion.Fitness)
 }
 
+func TestGeneration_FillWinner(t *testing.T) {
+	rand.Seed(42)
+	pop, _ := buildTestPopulation(t)
+	first, second := pop.Organisms[3], pop.Organisms[7]
+	first.Fitness, second.Fitness = 10, 20
+	winners := make([]bool, len(pop.Organisms))
+	winners[3], winners[7] = true, true
+
+	gen := Generation{Id: 2}
+	gen.FillWinner(pop, winners, 100)
+	assert.True(t, gen.Solved)
+	assert.Equal(t, second, gen.Champion)
+	assert.True(t, second.IsWinner)
+	assert.Equal(t, 200+second.Genotype.Id, gen.WinnerEvals)
+	assert.Equal(t, len(second.Genotype.Nodes), gen.WinnerNodes)
+	assert.Equal(t, second.Genotype.Extrons(), gen.WinnerGenes)
+
+	// no winners
+	gen = Generation{Id: 2}
+	gen.FillWinner(pop, make([]bool, len(pop.Organisms)), 100)
+	assert.False(t, gen.Solved)
+	assert.Nil(t, gen.Champion)
+
+	// the champion set before is replaced by the winner even if more fit
+	champion := pop.Organisms[0]
+	champion.Fitness = 100
+	gen = Generation{Id: 2, Champion: champion}
+	gen.FillWinner(pop, winners, 100)
+	assert.True(t, gen.Solved)
+	assert.Equal(t, second, gen.Champion)
+	assert.Equal(t, 200+second.Genotype.Id, gen.WinnerEvals)
+	assert.False(t, champion.IsWinner)
+}
+
 func createGenerationWith(fitness Floats, ages Floats, complexities Floats) *Generation {
 	return &Generation{
 		Fitness:    fitness,
@@ -195,3 +229,39 @@ func buildTestGenome(id int) *genetics.Genome {
 
 	return genetics.NewGenome(id, traits, nodes, genes)
 }
+
+func TestGeneration_FillPopulationsStatistics(t *testing.T) {
+	populations := Generations{
+		*buildTestGeneration(1, 10.0),
+		*buildTestGeneration(2, 20.0),
+		*buildTestGeneration(3, 15.0),
+	}
+	populations[0].Fitness, populations[0].Age, populations[0].Complexity = Floats{10, 5}, Floats{1, 2}, Floats{3, 4}
+	populations[1].Fitness, populations[1].Age, populations[1].Complexity = Floats{20}, Floats{3}, Floats{5}
+	populations[2].Fitness, populations[2].Age, populations[2].Complexity = Floats{}, Floats{}, Floats{}
+	for i := range populations {
+		populations[i].Solved = false
+		populations[i].Diversity = len(populations[i].Fitness)
+	}
+
+	gen := Generation{Id: 1}
+	gen.FillPopulationsStatistics(populations)
+	assert.False(t, gen.Solved)
+	assert.Equal(t, populations[1].Champion, gen.Champion)
+	assert.Equal(t, Floats{10, 5, 20}, gen.Fitness)
+	assert.Equal(t, Floats{1, 2, 3}, gen.Age)
+	assert.Equal(t, Floats{3, 4, 5}, gen.Complexity)
+	assert.Equal(t, 3, gen.Diversity)
+	assert.Len(t, gen.Populations, 3)
+
+	// the first found winner is the champion
+	populations[2].Solved = true
+	populations[2].WinnerEvals, populations[2].WinnerNodes, populations[2].WinnerGenes = 100, 5, 7
+	gen = Generation{Id: 1}
+	gen.FillPopulationsStatistics(populations)
+	assert.True(t, gen.Solved)
+	assert.Equal(t, populations[2].Champion, gen.Champion)
+	assert.Equal(t, 100, gen.WinnerEvals)
+	assert.Equal(t, 5, gen.WinnerNodes)
+	assert.Equal(t, 7, gen.WinnerGenes)
+}
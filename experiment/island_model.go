@@ -0,0 +1,214 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"time"
+)
+
+// Island is the population of organisms evolving in isolation from the populations of other islands of the island
+// model except the periodic migration of the most fit organisms.
+type Island struct {
+	// The ID of the island
+	Id int
+	// The population of the island
+	Population *genetics.Population
+	// The NEAT options of the island
+	Options *neat.Options
+
+	// The executor of population epochs
+	epochExecutor genetics.PopulationEpochExecutor
+}
+
+// Context Returns the context carrying the NEAT options of this island derived from the given parent context
+func (i *Island) Context(ctx context.Context) context.Context {
+	return neat.NewContext(ctx, i.Options)
+}
+
+// IslandModel is the group of islands with populations evolving independently and periodically exchanging their most
+// fit organisms along the migration routes defined by the migration topology. All islands share the innovations, so
+// that the genes of migrants can be aligned with the genes of native organisms during crossover. The islands are
+// evaluated and turned over to the next epoch one after another in order of islands.
+type IslandModel struct {
+	// The islands of the model
+	Islands []*Island
+	// The options of the island model
+	Options *neat.IslandModelOptions
+
+	// The number of organisms evaluated by all islands in the previous generations
+	evaluations int
+}
+
+// NewIslandModel Creates new island model with populations spawned from the start genome using the random numbers
+// generator of the context. Each island is evolved with its own NEAT options from the given list, which should have
+// one item per island.
+func NewIslandModel(ctx context.Context, startGenome *genetics.Genome, islandsOptions []*neat.Options, opts *neat.IslandModelOptions) (*IslandModel, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if len(islandsOptions) != opts.IslandsCount {
+		return nil, errors.Errorf("number of islands options: %d doesn't match number of islands: %d",
+			len(islandsOptions), opts.IslandsCount)
+	}
+	for i, islandOpts := range islandsOptions {
+		if err := opts.ValidatePopulationSize(islandOpts.PopSize); err != nil {
+			return nil, errors.Wrapf(err, "invalid options of island: %d", i)
+		}
+		if islandOpts.KeepInnovations != islandsOptions[0].KeepInnovations {
+			return nil, errors.Errorf("islands sharing innovations must agree on keeping them, island: %d", i)
+		}
+	}
+	model := &IslandModel{
+		Islands: make([]*Island, opts.IslandsCount),
+		Options: opts,
+	}
+	for i, islandOpts := range islandsOptions {
+		island := &Island{
+			Id:      i,
+			Options: islandOpts,
+		}
+		pop, err := spawnPopulation(island.Context(ctx), startGenome)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to spawn population of island: %d", i)
+		}
+		if i > 0 {
+			pop.ShareInnovations(model.Islands[0].Population)
+		}
+		island.Population = pop
+		if island.epochExecutor, err = epochExecutorForContext(island.Context(ctx)); err != nil {
+			return nil, err
+		}
+		model.Islands[i] = island
+	}
+	return model, nil
+}
+
+// Migrate is to send copies of the most fit organisms of each island to the islands along the migration routes, where
+// they replace the least fit organisms. The organisms of all islands should be evaluated before.
+func (m *IslandModel) Migrate(ctx context.Context) error {
+	// collect migrants before any island received them to avoid re-emigration of just arrived organisms
+	migrants := make([][]*genetics.Organism, len(m.Islands))
+	for i := range m.Islands {
+		for _, source := range m.migrationSources(i) {
+			emigrants, err := m.Islands[source].Population.Emigrants(m.Options.MigrantsCount)
+			if err != nil {
+				return errors.Wrapf(err, "failed to select emigrants of island: %d", source)
+			}
+			migrants[i] = append(migrants[i], emigrants...)
+		}
+	}
+	for i, island := range m.Islands {
+		if err := island.Population.ReceiveMigrants(island.Context(ctx), migrants[i]); err != nil {
+			return errors.Wrapf(err, "failed to receive migrants at island: %d", i)
+		}
+		neat.DebugLog(fmt.Sprintf("ISLAND MODEL: Island [%d] received %d migrants", i, len(migrants[i])))
+	}
+	return nil
+}
+
+// islandModelEvolution is the evolution of populations of the island model evaluated by the generation evaluator
+type islandModelEvolution struct {
+	*IslandModel
+	// The evaluator of islands generations
+	evaluator GenerationEvaluator
+}
+
+func (i *islandModelEvolution) evaluate(ctx context.Context, generation *Generation) error {
+	return i.Evaluate(ctx, generation, i.evaluator)
+}
+
+func (i *islandModelEvolution) nextEpoch(ctx context.Context, generation int) error {
+	return i.NextEpoch(ctx, generation)
+}
+
+// migrationSources Returns the indexes of the islands sending their emigrants to the island with given index
+func (m *IslandModel) migrationSources(target int) []int {
+	count := len(m.Islands)
+	if m.Options.Topology == neat.MigrationTopologyFullyConnected {
+		sources := make([]int, 0, count-1)
+		for i := 0; i < count; i++ {
+			if i != target {
+				sources = append(sources, i)
+			}
+		}
+		return sources
+	}
+	// ring topology - the island receives emigrants from the previous island in the ring
+	return []int{(target - 1 + count) % count}
+}
+
+// Evaluate is to evaluate populations of all islands with given evaluator and to collect statistics of islands into
+// the given generation. If solved, the number of evaluations done before the winner was found is counted over all
+// islands, including the islands evaluated in the previous generations and before the island of the winner.
+func (m *IslandModel) Evaluate(ctx context.Context, generation *Generation, evaluator GenerationEvaluator) error {
+	islands := make(Generations, len(m.Islands))
+	for i, island := range m.Islands {
+		islandGen := Generation{
+			Id:      generation.Id,
+			TrialId: generation.TrialId,
+		}
+		genStartTime := time.Now()
+		if err := applyPlasticityRule(island.Population, island.Options.PlasticityRule); err != nil {
+			return err
+		}
+		if err := evaluator.GenerationEvaluate(island.Context(ctx), island.Population, &islandGen); err != nil {
+			return errors.Wrapf(err, "failed to evaluate population of island: %d", i)
+		}
+		islandGen.Executed = time.Now()
+		islandGen.Duration = islandGen.Executed.Sub(genStartTime)
+		islands[i] = islandGen
+	}
+	generation.FillPopulationsStatistics(islands)
+
+	if generation.Solved {
+		// count evaluations done by all islands before the winner was found
+		winnerEvals := m.evaluations
+		for i, islandGen := range islands {
+			organisms := m.Islands[i].Population.Organisms
+			if islandGen.Solved && islandGen.Champion == generation.Champion {
+				winnerEvals += organismIndex(organisms, generation.Champion)
+				break
+			}
+			winnerEvals += len(organisms)
+		}
+		generation.WinnerEvals = winnerEvals
+	}
+	for _, island := range m.Islands {
+		m.evaluations += len(island.Population.Organisms)
+	}
+	return nil
+}
+
+// organismIndex Returns the index of organism among given organisms or the number of organisms if not found
+func organismIndex(organisms []*genetics.Organism, organism *genetics.Organism) int {
+	for i, org := range organisms {
+		if org == organism {
+			return i
+		}
+	}
+	return len(organisms)
+}
+
+// NextEpoch is to migrate organisms between islands if appropriate and to turnover populations of all islands to the
+// next epoch
+func (m *IslandModel) NextEpoch(ctx context.Context, generation int) error {
+	if (generation+1)%m.Options.MigrationInterval == 0 && m.Options.MigrantsCount > 0 {
+		neat.InfoLog(fmt.Sprintf(">>>>> Migration between %d islands in generation: %d\n", len(m.Islands), generation))
+		if err := m.Migrate(ctx); err != nil {
+			return err
+		}
+	}
+	for i, island := range m.Islands {
+		if err := island.epochExecutor.NextEpoch(island.Context(ctx), generation, island.Population); err != nil {
+			return errors.Wrapf(err, "failed to execute epoch of island: %d", i)
+		}
+	}
+	// Remove the shared innovations of the current generation once all islands were turned over
+	if !m.Islands[0].Options.KeepInnovations {
+		m.Islands[0].Population.ResetInnovations()
+	}
+	return nil
+}
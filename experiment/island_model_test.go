@@ -0,0 +1,247 @@
+package experiment
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"testing"
+)
+
+// fitnessGenerationEvaluator assigns fitness to organisms by their index in the population
+type fitnessGenerationEvaluator struct{}
+
+func (f *fitnessGenerationEvaluator) GenerationEvaluate(_ context.Context, pop *genetics.Population, epoch *Generation) error {
+	for i, org := range pop.Organisms {
+		org.Fitness = float64(i + 1)
+	}
+	epoch.FillPopulationStatistics(pop)
+	return nil
+}
+
+func buildTestIslandModel(t *testing.T, topology neat.MigrationTopology) (*IslandModel, *neat.Options) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.PopSize = 20
+	opts.IslandModel = &neat.IslandModelOptions{
+		IslandsCount:      3,
+		MigrationInterval: 2,
+		MigrantsCount:     2,
+		Topology:          topology,
+	}
+	exp := Experiment{}
+	model, err := NewIslandModel(context.Background(), genome, exp.islandsOptions(opts), opts.IslandModel)
+	require.NoError(t, err, "failed to create island model")
+	return model, opts
+}
+
+func TestNewIslandModel(t *testing.T) {
+	model, opts := buildTestIslandModel(t, neat.MigrationTopologyRing)
+	require.Len(t, model.Islands, 3)
+	for i, island := range model.Islands {
+		assert.Equal(t, i, island.Id)
+		assert.Len(t, island.Population.Organisms, opts.PopSize)
+		assert.NotSame(t, opts, island.Options, "island should have own options")
+	}
+
+	// all islands share the innovation numbers
+	innovNum := model.Islands[0].Population.NextInnovationNumber()
+	assert.Equal(t, innovNum+1, model.Islands[1].Population.NextInnovationNumber())
+	assert.Equal(t, innovNum+2, model.Islands[2].Population.NextInnovationNumber())
+}
+
+func TestNewIslandModel_optionsMismatch(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	modelOpts := &neat.IslandModelOptions{
+		IslandsCount:      3,
+		MigrationInterval: 2,
+	}
+	_, err = NewIslandModel(context.Background(), genome, []*neat.Options{opts}, modelOpts)
+	assert.Error(t, err)
+}
+
+func TestNewIslandModel_invalidIslandsOptions(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.PopSize = 10
+	modelOpts := &neat.IslandModelOptions{
+		IslandsCount:      3,
+		MigrationInterval: 2,
+		MigrantsCount:     5,
+		Topology:          neat.MigrationTopologyFullyConnected,
+	}
+	opts.IslandModel = modelOpts
+	exp := Experiment{}
+	// too many migrants per island
+	_, err = NewIslandModel(context.Background(), genome, exp.islandsOptions(opts), modelOpts)
+	assert.Error(t, err)
+
+	// islands disagree on keeping shared innovations
+	modelOpts.MigrantsCount = 1
+	islandsOptions := exp.islandsOptions(opts)
+	islandsOptions[1].KeepInnovations = !islandsOptions[0].KeepInnovations
+	_, err = NewIslandModel(context.Background(), genome, islandsOptions, modelOpts)
+	assert.Error(t, err)
+}
+
+func TestIslandModel_NextEpoch_resetInnovations(t *testing.T) {
+	model, opts := buildTestIslandModel(t, neat.MigrationTopologyRing)
+	require.False(t, opts.KeepInnovations)
+	generation := &Generation{Id: 1}
+	err := model.Evaluate(context.Background(), generation, &fitnessGenerationEvaluator{})
+	require.NoError(t, err)
+	model.Islands[0].Population.StoreInnovation(*genetics.NewInnovationForLink(1, 4, 1000, 0.5, 1))
+
+	err = model.NextEpoch(context.Background(), 1)
+	require.NoError(t, err)
+	_, found := model.Islands[2].Population.FindLinkInnovation(1, 4, false)
+	assert.False(t, found, "shared innovations expected to be removed after all islands turned over")
+}
+
+func TestIslandModel_migrationSources(t *testing.T) {
+	model, _ := buildTestIslandModel(t, neat.MigrationTopologyRing)
+	assert.Equal(t, []int{2}, model.migrationSources(0))
+	assert.Equal(t, []int{0}, model.migrationSources(1))
+	assert.Equal(t, []int{1}, model.migrationSources(2))
+
+	model.Options.Topology = neat.MigrationTopologyFullyConnected
+	assert.Equal(t, []int{1, 2}, model.migrationSources(0))
+	assert.Equal(t, []int{0, 2}, model.migrationSources(1))
+	assert.Equal(t, []int{0, 1}, model.migrationSources(2))
+}
+
+func TestIslandModel_Migrate(t *testing.T) {
+	testCases := []struct {
+		topology neat.MigrationTopology
+		received int
+	}{
+		{topology: neat.MigrationTopologyRing, received: 2},
+		{topology: neat.MigrationTopologyFullyConnected, received: 4},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.topology), func(t *testing.T) {
+			model, opts := buildTestIslandModel(t, tc.topology)
+			evaluator := &fitnessGenerationEvaluator{}
+			for i, island := range model.Islands {
+				err := evaluator.GenerationEvaluate(context.Background(), island.Population, &Generation{})
+				require.NoError(t, err)
+				// make the best organisms of islands distinguishable
+				for _, org := range island.Population.Organisms {
+					org.Fitness += float64(100 * i)
+				}
+			}
+
+			err := model.Migrate(context.Background())
+			require.NoError(t, err)
+
+			for i, island := range model.Islands {
+				pop := island.Population
+				require.Len(t, pop.Organisms, opts.PopSize)
+				received := 0
+				for _, source := range model.migrationSources(i) {
+					best := float64(opts.PopSize + 100*source)
+					for _, org := range pop.Organisms {
+						if org.Fitness == best || org.Fitness == best-1 {
+							received++
+						}
+					}
+				}
+				assert.Equal(t, tc.received, received, "wrong number of migrants at island: %d", i)
+			}
+		})
+	}
+}
+
+// winnerGenerationEvaluator assigns fitness to organisms by their index in the population and marks the organism
+// with given index in the given population as winner starting from the given generation
+type winnerGenerationEvaluator struct {
+	fitnessGenerationEvaluator
+	winnerPop        *genetics.Population
+	winnerIndex      int
+	winnerGeneration int
+}
+
+func (w *winnerGenerationEvaluator) GenerationEvaluate(ctx context.Context, pop *genetics.Population, epoch *Generation) error {
+	if pop == w.winnerPop && epoch.Id >= w.winnerGeneration {
+		winner := pop.Organisms[w.winnerIndex]
+		winner.IsWinner = true
+		epoch.Solved = true
+		epoch.Champion = winner
+		epoch.WinnerEvals = 1000
+	}
+	return w.fitnessGenerationEvaluator.GenerationEvaluate(ctx, pop, epoch)
+}
+
+func TestIslandModel_Evaluate_winnerEvals(t *testing.T) {
+	model, opts := buildTestIslandModel(t, neat.MigrationTopologyRing)
+	evaluator := &winnerGenerationEvaluator{
+		winnerPop:        model.Islands[1].Population,
+		winnerIndex:      5,
+		winnerGeneration: 2,
+	}
+	for id := 0; id < 2; id++ {
+		generation := Generation{Id: id}
+		err := model.Evaluate(context.Background(), &generation, evaluator)
+		require.NoError(t, err, "failed to evaluate at: %d", id)
+		assert.False(t, generation.Solved)
+	}
+
+	generation := Generation{Id: 2}
+	err := model.Evaluate(context.Background(), &generation, evaluator)
+	require.NoError(t, err, "failed to evaluate")
+	require.True(t, generation.Solved)
+	assert.Equal(t, model.Islands[1].Population.Organisms[5], generation.Champion)
+	// two generations of all islands, the first island, and the organisms before the winner on its island
+	islandsCount := len(model.Islands)
+	assert.Equal(t, 2*islandsCount*opts.PopSize+opts.PopSize+5, generation.WinnerEvals)
+}
+
+func TestExperiment_Execute_islandModel(t *testing.T) {
+	exp := Experiment{
+		Id: 0,
+	}
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.NumRuns = 2
+	opts.NumGenerations = 10
+	opts.IslandModel = &neat.IslandModelOptions{
+		IslandsCount:      3,
+		MigrationInterval: 3,
+		MigrantsCount:     2,
+	}
+	ctx := neat.NewContext(context.Background(), opts)
+
+	genEvaluator := &MockedGenerationEvaluator{}
+	trialsObserver := &MockedTrialRunObserver{}
+
+	// setup expectations
+	genEvaluatorCallsNum := opts.NumRuns * opts.NumGenerations * opts.IslandModel.IslandsCount
+	genEvaluator.On("GenerationEvaluate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	trialsObserver.On("TrialRunStarted", mock.Anything).Return(nil)
+	trialsObserver.On("TrialRunFinished", mock.Anything).Return(nil)
+	trialsObserver.On("EpochEvaluated", mock.Anything, mock.Anything).Return(nil)
+
+	err = exp.Execute(ctx, genome, genEvaluator, trialsObserver)
+	require.NoError(t, err, "failed to execute experiment")
+	assert.Equal(t, opts.NumRuns, len(exp.Trials), "wrong number of trials collected")
+	assert.EqualValues(t, opts.NumGenerations, exp.AvgGenerationsPerTrial())
+	for _, trial := range exp.Trials {
+		for _, generation := range trial.Generations {
+			assert.Len(t, generation.Populations, opts.IslandModel.IslandsCount, "wrong number of islands statistics")
+		}
+	}
+
+	genEvaluator.AssertNumberOfCalls(t, "GenerationEvaluate", genEvaluatorCallsNum)
+	genEvaluator.AssertExpectations(t)
+}
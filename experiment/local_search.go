@@ -0,0 +1,48 @@
+package experiment
+
+import (
+	"context"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+)
+
+// LocalSearchEvaluator The generation evaluator, which trains phenotypes of all organisms in the population against
+// supervised dataset before delegating the fitness evaluation to the wrapped evaluator. The local search parameters
+// are taken from the NEAT options, if omitted the organisms are evaluated without training.
+type LocalSearchEvaluator struct {
+	// The supervised dataset to train phenotypes against
+	Dataset []network.TrainingSample
+	// The wrapped evaluator to assign fitness to the trained organisms
+	Evaluator GenerationEvaluator
+}
+
+// NewLocalSearchEvaluator Creates new local search evaluator with given dataset and wrapped fitness evaluator
+func NewLocalSearchEvaluator(dataset []network.TrainingSample, evaluator GenerationEvaluator) *LocalSearchEvaluator {
+	return &LocalSearchEvaluator{
+		Dataset:   dataset,
+		Evaluator: evaluator,
+	}
+}
+
+// GenerationEvaluate Trains phenotypes of organisms and evaluates the generation with the wrapped evaluator
+func (e *LocalSearchEvaluator) GenerationEvaluate(ctx context.Context, pop *genetics.Population, epoch *Generation) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	if opts.LocalSearch != nil {
+		for _, org := range pop.Organisms {
+			// check if execution was canceled
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if _, err := org.TrainPhenotype(e.Dataset, opts.LocalSearch); err != nil {
+				return err
+			}
+		}
+	}
+	return e.Evaluator.GenerationEvaluate(ctx, pop, epoch)
+}
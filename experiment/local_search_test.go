@@ -0,0 +1,73 @@
+package experiment
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"testing"
+)
+
+var xorTrainingDataset = []network.TrainingSample{
+	{Inputs: []float64{0, 0}, Outputs: []float64{0}},
+	{Inputs: []float64{0, 1}, Outputs: []float64{1}},
+	{Inputs: []float64{1, 0}, Outputs: []float64{1}},
+	{Inputs: []float64{1, 1}, Outputs: []float64{0}},
+}
+
+func TestLocalSearchEvaluator_GenerationEvaluate(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	opts.LocalSearch = &neat.LocalSearchOptions{LearningRate: 0.1, Epochs: 5, Lamarckian: true}
+	ctx := neat.NewContext(context.Background(), opts)
+
+	pop, err := genetics.NewPopulation(genome, opts)
+	require.NoError(t, err, "failed to create population")
+	weights := make([]float64, len(pop.Organisms))
+	for i, org := range pop.Organisms {
+		weights[i] = org.Genotype.Genes[0].Link.ConnectionWeight
+	}
+
+	genEvaluator := &MockedGenerationEvaluator{}
+	genEvaluator.On("GenerationEvaluate", ctx, pop, mock.Anything).Return(nil)
+
+	evaluator := NewLocalSearchEvaluator(xorTrainingDataset, genEvaluator)
+	err = evaluator.GenerationEvaluate(ctx, pop, &Generation{})
+	require.NoError(t, err)
+	genEvaluator.AssertExpectations(t)
+
+	// check that learned weights written back into genomes
+	for i, org := range pop.Organisms {
+		assert.NotEqual(t, weights[i], org.Genotype.Genes[0].Link.ConnectionWeight, "at: %d", i)
+	}
+}
+
+func TestLocalSearchEvaluator_GenerationEvaluate_noLocalSearch(t *testing.T) {
+	genome, err := readTestGenome()
+	require.NoError(t, err, "failed to read XOR genome")
+	opts, err := neat.ReadNeatOptionsFromFile(xorConfigPath)
+	require.NoError(t, err, "failed to read NEAT options")
+	ctx := neat.NewContext(context.Background(), opts)
+
+	pop, err := genetics.NewPopulation(genome, opts)
+	require.NoError(t, err, "failed to create population")
+
+	genEvaluator := &MockedGenerationEvaluator{}
+	genEvaluator.On("GenerationEvaluate", ctx, pop, mock.Anything).Return(nil)
+
+	evaluator := NewLocalSearchEvaluator(xorTrainingDataset, genEvaluator)
+	err = evaluator.GenerationEvaluate(ctx, pop, &Generation{})
+	require.NoError(t, err)
+	genEvaluator.AssertExpectations(t)
+}
+
+func TestLocalSearchEvaluator_GenerationEvaluate_noOptions(t *testing.T) {
+	evaluator := NewLocalSearchEvaluator(xorTrainingDataset, &MockedGenerationEvaluator{})
+	err := evaluator.GenerationEvaluate(context.Background(), nil, &Generation{})
+	assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+}
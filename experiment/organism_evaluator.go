@@ -0,0 +1,91 @@
+package experiment
+
+import (
+	"context"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// OrganismEvaluator the function to evaluate one organism within given execution context. It's expected to set the
+// fitness and the error value of the organism and to return true if organism solved the task. The context carries
+// the NEAT options and the stream of random numbers of the organism.
+type OrganismEvaluator func(ctx context.Context, organism *genetics.Organism) (winner bool, err error)
+
+// EvaluateOrganisms is to evaluate organisms of the population concurrently by the pool of workers using provided
+// organism evaluator. If the number of workers is not positive, the number of CPUs is used. The streams of random
+// numbers of organisms are derived from the generator of the context in order of organisms, and the results are
+// collected in order of organisms as well, so that the same seed gives the same results regardless of scheduling of
+// workers. After evaluation, the winner fields of the generation are set if any organism solved the task, and
+// the statistics of population are collected into it. The evaluation is stopped on the first error or when context
+// is canceled.
+func EvaluateOrganisms(ctx context.Context, pop *genetics.Population, epoch *Generation, evaluator OrganismEvaluator, workers int) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(pop.Organisms) {
+		workers = len(pop.Organisms)
+	}
+
+	// publish evaluation jobs
+	type evaluationJob struct {
+		index int
+		rng   *rand.Rand
+	}
+	rng := neat.RandFromContext(ctx)
+	jobs := make(chan evaluationJob, len(pop.Organisms))
+	for i := range pop.Organisms {
+		jobs <- evaluationJob{index: i, rng: neat.DeriveRand(rng)}
+	}
+	close(jobs)
+
+	evalCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var evalErr error
+	var errOnce sync.Once
+	winners := make([]bool, len(pop.Organisms))
+
+	// The wait group to wait for all workers
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if evalCtx.Err() != nil {
+					return
+				}
+				winner, err := evaluator(neat.NewRandContext(evalCtx, job.rng), pop.Organisms[job.index])
+				if err != nil {
+					errOnce.Do(func() {
+						evalErr = err
+						cancel()
+					})
+					return
+				}
+				winners[job.index] = winner
+			}
+		}()
+	}
+	wg.Wait()
+
+	if evalErr != nil {
+		return evalErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// find the winner in order of organisms
+	epoch.FillWinner(pop, winners, opts.PopSize)
+
+	// Fill statistics about current epoch
+	epoch.FillPopulationStatistics(pop)
+	return nil
+}
@@ -0,0 +1,92 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"testing"
+)
+
+func TestEvaluateOrganisms(t *testing.T) {
+	pop, _ := buildTestPopulation(t)
+	opts := &neat.Options{PopSize: len(pop.Organisms)}
+	winnerId := pop.Organisms[len(pop.Organisms)/2].Genotype.Id
+	evaluator := func(ctx context.Context, org *genetics.Organism) (bool, error) {
+		org.Fitness = neat.RandFromContext(ctx).Float64()
+		org.Error = 1.0
+		if org.Genotype.Id == winnerId {
+			org.Fitness = 100
+			return true, nil
+		}
+		return false, nil
+	}
+
+	// evaluate with different number of workers and check that results are the same for the same seed
+	var fitness []float64
+	for _, workers := range []int{1, 4, 0} {
+		epoch := Generation{Id: 2}
+		ctx := neat.NewRandContext(neat.NewContext(context.Background(), opts), neat.NewRand(42))
+		err := EvaluateOrganisms(ctx, pop, &epoch, evaluator, workers)
+		require.NoError(t, err, "failed with workers: %d", workers)
+
+		assert.True(t, epoch.Solved)
+		require.NotNil(t, epoch.Champion)
+		assert.Equal(t, winnerId, epoch.Champion.Genotype.Id)
+		assert.True(t, epoch.Champion.IsWinner)
+		assert.Equal(t, opts.PopSize*2+winnerId, epoch.WinnerEvals)
+		assert.Equal(t, len(pop.Species), epoch.Diversity)
+
+		orgFitness := make([]float64, len(pop.Organisms))
+		for i, org := range pop.Organisms {
+			assert.Equal(t, 1.0, org.Error, "organism not evaluated at: %d", i)
+			orgFitness[i] = org.Fitness
+		}
+		if fitness == nil {
+			fitness = orgFitness
+		} else {
+			assert.Equal(t, fitness, orgFitness, "different results with workers: %d", workers)
+		}
+	}
+}
+
+func TestEvaluateOrganisms_error(t *testing.T) {
+	pop, _ := buildTestPopulation(t)
+	opts := &neat.Options{PopSize: len(pop.Organisms)}
+	errEvaluation := errors.New("evaluation failed")
+	evaluator := func(ctx context.Context, org *genetics.Organism) (bool, error) {
+		if org.Genotype.Id == pop.Organisms[0].Genotype.Id {
+			return false, errEvaluation
+		}
+		return false, nil
+	}
+
+	epoch := Generation{}
+	err := EvaluateOrganisms(neat.NewContext(context.Background(), opts), pop, &epoch, evaluator, 4)
+	assert.ErrorIs(t, err, errEvaluation)
+
+	// no NEAT options in context
+	err = EvaluateOrganisms(context.Background(), pop, &epoch, evaluator, 4)
+	assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+}
+
+func TestEvaluateOrganisms_canceled(t *testing.T) {
+	pop, _ := buildTestPopulation(t)
+	opts := &neat.Options{PopSize: len(pop.Organisms)}
+	ctx, cancel := context.WithCancel(neat.NewContext(context.Background(), opts))
+	evaluated := 0
+	evaluator := func(ctx context.Context, org *genetics.Organism) (bool, error) {
+		// cancel after the first organism evaluated
+		evaluated++
+		cancel()
+		return false, nil
+	}
+
+	epoch := Generation{}
+	err := EvaluateOrganisms(ctx, pop, &epoch, evaluator, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, evaluated)
+	assert.Nil(t, epoch.Champion)
+}
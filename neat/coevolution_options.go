@@ -0,0 +1,30 @@
+package neat
+
+import "github.com/pkg/errors"
+
+// CoevolutionOptions The options of the competitive coevolution, where the fitness of organisms is estimated by
+// evaluating them against opponents sampled from the competing population and from the hall of fame of its past
+// champions.
+type CoevolutionOptions struct {
+	// The number of opponents sampled from the competing population to evaluate each generation against
+	OpponentsCount int `yaml:"opponents_count"`
+	// The number of opponents sampled from the hall of fame of the competing population
+	HallOfFameOpponents int `yaml:"hall_of_fame_opponents"`
+	// The maximal number of champions stored in the hall of fame, zero means unlimited archive
+	HallOfFameSize int `yaml:"hall_of_fame_size"`
+}
+
+// Validate is to check that coevolution options has valid values
+func (c *CoevolutionOptions) Validate() error {
+	if c.OpponentsCount < 0 || c.HallOfFameOpponents < 0 {
+		return errors.Errorf("coevolution opponents count must not be negative, but got population: %d, hall of fame: %d",
+			c.OpponentsCount, c.HallOfFameOpponents)
+	}
+	if c.OpponentsCount == 0 && c.HallOfFameOpponents == 0 {
+		return errors.New("coevolution requires at least one opponent either from population or from hall of fame")
+	}
+	if c.HallOfFameSize < 0 {
+		return errors.Errorf("hall of fame size must not be negative, but got: %d", c.HallOfFameSize)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package neat
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+const coevolutionOptionsYaml = `
+coevolution:
+  opponents_count: 5
+  hall_of_fame_opponents: 3
+  hall_of_fame_size: 50
+`
+
+func TestLoadYAMLOptions_Coevolution(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte(coevolutionOptionsYaml)...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err, "failed to load options")
+	checkNeatOptions(opts, t)
+
+	co := opts.Coevolution
+	require.NotNil(t, co)
+	assert.Equal(t, 5, co.OpponentsCount)
+	assert.Equal(t, 3, co.HallOfFameOpponents)
+	assert.Equal(t, 50, co.HallOfFameSize)
+}
+
+func TestCoevolutionOptions_Validate(t *testing.T) {
+	opts := CoevolutionOptions{
+		OpponentsCount: 5,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.OpponentsCount = -1
+	assert.Error(t, opts.Validate())
+	opts.OpponentsCount = 0
+
+	// no opponents at all
+	assert.Error(t, opts.Validate())
+
+	opts.HallOfFameOpponents = 2
+	assert.NoError(t, opts.Validate())
+
+	opts.HallOfFameSize = -1
+	assert.Error(t, opts.Validate())
+}
@@ -3,6 +3,7 @@ package neat
 import (
 	"context"
 	"errors"
+	"math/rand"
 )
 
 var ErrNEATOptionsNotFound = errors.New("NEAT options not found in the context")
@@ -16,6 +17,20 @@ type key int
 // instead of using this key directly.
 var neatOptionsKey key
 
+// randKey is the key for the random numbers generator in Contexts. It is unexported; clients use neat.NewRandContext
+// and neat.RandFromContext instead of using this key directly.
+var randKey key = 1
+
+// randSourceKey is the key for the source of random numbers generator in Contexts. It is unexported; clients use
+// neat.NewRandSourceContext and neat.RandSourceFromContext instead of using this key directly.
+var randSourceKey key = 2
+
+// randSource The source of random numbers along with the generator created from it
+type randSource struct {
+	src *Source
+	rng *rand.Rand
+}
+
 // NewContext returns a new Context that carries value of NEAT options.
 func NewContext(ctx context.Context, opts *Options) context.Context {
 	return context.WithValue(ctx, neatOptionsKey, opts)
@@ -26,3 +41,34 @@ func FromContext(ctx context.Context) (*Options, bool) {
 	u, ok := ctx.Value(neatOptionsKey).(*Options)
 	return u, ok
 }
+
+// NewRandContext returns a new Context that carries the random numbers generator.
+func NewRandContext(ctx context.Context, rng *rand.Rand) context.Context {
+	return context.WithValue(ctx, randKey, rng)
+}
+
+// RandFromContext returns the random numbers generator stored in ctx, if any. Otherwise, returns the generator
+// backed by the global generator of math/rand package.
+func RandFromContext(ctx context.Context) *rand.Rand {
+	if rng, ok := ctx.Value(randKey).(*rand.Rand); ok && rng != nil {
+		return rng
+	}
+	return globalRand
+}
+
+// NewRandSourceContext returns a new Context that carries the random numbers generator created from the given source.
+// The state of the source can be saved and restored, e.g., with population checkpoint.
+func NewRandSourceContext(ctx context.Context, src *Source) context.Context {
+	rng := rand.New(src)
+	ctx = context.WithValue(ctx, randSourceKey, randSource{src: src, rng: rng})
+	return NewRandContext(ctx, rng)
+}
+
+// RandSourceFromContext returns the source of the random numbers generator stored in ctx, if it was stored by
+// NewRandSourceContext and not replaced by another generator since.
+func RandSourceFromContext(ctx context.Context) (*Source, bool) {
+	if rs, ok := ctx.Value(randSourceKey).(randSource); ok && rs.rng == RandFromContext(ctx) {
+		return rs.src, true
+	}
+	return nil, false
+}
@@ -30,6 +30,9 @@ const (
 	goldGaussianMutator
 )
 
+// The number of attempts to sample the new activation function of the node different from the current one
+const activationMutationTries = 20
+
 // GenomeEncoding Defines format of Genome data encoding
 type GenomeEncoding byte
 
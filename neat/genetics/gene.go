@@ -15,6 +15,7 @@ import (
 // determine which organisms are compatible (i.e. in the same species).
 // A MutationNum gives a rough sense of how much mutation the gene has experienced since it originally appeared
 // (Since it was first innovated). In the current implementation the mutation number is the same as the weight.
+// A MutationPower is the self-adaptive step size of the link weight mutation evolved along with the weight.
 type Gene struct {
 	// The link between nodes
 	Link *network.Link
@@ -24,6 +25,9 @@ type Gene struct {
 	MutationNum float64
 	// If true the gene is enabled
 	IsEnabled bool
+	// The self-adaptive power of the link weight mutation. It is used only if self-adaptation is enabled by the NEAT
+	// options, and if zero, the global weight mutation power is used.
+	MutationPower float64
 }
 
 // NewGene Creates new Gene
@@ -39,8 +43,10 @@ func NewGeneWithTrait(trait *neat.Trait, weight float64, inNode, outNode *networ
 
 // NewGeneCopy Construct a gene off of another gene as a duplicate
 func NewGeneCopy(g *Gene, trait *neat.Trait, inNode, outNode *network.NNode) *Gene {
-	return NewConnectionGene(network.NewLinkWithTrait(trait, g.Link.ConnectionWeight, inNode, outNode, g.Link.IsRecurrent),
+	gene := NewConnectionGene(network.NewLinkWithTrait(trait, g.Link.ConnectionWeight, inNode, outNode, g.Link.IsRecurrent),
 		g.InnovationNum, g.MutationNum, true)
+	gene.MutationPower = g.MutationPower
+	return gene
 }
 
 // NewConnectionGene is to create new connection gene with provided link
@@ -16,6 +16,7 @@ func TestNewGeneCopy(t *testing.T) {
 	}
 	trait := &neat.Trait{Id: 1, Params: []float64{0.1, 0, 0, 0, 0, 0, 0, 0}}
 	g1 := NewGeneWithTrait(trait, 3.2, nodes[0], nodes[1], true, 42, 5.2)
+	g1.MutationPower = 0.7
 
 	// test
 	g := NewGeneCopy(g1, trait, nodes[0], nodes[1])
@@ -26,5 +27,6 @@ func TestNewGeneCopy(t *testing.T) {
 	assert.Equal(t, g1.InnovationNum, g.InnovationNum)
 	assert.Equal(t, g1.InnovationNum, g.InnovationNum)
 	assert.Equal(t, g1.MutationNum, g.MutationNum)
+	assert.Equal(t, g1.MutationPower, g.MutationPower)
 	assert.Equal(t, g1.IsEnabled, g.IsEnabled)
 }
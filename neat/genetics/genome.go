@@ -78,7 +78,7 @@ func newGenomeWithNodeIdMap(id int, traits []*neat.Trait, nodes []*network.NNode
 // This special constructor creates a Genome with in inputs, out outputs, n out of maxHidden hidden units, and random
 // connectivity.  If rec is true then recurrent connections will be included. The last input is a bias
 // link_prob is the probability of a link. The created genome is not modular.
-func newGenomeRand(newId, in, out, n, maxHidden int, recurrent bool, linkProb float64, opts *neat.Options) (*Genome, error) {
+func newGenomeRand(newId, in, out, n, maxHidden int, recurrent bool, linkProb float64, opts *neat.Options, rng *rand.Rand) (*Genome, error) {
 	totalNodes := in + out + maxHidden
 	matrixDim := totalNodes * totalNodes
 	// The connection matrix which will be randomized
@@ -104,7 +104,7 @@ func newGenomeRand(newId, in, out, n, maxHidden int, recurrent bool, linkProb fl
 
 	// Step through the connection matrix, randomly assigning bits
 	for count := 0; count < matrixDim; count++ {
-		cm[count] = rand.Float64() < linkProb
+		cm[count] = rng.Float64() < linkProb
 	}
 
 	// Build the input nodes
@@ -118,7 +118,7 @@ func newGenomeRand(newId, in, out, n, maxHidden int, recurrent bool, linkProb fl
 	// Build the hidden nodes
 	for i := in + 1; i <= in+n; i++ {
 		newNode := network.NewNNode(i, network.HiddenNeuron)
-		if activationType, err := opts.RandomNodeActivationType(); err != nil {
+		if activationType, err := opts.RandomNodeActivationTypeRng(rng); err != nil {
 			return nil, err
 		} else {
 			newNode.ActivationType = activationType
@@ -183,7 +183,7 @@ func newGenomeRand(newId, in, out, n, maxHidden int, recurrent bool, linkProb fl
 					}
 
 					// Create the gene
-					weight := float64(math.RandSign()) * rand.Float64()
+					weight := float64(math.RandSignRng(rng)) * rng.Float64()
 					gene := NewGeneWithTrait(newTrait, weight, inNode, outNode, flagRecurrent, int64(count), weight)
 
 					//Add the gene to the genome
@@ -268,6 +268,11 @@ func (g *Genome) Extrons() int {
 	return total
 }
 
+// Complexity Returns complexity of this genome which is sum of nodes count and genes count
+func (g *Genome) Complexity() int {
+	return len(g.Nodes) + len(g.Genes)
+}
+
 // IsEqual Tests if given genome is equal to this one genetically and phenotypically. This method will check that both
 // genomes has the same traits, nodes and genes.
 // If mismatch detected the error will be returned with mismatch details.
@@ -685,6 +690,16 @@ func (g *Genome) geneInsert(gene *Gene) {
 	g.Genes = geneInsert(g.Genes, gene)
 }
 
+func (g *Genome) nodeDelete(nodeId int) {
+	for i, n := range g.Nodes {
+		if n.Id == nodeId {
+			g.Nodes = append(g.Nodes[:i], g.Nodes[i+1:]...)
+			break
+		}
+	}
+	delete(g.nodeByIdMap, nodeId)
+}
+
 // Inserts a NNode into a given ordered list of NNodes in ascending order by NNode ID
 func nodeInsert(nodes []*network.NNode, n *network.NNode) []*network.NNode {
 	if n == nil {
@@ -11,16 +11,38 @@ import (
 // characterizing variables of their compatibility. The three variables represent PERCENT DISJOINT GENES,
 // PERCENT EXCESS GENES, MUTATIONAL DIFFERENCE WITHIN MATCHING GENES. So the formula for compatibility
 // is:  disjoint_coeff * pdg + excess_coeff * peg + mutdiff_coeff * mdmg
-// The three coefficients are global system parameters.
+// The three coefficients are global system parameters. The average difference between biases of matching nodes is
+// added to the compatibility with the mutdiff_coeff as well.
 // The bigger returned value the less compatible the genomes.
 //
 // Fully compatible genomes has 0.0 returned.
 func (g *Genome) compatibility(og *Genome, opts *neat.Options) float64 {
+	var comp float64
 	if opts.GenCompatMethod == neat.GenomeCompatibilityMethodLinear {
-		return g.compatLinear(og, opts)
+		comp = g.compatLinear(og, opts)
 	} else {
-		return g.compatFast(og, opts)
+		comp = g.compatFast(og, opts)
 	}
+	return comp + opts.MutdiffCoeff*g.biasDifference(og)
+}
+
+// The average difference between biases of the neuron nodes with the same ID in both genomes. Returns zero if
+// genomes have no matching neuron nodes.
+func (g *Genome) biasDifference(og *Genome) float64 {
+	diffTotal, numMatching := 0.0, 0
+	for _, node := range g.Nodes {
+		if !node.IsNeuron() {
+			continue
+		}
+		if oNode := og.NodeWithId(node.Id); oNode != nil {
+			diffTotal += math.Abs(node.Bias - oNode.Bias)
+			numMatching++
+		}
+	}
+	if numMatching == 0 {
+		return 0.0
+	}
+	return diffTotal / float64(numMatching)
 }
 
 // The compatibility checking method with linear performance depending on the size of the lognest genome in comparison.
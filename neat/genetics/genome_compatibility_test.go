@@ -41,6 +41,28 @@ func TestGenome_Compatibility_Linear(t *testing.T) {
 	assert.Equal(t, 2.0, comp)
 }
 
+func TestGenome_Compatibility_nodeBias(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	gnome2 := buildTestGenome(2)
+
+	for _, method := range []neat.GenomeCompatibilityMethod{neat.GenomeCompatibilityMethodLinear, neat.GenomeCompatibilityMethodFast} {
+		conf := neat.Options{
+			DisjointCoeff:   0.5,
+			ExcessCoeff:     0.5,
+			MutdiffCoeff:    0.5,
+			GenCompatMethod: method,
+		}
+		gnome1.Nodes[3].Bias, gnome2.Nodes[3].Bias = 0.0, 0.0
+		comp := gnome1.compatibility(gnome2, &conf)
+		assert.Equal(t, 0.0, comp, "not fully compatible")
+
+		// only the output node is neuron, thus average bias difference is 2.0
+		gnome1.Nodes[3].Bias, gnome2.Nodes[3].Bias = 1.5, -0.5
+		comp = gnome1.compatibility(gnome2, &conf)
+		assert.Equal(t, 1.0, comp, "wrong compatibility for method: %s", method)
+	}
+}
+
 func TestGenome_Compatibility_Fast(t *testing.T) {
 	//rand.Seed(42)
 	gnome1 := buildTestGenome(1)
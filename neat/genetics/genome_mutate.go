@@ -6,6 +6,7 @@ import (
 	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/math"
 	"github.com/yaricom/goNEAT/v4/neat/network"
+	gomath "math"
 	"math/rand"
 )
 
@@ -18,7 +19,7 @@ import (
 // 	(1) You can start minimally even in problems with many inputs and
 // 	(2) you don't need to know a priori what the important features of the domain are.
 // If all sensors already connected than do nothing.
-func (g *Genome) mutateConnectSensors(innovations InnovationsObserver, _ *neat.Options) (bool, error) {
+func (g *Genome) mutateConnectSensors(innovations InnovationsObserver, _ *neat.Options, rng *rand.Rand) (bool, error) {
 
 	if len(g.Genes) == 0 {
 		return false, errors.New("genome has no genes")
@@ -60,7 +61,7 @@ func (g *Genome) mutateConnectSensors(innovations InnovationsObserver, _ *neat.O
 	}
 
 	// pick randomly from disconnected sensors
-	sensor := disconnectedSensors[rand.Intn(len(disconnectedSensors))]
+	sensor := disconnectedSensors[rng.Intn(len(disconnectedSensors))]
 	// add new links to chosen sensor, avoiding redundancy
 	linkAdded := false
 	for _, output := range outputs {
@@ -75,27 +76,18 @@ func (g *Genome) mutateConnectSensors(innovations InnovationsObserver, _ *neat.O
 		if !found {
 			var gene *Gene
 			// Check to see if this innovation already occurred in the population
-			innovationFound := false
-			for _, inn := range innovations.Innovations() {
-				if inn.innovationType == newLinkInnType &&
-					inn.InNodeId == sensor.Id &&
-					inn.OutNodeId == output.Id &&
-					!inn.IsRecurrent {
-
-					gene = NewGeneWithTrait(g.Traits[inn.NewTraitNum], inn.NewWeight,
-						sensor, output, false, inn.InnovationNum, 0)
-
-					innovationFound = true
-					break
-				}
+			inn, innovationFound := innovations.FindLinkInnovation(sensor.Id, output.Id, false)
+			if innovationFound {
+				gene = NewGeneWithTrait(g.Traits[inn.NewTraitNum], inn.NewWeight,
+					sensor, output, false, inn.InnovationNum, 0)
 			}
 
 			// The innovation is totally novel
 			if !innovationFound {
 				// Choose a random trait
-				traitNum := rand.Intn(len(g.Traits))
+				traitNum := rng.Intn(len(g.Traits))
 				// Choose the new weight
-				newWeight := float64(math.RandSign()) * rand.Float64() * 10.0
+				newWeight := float64(math.RandSignRng(rng)) * rng.Float64() * 10.0
 				// read next innovation id
 				nextInnovId := innovations.NextInnovationNumber()
 
@@ -129,7 +121,7 @@ func (g *Genome) mutateConnectSensors(innovations InnovationsObserver, _ *neat.O
 
 // Mutate the genome by adding a new link between two random NNodes,
 // if NNodes are already connected, keep trying conf.NewLinkTries times
-func (g *Genome) mutateAddLink(innovations InnovationsObserver, generation int, opts *neat.Options) (bool, error) {
+func (g *Genome) mutateAddLink(innovations InnovationsObserver, generation int, opts *neat.Options, rng *rand.Rand) (bool, error) {
 	// If the phenotype does not exist, exit on false, print error
 	// Note: This should never happen - if it does there is a bug
 	if g.Phenotype == nil {
@@ -144,7 +136,7 @@ func (g *Genome) mutateAddLink(innovations InnovationsObserver, generation int,
 
 	// Decide whether to make link recurrent
 	doRecur := false
-	if rand.Float64() < opts.RecurOnlyProb {
+	if rng.Float64() < opts.RecurOnlyProb {
 		doRecur = true
 	}
 
@@ -170,22 +162,22 @@ func (g *Genome) mutateAddLink(innovations InnovationsObserver, generation int,
 			// 50% of prob to decide create a recurrent link (node X to node X)
 			// 50% of a normal link (node X to node Y)
 			loopRecur := false
-			if rand.Float64() > 0.5 {
+			if rng.Float64() > 0.5 {
 				loopRecur = true
 			}
 			if loopRecur {
-				nodeNum1 = firstNonSensor + rand.Intn(nodesLen-firstNonSensor) // only NON SENSOR
+				nodeNum1 = firstNonSensor + rng.Intn(nodesLen-firstNonSensor) // only NON SENSOR
 				nodeNum2 = nodeNum1
 			} else {
 				for nodeNum1 == nodeNum2 {
-					nodeNum1 = rand.Intn(nodesLen)
-					nodeNum2 = firstNonSensor + rand.Intn(nodesLen-firstNonSensor) // only NON SENSOR
+					nodeNum1 = rng.Intn(nodesLen)
+					nodeNum2 = firstNonSensor + rng.Intn(nodesLen-firstNonSensor) // only NON SENSOR
 				}
 			}
 		} else {
 			for nodeNum1 == nodeNum2 {
-				nodeNum1 = rand.Intn(nodesLen)
-				nodeNum2 = firstNonSensor + rand.Intn(nodesLen-firstNonSensor) // only NON SENSOR
+				nodeNum1 = rng.Intn(nodesLen)
+				nodeNum2 = firstNonSensor + rng.Intn(nodesLen-firstNonSensor) // only NON SENSOR
 			}
 		}
 
@@ -245,27 +237,17 @@ func (g *Genome) mutateAddLink(innovations InnovationsObserver, generation int,
 	if node1 != nil && node2 != nil && found {
 		var gene *Gene
 		// Check to see if this innovation already occurred in the population
-		innovationFound := false
-		for _, inn := range innovations.Innovations() {
-			// match the innovation in the innovations list
-			if inn.innovationType == newLinkInnType &&
-				inn.InNodeId == node1.Id &&
-				inn.OutNodeId == node2.Id &&
-				inn.IsRecurrent == doRecur {
-
-				// Create new gene
-				gene = NewGeneWithTrait(g.Traits[inn.NewTraitNum], inn.NewWeight, node1, node2, doRecur, inn.InnovationNum, 0)
-
-				innovationFound = true
-				break
-			}
+		inn, innovationFound := innovations.FindLinkInnovation(node1.Id, node2.Id, doRecur)
+		if innovationFound {
+			// Create new gene
+			gene = NewGeneWithTrait(g.Traits[inn.NewTraitNum], inn.NewWeight, node1, node2, doRecur, inn.InnovationNum, 0)
 		}
 		// The innovation is totally novel
 		if !innovationFound {
 			// Choose a random trait
-			traitNum := rand.Intn(len(g.Traits))
+			traitNum := rng.Intn(len(g.Traits))
 			// Choose the new weight
-			newWeight := float64(math.RandSign()) * rand.Float64() * 10.0
+			newWeight := float64(math.RandSignRng(rng)) * rng.Float64() * 10.0
 			// read next innovation id
 			nextInnovId := innovations.NextInnovationNumber()
 
@@ -307,7 +289,7 @@ func (g *Genome) mutateAddLink(innovations InnovationsObserver, generation int,
 // The innovations list from population is used to compare the innovation with other innovations in the list and see
 // whether they match. If they do, the same innovation numbers will be assigned to the new genes. If a disabled link
 // is chosen, then the method just exits with false.
-func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator network.NodeIdGenerator, opts *neat.Options) (bool, error) {
+func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator network.NodeIdGenerator, opts *neat.Options, rng *rand.Rand) (bool, error) {
 	if len(g.Genes) == 0 {
 		return false, nil // it's possible to have such a network without any link
 	}
@@ -321,7 +303,7 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 	if len(g.Genes) < 15 {
 		for _, gn := range g.Genes {
 			// Now randomize which gene is chosen.
-			if gn.IsEnabled && gn.Link.InNode.NeuronType != network.BiasNeuron && rand.Float32() >= 0.3 {
+			if gn.IsEnabled && gn.Link.InNode.NeuronType != network.BiasNeuron && rng.Float32() >= 0.3 {
 				gene = gn
 				found = true
 				break
@@ -331,7 +313,7 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 		tryCount := 0
 		// Alternative uniform random choice of genes. When the genome is not tiny, it is safe to choose randomly.
 		for tryCount < 20 && !found {
-			geneNum := rand.Intn(len(g.Genes))
+			geneNum := rng.Intn(len(g.Genes))
 			gene = g.Genes[geneNum]
 			if gene.IsEnabled && gene.Link.InNode.NeuronType != network.BiasNeuron {
 				found = true
@@ -344,8 +326,6 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 		return false, nil
 	}
 
-	gene.IsEnabled = false
-
 	// Extract the link
 	link := gene.Link
 	// Extract the weight
@@ -363,33 +343,24 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 	var node *network.NNode
 
 	// Check to see if this innovation already occurred in the population
-	innovationFound := false
-	for _, inn := range innovations.Innovations() {
-		/* We check to see if an innovation already occurred that was:
-			-A new node
-			-Stuck between the same nodes as were chosen for this mutation
-			-Splitting the same gene as chosen for this mutation
-		If so, we know this mutation is not a novel innovation in this generation,
-		so we make it match the original, identical mutation which occurred
-		elsewhere in the population by coincidence */
-		if inn.innovationType == newNodeInnType &&
-			inn.InNodeId == inNode.Id &&
-			inn.OutNodeId == outNode.Id &&
-			inn.OldInnovNum == gene.InnovationNum {
-
-			// Create the new NNode
-			node = network.NewNNode(inn.NewNodeId, network.HiddenNeuron)
-			// By convention, it will point to the first trait
-			// Note: In future may want to change this
-			node.Trait = g.Traits[0]
-
-			// Create the new Genes
-			gene1 = NewGeneWithTrait(trait, 1.0, inNode, node, link.IsRecurrent, inn.InnovationNum, 0)
-			gene2 = NewGeneWithTrait(trait, oldWeight, node, outNode, false, inn.InnovationNum2, 0)
-
-			innovationFound = true
-			break
-		}
+	/* We check to see if an innovation already occurred that was:
+		-A new node
+		-Stuck between the same nodes as were chosen for this mutation
+		-Splitting the same gene as chosen for this mutation
+	If so, we know this mutation is not a novel innovation in this generation,
+	so we make it match the original, identical mutation which occurred
+	elsewhere in the population by coincidence */
+	inn, innovationFound := innovations.FindNodeInnovation(inNode.Id, outNode.Id, gene.InnovationNum)
+	if innovationFound {
+		// Create the new NNode
+		node = network.NewNNode(inn.NewNodeId, network.HiddenNeuron)
+		// By convention, it will point to the first trait
+		// Note: In future may want to change this
+		node.Trait = g.Traits[0]
+
+		// Create the new Genes
+		gene1 = NewGeneWithTrait(trait, 1.0, inNode, node, link.IsRecurrent, inn.InnovationNum, 0)
+		gene2 = NewGeneWithTrait(trait, oldWeight, node, outNode, false, inn.InnovationNum2, 0)
 	}
 	// The innovation is totally novel
 	if !innovationFound {
@@ -401,7 +372,7 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 		// By convention, it will point to the first trait
 		node.Trait = g.Traits[0]
 		// Set node activation function as random from a list of types registered with opts
-		if activationType, err := opts.RandomNodeActivationType(); err != nil {
+		if activationType, err := opts.RandomNodeActivationTypeRng(rng); err != nil {
 			return false, err
 		} else {
 			node.ActivationType = activationType
@@ -433,8 +404,10 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 		return false, nil
 	}
 
-	// Now add the new NNode and new Genes to the Genome
+	// Now add the new NNode and new Genes to the Genome. The split gene is disabled only now, so that it stays
+	// enabled if the mutation was skipped, e.g. when the gene re-enabled after split earlier is split again.
 	if node != nil && gene1 != nil && gene2 != nil {
+		gene.IsEnabled = false
 		g.geneInsert(gene1)
 		g.geneInsert(gene2)
 		g.nodeInsert(node)
@@ -444,16 +417,43 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 	return false, nil
 }
 
+// Adapts the mutation power of each gene by log-normal rule: sigma' = sigma * exp(tau * N(0, 1)) and after that
+// mutates link weights using adapted mutation powers. The genes without mutation power get the global one as the
+// initial value. The adapted mutation powers are kept within bounds defined by the NEAT options.
+func (g *Genome) mutateLinkWeightsAdaptive(opts *neat.Options, rng *rand.Rand) (bool, error) {
+	if len(g.Genes) == 0 {
+		return false, errors.New("genome has no genes")
+	}
+	tau := opts.WeightMutPowerTau
+	if tau == 0 {
+		tau = 1.0 / gomath.Sqrt(float64(len(g.Genes)))
+	}
+	for _, gene := range g.Genes {
+		if gene.MutationPower <= 0 {
+			gene.MutationPower = opts.WeightMutPower
+		}
+		gene.MutationPower *= gomath.Exp(tau * rng.NormFloat64())
+		gene.MutationPower = gomath.Max(opts.WeightMutPowerMin, gomath.Min(gene.MutationPower, opts.WeightMutPowerMax))
+	}
+	return g.perturbLinkWeights(opts.WeightMutPower, true, 1.0, gaussianMutator, rng)
+}
+
 // Adds Gaussian noise to link weights either GAUSSIAN or COLD_GAUSSIAN (from zero).
 // The COLD_GAUSSIAN means ALL connection weights will be given completely new values
-func (g *Genome) mutateLinkWeights(power, rate float64, mutationType mutatorType) (bool, error) {
+func (g *Genome) mutateLinkWeights(power, rate float64, mutationType mutatorType, rng *rand.Rand) (bool, error) {
+	return g.perturbLinkWeights(power, false, rate, mutationType, rng)
+}
+
+// perturbLinkWeights is to mutate link weights as mutateLinkWeights does. If adaptive is true, the self-adaptive
+// mutation power of each gene is used instead of the global one when set.
+func (g *Genome) perturbLinkWeights(power float64, adaptive bool, rate float64, mutationType mutatorType, rng *rand.Rand) (bool, error) {
 	if len(g.Genes) == 0 {
 		return false, errors.New("genome has no genes")
 	}
 
 	// Once in a while really shake things up
 	severe := false
-	if rand.Float64() > 0.5 {
+	if rng.Float64() > 0.5 {
 		severe = true
 	}
 
@@ -477,7 +477,7 @@ func (g *Genome) mutateLinkWeights(power, rate float64, mutationType mutatorType
 			coldGaussPoint = 0.3 // Mutate the rest by replacement % of the time
 		} else {
 			// Half the time don't do any cold mutations
-			if rand.Float64() > 0.5 {
+			if rng.Float64() > 0.5 {
 				gaussPoint = 1.0 - rate
 				coldGaussPoint = gaussPoint - 0.1
 			} else {
@@ -486,9 +486,14 @@ func (g *Genome) mutateLinkWeights(power, rate float64, mutationType mutatorType
 			}
 		}
 
-		random := float64(math.RandSign()) * rand.Float64() * power
+		// The self-adaptive mutation power of the gene has precedence over the global one if enabled
+		genePower := power
+		if adaptive && gene.MutationPower > 0 {
+			genePower = gene.MutationPower
+		}
+		random := float64(math.RandSignRng(rng)) * rng.Float64() * genePower
 		if mutationType == gaussianMutator {
-			randChoice := rand.Float64()
+			randChoice := rng.Float64()
 			if randChoice > gaussPoint {
 				gene.Link.ConnectionWeight += random
 			} else if randChoice > coldGaussPoint {
@@ -508,30 +513,30 @@ func (g *Genome) mutateLinkWeights(power, rate float64, mutationType mutatorType
 }
 
 // Perturb params in one trait
-func (g *Genome) mutateRandomTrait(context *neat.Options) (bool, error) {
+func (g *Genome) mutateRandomTrait(context *neat.Options, rng *rand.Rand) (bool, error) {
 	if len(g.Traits) == 0 {
 		return false, errors.New("genome has no traits")
 	}
 	// Choose a random trait number
-	traitNum := rand.Intn(len(g.Traits))
+	traitNum := rng.Intn(len(g.Traits))
 
 	// Retrieve the trait and mutate it
-	g.Traits[traitNum].Mutate(context.TraitMutationPower, context.TraitParamMutProb)
+	g.Traits[traitNum].MutateRng(rng, context.TraitMutationPower, context.TraitParamMutProb)
 
 	return true, nil
 }
 
 // This chooses a random gene, extracts the link from it and re-points the link to a random trait
-func (g *Genome) mutateLinkTrait(times int) (bool, error) {
+func (g *Genome) mutateLinkTrait(times int, rng *rand.Rand) (bool, error) {
 	if len(g.Traits) == 0 || len(g.Genes) == 0 {
 		return false, errors.New("genome has either no traits od genes")
 	}
 	for loop := 0; loop < times; loop++ {
 		// Choose a random trait number
-		traitNum := rand.Intn(len(g.Traits))
+		traitNum := rng.Intn(len(g.Traits))
 
 		// Choose a random link number
-		geneNum := rand.Intn(len(g.Genes))
+		geneNum := rng.Intn(len(g.Genes))
 
 		// set the link to point to the new trait
 		g.Genes[geneNum].Link.Trait = g.Traits[traitNum]
@@ -541,16 +546,16 @@ func (g *Genome) mutateLinkTrait(times int) (bool, error) {
 }
 
 // This chooses a random node and re-points the node to a random trait specified number of times
-func (g *Genome) mutateNodeTrait(times int) (bool, error) {
+func (g *Genome) mutateNodeTrait(times int, rng *rand.Rand) (bool, error) {
 	if len(g.Traits) == 0 || len(g.Nodes) == 0 {
 		return false, errors.New("genome has either no traits or nodes")
 	}
 	for loop := 0; loop < times; loop++ {
 		// Choose a random trait number
-		traitNum := rand.Intn(len(g.Traits))
+		traitNum := rng.Intn(len(g.Traits))
 
 		// Choose a random node number
-		nodeNum := rand.Intn(len(g.Nodes))
+		nodeNum := rng.Intn(len(g.Nodes))
 
 		// set the node to point to the new trait
 		g.Nodes[nodeNum].Trait = g.Traits[traitNum]
@@ -558,14 +563,56 @@ func (g *Genome) mutateNodeTrait(times int) (bool, error) {
 	return true, nil
 }
 
+// This mutator perturbs the biases of all neuron nodes (hidden and output) of the Genome by adding a random value in
+// range [-power, power]. Returns false if Genome has no neuron nodes.
+func (g *Genome) mutateNodeBiases(power float64, rng *rand.Rand) (bool, error) {
+	mutated := false
+	for _, node := range g.Nodes {
+		if !node.IsNeuron() {
+			continue
+		}
+		node.Bias += float64(math.RandSignRng(rng)) * rng.Float64() * power
+		mutated = true
+	}
+	return mutated, nil
+}
+
+// This chooses a random hidden node and changes its activation function to another one sampled among activators
+// registered with options. Only a limited number of samples is made, and if all of them are the same as the current
+// activation function of the node, the method just exits with false.
+func (g *Genome) mutateNodeActivation(opts *neat.Options, rng *rand.Rand) (bool, error) {
+	hidden := make([]*network.NNode, 0)
+	for _, node := range g.Nodes {
+		if node.NeuronType == network.HiddenNeuron {
+			hidden = append(hidden, node)
+		}
+	}
+	if len(hidden) == 0 || len(opts.NodeActivators) < 2 {
+		// nothing to change
+		return false, nil
+	}
+	node := hidden[rng.Intn(len(hidden))]
+	for tries := 0; tries < activationMutationTries; tries++ {
+		activationType, err := opts.RandomNodeActivationTypeRng(rng)
+		if err != nil {
+			return false, err
+		}
+		if activationType != node.ActivationType {
+			node.ActivationType = activationType
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Toggle genes from enable ON to enable OFF or vice versa. Do it specified number of times.
-func (g *Genome) mutateToggleEnable(times int) (bool, error) {
+func (g *Genome) mutateToggleEnable(times int, rng *rand.Rand) (bool, error) {
 	if len(g.Genes) == 0 {
 		return false, errors.New("genome has no genes to toggle")
 	}
 	for loop := 0; loop < times; loop++ {
 		// Choose a random gene number
-		geneNum := rand.Intn(len(g.Genes))
+		geneNum := rng.Intn(len(g.Genes))
 
 		gene := g.Genes[geneNum]
 		if gene.IsEnabled {
@@ -597,38 +644,154 @@ func (g *Genome) mutateGeneReEnable() (bool, error) {
 	return true, nil
 }
 
-// Applies all non-structural mutations to this genome
-func (g *Genome) mutateAllNonstructural(context *neat.Options) (bool, error) {
-	res := false
-	var err error
-	if rand.Float64() < context.MutateRandomTraitProb {
-		// mutate random trait
-		res, err = g.mutateRandomTrait(context)
+// This mutator removes a random link gene from the Genome. The disabled genes are removed first as they have no
+// influence on the phenotype. The hidden nodes left without any connection are removed as well. The innovation numbers
+// of removed genes and IDs of removed nodes are never reused, thus historical markings of the remaining genes stay
+// valid. The last gene of the Genome and the link which removal breaks off an output node from sensors are never removed.
+func (g *Genome) mutateDeleteLink(rng *rand.Rand) (bool, error) {
+	if len(g.Genes) <= 1 {
+		return false, nil
 	}
 
-	if err == nil && rand.Float64() < context.MutateLinkTraitProb {
-		// mutate link trait
-		res, err = g.mutateLinkTrait(1)
+	// First, try to find disabled genes
+	disabled := make([]*Gene, 0)
+	for _, gene := range g.Genes {
+		if !gene.IsEnabled {
+			disabled = append(disabled, gene)
+		}
+	}
+	var gene *Gene
+	if len(disabled) > 0 {
+		gene = disabled[rng.Intn(len(disabled))]
+	} else {
+		gene = g.Genes[rng.Intn(len(g.Genes))]
 	}
 
-	if err == nil && rand.Float64() < context.MutateNodeTraitProb {
-		// mutate node trait
-		res, err = g.mutateNodeTrait(1)
+	removed := map[*Gene]bool{gene: true}
+	if !g.outputsStayConnected(removed) {
+		// the removal will break off the output node
+		return false, nil
 	}
+	g.deleteGenes(removed)
+	return true, nil
+}
 
-	if err == nil && rand.Float64() < context.MutateLinkWeightsProb {
-		// mutate link weight
-		res, err = g.mutateLinkWeights(context.WeightMutPower, 1.0, gaussianMutator)
+// This mutator removes a random hidden node from the Genome together with all link genes connected to it. The
+// hidden nodes left without any connection after that are removed as well. The innovation numbers of removed genes
+// and IDs of removed nodes are never reused. If removal of the node will leave the Genome without genes or disconnect
+// the output node, the method just exits with false.
+func (g *Genome) mutateDeleteNode(rng *rand.Rand) (bool, error) {
+	hidden := make([]*network.NNode, 0)
+	for _, node := range g.Nodes {
+		if node.NeuronType == network.HiddenNeuron && !g.isControlledNode(node.Id) {
+			hidden = append(hidden, node)
+		}
 	}
+	if len(hidden) == 0 {
+		return false, nil
+	}
+	node := hidden[rng.Intn(len(hidden))]
 
-	if err == nil && rand.Float64() < context.MutateToggleEnableProb {
-		// mutate toggle enable
-		res, err = g.mutateToggleEnable(1)
+	// Find all genes connected to the node
+	removed := make(map[*Gene]bool)
+	for _, gene := range g.Genes {
+		if gene.Link.InNode.Id == node.Id || gene.Link.OutNode.Id == node.Id {
+			removed[gene] = true
+		}
+	}
+	if len(removed) >= len(g.Genes) || !g.outputsStayConnected(removed) {
+		return false, nil
 	}
+	g.deleteGenes(removed)
+	if g.haveNode(node.Id) {
+		// the node without any connections
+		g.nodeDelete(node.Id)
+	}
+	return true, nil
+}
 
-	if err == nil && rand.Float64() < context.MutateGeneReenableProb {
-		// mutate gene reenable
-		res, err = g.mutateGeneReEnable()
+// outputsStayConnected is to check that each output node reachable from sensors along enabled links stays reachable
+// after removal of provided genes. Otherwise, the output node will be broken off, even if it keeps incoming links from
+// hidden nodes left without inputs, and the phenotype network will fail to activate.
+func (g *Genome) outputsStayConnected(removed map[*Gene]bool) bool {
+	before := g.reachableFromSensors(nil)
+	after := g.reachableFromSensors(removed)
+	for _, node := range g.Nodes {
+		if node.NeuronType == network.OutputNeuron && before[node.Id] && !after[node.Id] {
+			return false
+		}
+	}
+	return true
+}
+
+// reachableFromSensors Returns the IDs of nodes reachable from the sensor nodes of this Genome along enabled links,
+// skipping provided removed genes
+func (g *Genome) reachableFromSensors(removed map[*Gene]bool) map[int]bool {
+	reachable := make(map[int]bool, len(g.Nodes))
+	queue := make([]int, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		if node.IsSensor() {
+			reachable[node.Id] = true
+			queue = append(queue, node.Id)
+		}
+	}
+	for len(queue) > 0 {
+		nodeId := queue[0]
+		queue = queue[1:]
+		for _, gene := range g.Genes {
+			if !gene.IsEnabled || removed[gene] || gene.Link.InNode.Id != nodeId {
+				continue
+			}
+			if outId := gene.Link.OutNode.Id; !reachable[outId] {
+				reachable[outId] = true
+				queue = append(queue, outId)
+			}
+		}
+	}
+	return reachable
+}
+
+// deleteGenes is to remove provided genes from this Genome along with the hidden nodes left without any connection
+func (g *Genome) deleteGenes(removed map[*Gene]bool) {
+	genes := make([]*Gene, 0, len(g.Genes))
+	for _, gene := range g.Genes {
+		if !removed[gene] {
+			genes = append(genes, gene)
+		}
+	}
+	g.Genes = genes
+
+	for gene := range removed {
+		for _, node := range []*network.NNode{gene.Link.InNode, gene.Link.OutNode} {
+			if node.NeuronType == network.HiddenNeuron && g.haveNode(node.Id) &&
+				!g.isConnectedNode(node.Id) && !g.isControlledNode(node.Id) {
+				g.nodeDelete(node.Id)
+			}
+		}
+	}
+}
+
+// isConnectedNode is to check whether any gene of this Genome connects the node with given ID
+func (g *Genome) isConnectedNode(nodeId int) bool {
+	for _, gene := range g.Genes {
+		if gene.Link.InNode.Id == nodeId || gene.Link.OutNode.Id == nodeId {
+			return true
+		}
+	}
+	return false
+}
+
+// isControlledNode is to check whether the node with given ID is referenced by any MIMO control gene of this Genome
+func (g *Genome) isControlledNode(nodeId int) bool {
+	for _, cg := range g.ControlGenes {
+		if cg.ControlNode.Id == nodeId {
+			return true
+		}
+		for _, n := range cg.ioNodes {
+			if n.Id == nodeId {
+				return true
+			}
+		}
 	}
-	return res, err
+	return false
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/math"
 	"github.com/yaricom/goNEAT/v4/neat/network"
+	gomath "math"
 	"math/rand"
 	"testing"
 )
@@ -22,14 +23,14 @@ func TestGenome_mutateAddLink(t *testing.T) {
 	}
 	// The population with one organism
 	pop := newPopulation()
-	err := pop.spawn(gnome1, context)
+	err := pop.spawn(context.NeatContext(), gnome1)
 	require.NoError(t, err, "failed to spawn population")
 
 	// Create gnome phenotype
 	_, err = gnome1.Genesis(1)
 	require.NoError(t, err, "genesis failed")
 
-	res, err := gnome1.mutateAddLink(pop, 1, context)
+	res, err := gnome1.mutateAddLink(pop, 1, context, neat.GlobalRand())
 	require.NoError(t, err, "failed to add link")
 	require.True(t, res, "New link not added")
 
@@ -52,7 +53,7 @@ func TestGenome_mutateAddLink(t *testing.T) {
 	_, err = gnome1.Genesis(1) // do network genesis with new nodes added
 	require.NoError(t, err, "genesis failed")
 
-	res, err = gnome1.mutateAddLink(pop, 1, context)
+	res, err = gnome1.mutateAddLink(pop, 1, context, neat.GlobalRand())
 	require.NoError(t, err, "failed to add link")
 	require.True(t, res, "New link not added")
 
@@ -78,10 +79,10 @@ func TestGenome_mutateConnectSensors(t *testing.T) {
 	context.PopSize = 1
 	// The population with one organism
 	pop := newPopulation()
-	err = pop.spawn(gnome1, context)
+	err = pop.spawn(context.NeatContext(), gnome1)
 	require.NoError(t, err, "failed to spawn population")
 
-	res, err := gnome1.mutateConnectSensors(pop, context)
+	res, err := gnome1.mutateConnectSensors(pop, context, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	assert.False(t, res, "All inputs already connected - no mutation expected")
 
@@ -97,7 +98,7 @@ func TestGenome_mutateConnectSensors(t *testing.T) {
 	// Create gnome phenotype
 	_, err = gnome1.Genesis(1)
 	require.NoError(t, err, "genesis failed")
-	res, err = gnome1.mutateConnectSensors(pop, context)
+	res, err = gnome1.mutateConnectSensors(pop, context, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	assert.True(t, res, "Its expected for disconnected sensor to be connected now")
 	assert.Len(t, gnome1.Genes, 4, "wrong number of genome genes")
@@ -120,10 +121,10 @@ func TestGenome_mutateAddNode(t *testing.T) {
 	context.PopSize = 1
 	// The population with one organism
 	pop := newPopulation()
-	err = pop.spawn(gnome1, context)
+	err = pop.spawn(context.NeatContext(), gnome1)
 	require.NoError(t, err, "failed to spawn population")
 
-	res, err := gnome1.mutateAddNode(pop, pop, context)
+	res, err := gnome1.mutateAddNode(pop, pop, context, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	require.True(t, res, "mutation failed")
 
@@ -138,10 +139,55 @@ func TestGenome_mutateAddNode(t *testing.T) {
 	assert.Equal(t, math.SigmoidSteepenedActivation, addedNode.ActivationType, "wrong activation type")
 }
 
+func TestGenome_mutateAddNode_splitReEnabled(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	// only the first gene can be split
+	gnome1.Genes[1].IsEnabled = false
+	splitGene := gnome1.Genes[0]
+
+	opts := &neat.Options{
+		KeepInnovations:    true,
+		PopSize:            1,
+		NodeActivators:     []math.NodeActivationType{math.SigmoidSteepenedActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+	pop := newPopulation()
+	err := pop.spawn(opts.NeatContext(), gnome1)
+	require.NoError(t, err, "failed to spawn population")
+	rng := neat.NewRand(42)
+
+	// split the gene
+	res := false
+	for i := 0; i < 100 && !res; i++ {
+		res, err = gnome1.mutateAddNode(pop, pop, opts, rng)
+		require.NoError(t, err, "failed to mutate")
+	}
+	require.True(t, res, "mutation failed")
+	require.False(t, splitGene.IsEnabled, "the split gene must be disabled")
+	genesCount, nodesCount := len(gnome1.Genes), len(gnome1.Nodes)
+
+	// re-enable the split gene
+	res, err = gnome1.mutateGeneReEnable()
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	require.True(t, splitGene.IsEnabled, "the split gene must be re-enabled")
+
+	// the same split is found among the run-wide innovations and skipped without disabling the gene
+	for i := 0; i < 10; i++ {
+		_, err = gnome1.mutateAddNode(pop, pop, opts, rng)
+		require.NoError(t, err, "failed to mutate")
+		assert.True(t, splitGene.IsEnabled, "the re-enabled gene must stay enabled at: %d", i)
+	}
+	assert.True(t, len(gnome1.Genes) >= genesCount)
+	assert.True(t, len(gnome1.Nodes) >= nodesCount)
+	_, err = gnome1.verify()
+	assert.NoError(t, err, "genome verification failed")
+}
+
 func TestGenome_mutateLinkWeights(t *testing.T) {
 	rand.Seed(42)
 	gnome1 := buildTestGenome(1)
-	res, err := gnome1.mutateLinkWeights(0.5, 1.0, gaussianMutator)
+	res, err := gnome1.mutateLinkWeights(0.5, 1.0, gaussianMutator, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	require.True(t, res, "mutation failed")
 
@@ -151,6 +197,59 @@ func TestGenome_mutateLinkWeights(t *testing.T) {
 	}
 }
 
+func TestGenome_mutateLinkWeights_ignoresGenePower(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+	for _, gn := range gnome1.Genes {
+		gn.MutationPower = 1000
+		gn.Link.ConnectionWeight = 0
+	}
+	res, err := gnome1.mutateLinkWeights(0.5, 1.0, gaussianMutator, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+
+	// the global mutation power is used if self-adaptation is not enabled
+	for i, gn := range gnome1.Genes {
+		assert.True(t, gn.Link.ConnectionWeight >= -0.5 && gn.Link.ConnectionWeight <= 0.5,
+			"weight perturbed beyond global mutation power at: %d", i)
+	}
+}
+
+func TestGenome_mutateLinkWeightsAdaptive(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+	opts := &neat.Options{
+		WeightMutPower:         2.5,
+		WeightMutPowerAdaptive: true,
+		WeightMutPowerMin:      0.01,
+		WeightMutPowerMax:      5.0,
+	}
+	res, err := gnome1.mutateLinkWeightsAdaptive(opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	for _, gn := range gnome1.Genes {
+		assert.NotEqual(t, opts.WeightMutPower, gn.MutationPower, "mutation power was not adapted")
+		assert.True(t, gn.MutationPower >= opts.WeightMutPowerMin && gn.MutationPower <= opts.WeightMutPowerMax,
+			"mutation power out of bounds: %f", gn.MutationPower)
+	}
+
+	// check that weights perturbed using gene's mutation power
+	opts.WeightMutPowerMin, opts.WeightMutPowerMax = 0.1, 0.1
+	weights := make([]float64, len(gnome1.Genes))
+	for i, gn := range gnome1.Genes {
+		weights[i] = gn.Link.ConnectionWeight
+	}
+	res, err = gnome1.mutateLinkWeightsAdaptive(opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	for i, gn := range gnome1.Genes {
+		assert.Equal(t, 0.1, gn.MutationPower)
+		delta := gn.Link.ConnectionWeight - weights[i]
+		assert.True(t, (delta >= -0.1 && delta <= 0.1) || (gn.Link.ConnectionWeight >= -0.1 && gn.Link.ConnectionWeight <= 0.1),
+			"weight perturbed beyond gene's mutation power at: %d", i)
+	}
+}
+
 func TestGenome_mutateRandomTrait(t *testing.T) {
 	gnome1 := buildTestGenome(1)
 	// Configuration
@@ -158,7 +257,7 @@ func TestGenome_mutateRandomTrait(t *testing.T) {
 		TraitMutationPower: 0.3,
 		TraitParamMutProb:  0.5,
 	}
-	res, err := gnome1.mutateRandomTrait(&context)
+	res, err := gnome1.mutateRandomTrait(&context, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	require.True(t, res, "mutation failed")
 
@@ -183,7 +282,7 @@ func TestGenome_mutateRandomTrait(t *testing.T) {
 func TestGenome_mutateLinkTrait(t *testing.T) {
 	gnome1 := buildTestGenome(1)
 
-	res, err := gnome1.mutateLinkTrait(10)
+	res, err := gnome1.mutateLinkTrait(10, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	require.True(t, res, "mutation failed")
 
@@ -208,7 +307,7 @@ func TestGenome_mutateNodeTrait(t *testing.T) {
 	}
 	gnome1.Nodes[3].Trait = &neat.Trait{Id: 4, Params: []float64{0.4, 0, 0, 0, 0, 0, 0, 0}}
 
-	res, err := gnome1.mutateNodeTrait(2)
+	res, err := gnome1.mutateNodeTrait(2, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	require.True(t, res, "mutation failed")
 
@@ -222,13 +321,83 @@ func TestGenome_mutateNodeTrait(t *testing.T) {
 	assert.True(t, mutationFound, "No mutation found in nodes traits")
 }
 
+func TestGenome_mutateNodeBiases(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	power := 0.5
+
+	res, err := gnome1.mutateNodeBiases(power, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+
+	for _, node := range gnome1.Nodes {
+		if node.IsNeuron() {
+			assert.NotZero(t, node.Bias, "bias not mutated at node: %d", node.Id)
+			assert.True(t, gomath.Abs(node.Bias) <= power, "bias out of range at node: %d", node.Id)
+		} else {
+			assert.Zero(t, node.Bias, "sensor bias mutated at node: %d", node.Id)
+		}
+	}
+
+	// no neuron nodes
+	gnome1.Nodes = gnome1.Nodes[:3]
+	res, err = gnome1.mutateNodeBiases(power, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	assert.False(t, res, "no neuron nodes expected")
+}
+
+func TestGenome_mutateNodeActivation(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	opts := &neat.Options{
+		NodeActivators:     []math.NodeActivationType{math.SigmoidSteepenedActivation, math.TanhActivation},
+		NodeActivatorsProb: []float64{0.0, 1.0},
+	}
+
+	activations := make([]math.NodeActivationType, len(gnome1.Nodes))
+	for i, node := range gnome1.Nodes {
+		activations[i] = node.ActivationType
+	}
+
+	// no hidden nodes to mutate
+	res, err := gnome1.mutateNodeActivation(opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	assert.False(t, res, "no hidden nodes expected")
+
+	hidden := network.NewNNode(5, network.HiddenNeuron)
+	hidden.ActivationType = math.SigmoidSteepenedActivation
+	gnome1.Nodes = append(gnome1.Nodes, hidden)
+
+	res, err = gnome1.mutateNodeActivation(opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	assert.Equal(t, math.TanhActivation, hidden.ActivationType, "wrong activation type")
+
+	// the sampled activator is the same as current
+	res, err = gnome1.mutateNodeActivation(opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	assert.False(t, res, "the activation type must not change")
+	assert.Equal(t, math.TanhActivation, hidden.ActivationType, "wrong activation type")
+
+	// the only one activator registered
+	opts.NodeActivators = []math.NodeActivationType{math.SigmoidSteepenedActivation}
+	opts.NodeActivatorsProb = []float64{1.0}
+	res, err = gnome1.mutateNodeActivation(opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	assert.False(t, res, "nothing to choose from")
+	assert.Equal(t, math.TanhActivation, hidden.ActivationType, "wrong activation type")
+
+	// the input, bias, and output nodes are not mutated
+	for i, activation := range activations {
+		assert.Equal(t, activation, gnome1.Nodes[i].ActivationType, "wrong activation type of node: %d", gnome1.Nodes[i].Id)
+	}
+}
+
 func TestGenome_mutateToggleEnable(t *testing.T) {
 	gnome1 := buildTestGenome(1)
 	// add extra connection gene from BIAS to OUT
 	gene := NewConnectionGene(network.NewLinkWithTrait(gnome1.Traits[2], 5.5, gnome1.Nodes[2], gnome1.Nodes[3], false), 4, 0, true)
 	gnome1.Genes = append(gnome1.Genes, gene)
 
-	res, err := gnome1.mutateToggleEnable(50)
+	res, err := gnome1.mutateToggleEnable(50, neat.GlobalRand())
 	require.NoError(t, err, "failed to mutate")
 	require.True(t, res, "mutation failed")
 
@@ -261,3 +430,148 @@ func TestGenome_mutateGeneReEnable(t *testing.T) {
 	assert.True(t, gnome1.Genes[1].IsEnabled, "The first encountered gene should be enabled")
 	assert.False(t, gnome1.Genes[3].IsEnabled, "The second disabled gene should still be disabled")
 }
+
+func TestGenome_mutateDeleteLink(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+	// add hidden node connected only by disabled gene
+	node := network.NewNNode(5, network.HiddenNeuron)
+	gnome1.nodeInsert(node)
+	gene := NewConnectionGene(network.NewLinkWithTrait(gnome1.Traits[0], 1.5, gnome1.Nodes[0], node, false), 4, 0, false)
+	gnome1.Genes = append(gnome1.Genes, gene)
+
+	// the disabled gene must be removed first along with the dead hidden node
+	res, err := gnome1.mutateDeleteLink(neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	require.Len(t, gnome1.Genes, 3, "wrong number of genes")
+	for _, gn := range gnome1.Genes {
+		assert.NotEqual(t, int64(4), gn.InnovationNum, "disabled gene was not removed")
+	}
+	assert.Len(t, gnome1.Nodes, 4, "wrong number of nodes")
+	assert.False(t, gnome1.haveNode(5), "dead hidden node was not removed")
+
+	// remove enabled genes while output node stays connected
+	res, err = gnome1.mutateDeleteLink(neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	res, err = gnome1.mutateDeleteLink(neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	assert.Len(t, gnome1.Genes, 1, "wrong number of genes")
+
+	// the last gene never removed
+	res, err = gnome1.mutateDeleteLink(neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	assert.False(t, res, "the last gene must not be removed")
+	assert.Len(t, gnome1.Genes, 1, "wrong number of genes")
+
+	_, err = gnome1.verify()
+	assert.NoError(t, err, "genome verification failed")
+}
+
+func TestGenome_mutateDeleteNode(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+
+	// no hidden nodes to delete
+	res, err := gnome1.mutateDeleteNode(neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	assert.False(t, res, "no hidden nodes expected")
+
+	context := &neat.Options{
+		NodeActivators:     []math.NodeActivationType{math.SigmoidSteepenedActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+	context.PopSize = 1
+	pop := newPopulation()
+	err = pop.spawn(context.NeatContext(), gnome1)
+	require.NoError(t, err, "failed to spawn population")
+
+	res, err = gnome1.mutateAddNode(pop, pop, context, neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	require.Len(t, gnome1.Nodes, 5, "wrong number of nodes")
+	require.Len(t, gnome1.Genes, 5, "wrong number of genes")
+
+	res, err = gnome1.mutateDeleteNode(neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	assert.Len(t, gnome1.Nodes, 4, "wrong number of nodes")
+	assert.False(t, gnome1.haveNode(6), "hidden node was not removed")
+	assert.Len(t, gnome1.Genes, 3, "wrong number of genes")
+	for _, gn := range gnome1.Genes {
+		assert.True(t, gn.InnovationNum <= 3, "gene of removed node found: %s", gn)
+	}
+	// the innovation numbers are never reused
+	assert.EqualValues(t, 5, pop.nextInnovNum, "wrong next innovation number")
+
+	_, err = gnome1.verify()
+	assert.NoError(t, err, "genome verification failed")
+}
+
+func TestGenome_mutateDeleteNode_modular(t *testing.T) {
+	gnome1 := buildTestModularGenome(1)
+	genesCount, nodesCount := len(gnome1.Genes), len(gnome1.Nodes)
+
+	// all hidden nodes are IO nodes of the control gene
+	res, err := gnome1.mutateDeleteNode(neat.GlobalRand())
+	require.NoError(t, err, "failed to mutate")
+	assert.False(t, res, "the IO nodes of control gene must not be removed")
+	assert.Len(t, gnome1.Genes, genesCount, "wrong number of genes")
+	assert.Len(t, gnome1.Nodes, nodesCount, "wrong number of nodes")
+}
+
+func TestGenome_mutateDeleteLink_hiddenPath(t *testing.T) {
+	rand.Seed(42)
+	// the output node is reachable from sensors only through the hidden node: S -> H -> O
+	traits := []*neat.Trait{{Id: 1, Params: []float64{0.1, 0, 0, 0, 0, 0, 0, 0}}}
+	nodes := []*network.NNode{
+		network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(2, network.BiasNeuron),
+		network.NewNNode(3, network.OutputNeuron),
+		network.NewNNode(4, network.HiddenNeuron),
+	}
+	genes := []*Gene{
+		NewConnectionGene(network.NewLinkWithTrait(traits[0], 1.5, nodes[0], nodes[3], false), 1, 0, true),
+		NewConnectionGene(network.NewLinkWithTrait(traits[0], 2.5, nodes[3], nodes[2], false), 2, 0, true),
+		NewConnectionGene(network.NewLinkWithTrait(traits[0], 3.5, nodes[1], nodes[2], false), 3, 0, false),
+	}
+	gnome1 := NewGenome(1, traits, nodes, genes)
+
+	// the links of the hidden path can not be removed
+	assert.False(t, gnome1.outputsStayConnected(map[*Gene]bool{genes[0]: true}))
+	assert.False(t, gnome1.outputsStayConnected(map[*Gene]bool{genes[1]: true}))
+	assert.True(t, gnome1.outputsStayConnected(map[*Gene]bool{genes[2]: true}))
+
+	// only the disabled gene is removed
+	for i := 0; i < 10; i++ {
+		_, err := gnome1.mutateDeleteLink(neat.GlobalRand())
+		require.NoError(t, err, "failed to mutate")
+	}
+	require.Len(t, gnome1.Genes, 2, "wrong number of genes")
+	assert.Equal(t, genes[:2], gnome1.Genes)
+	assert.True(t, gnome1.haveNode(4), "hidden node must not be removed")
+
+	// the phenotype still can be activated
+	net, err := gnome1.Genesis(1)
+	require.NoError(t, err, "failed to create phenotype")
+	err = net.LoadSensors([]float64{1.0})
+	require.NoError(t, err, "failed to load sensors")
+	res, err := net.Activate()
+	require.NoError(t, err, "failed to activate")
+	assert.True(t, res)
+}
+
+func TestGenome_outputsStayConnected(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+
+	removed := map[*Gene]bool{gnome1.Genes[0]: true, gnome1.Genes[1]: true}
+	assert.True(t, gnome1.outputsStayConnected(removed))
+
+	removed[gnome1.Genes[2]] = true
+	assert.False(t, gnome1.outputsStayConnected(removed))
+
+	// disabled genes have no influence
+	gnome1.Genes[2].IsEnabled = false
+	assert.True(t, gnome1.outputsStayConnected(map[*Gene]bool{gnome1.Genes[2]: true}))
+}
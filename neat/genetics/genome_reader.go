@@ -167,11 +167,19 @@ func readPlainNetworkNode(r io.Reader, traits []*neat.Trait) (*network.NNode, er
 		node.NeuronType = network.NodeNeuronType(neuronType)
 	}
 
-	if len(parts) == 5 {
-		node.ActivationType, err = math.NodeActivators.ActivationTypeFromName(parts[4])
+	if len(parts) >= 5 {
+		if node.ActivationType, err = math.NodeActivators.ActivationTypeFromName(parts[4]); err != nil {
+			return nil, err
+		}
+	}
+	// the node bias is optional
+	if len(parts) >= 6 {
+		if node.Bias, err = strconv.ParseFloat(parts[5], 64); err != nil {
+			return nil, err
+		}
 	}
 
-	return node, err
+	return node, nil
 }
 
 // Reads Gene from reader in plain text format
@@ -196,11 +204,24 @@ func readPlainConnectionGene(r io.Reader, traits []*neat.Trait, nodes []*network
 			outNode = np
 		}
 	}
+	var gene *Gene
 	if trait != nil {
-		return NewConnectionGene(network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		gene = NewConnectionGene(network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled)
 	} else {
-		return NewConnectionGene(network.NewLink(weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		gene = NewConnectionGene(network.NewLink(weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled)
+	}
+
+	// the mutation power is optional
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if mutPower := strings.TrimSpace(string(rest)); len(mutPower) > 0 {
+		if gene.MutationPower, err = strconv.ParseFloat(mutPower, 64); err != nil {
+			return nil, err
+		}
 	}
+	return gene, nil
 }
 
 // A YAMLGenomeReader reads genome data from YAML encoded text file
@@ -325,11 +346,19 @@ func readGene(conf map[string]interface{}, traits []*neat.Trait, nodes []*networ
 			outNode = np
 		}
 	}
+	var gene *Gene
 	if trait != nil {
-		return NewConnectionGene(network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		gene = NewConnectionGene(network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled)
 	} else {
-		return NewConnectionGene(network.NewLink(weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		gene = NewConnectionGene(network.NewLink(weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled)
+	}
+	// the self-adaptive mutation power is optional
+	if mutPower, ok := conf["mut_power"]; ok {
+		if gene.MutationPower, err = cast.ToFloat64E(mutPower); err != nil {
+			return nil, err
+		}
 	}
+	return gene, nil
 }
 
 // Reads MIMOControlGene configuration
@@ -424,8 +453,16 @@ func readNNode(conf map[string]interface{}, traits []*neat.Trait) (*network.NNod
 		return nil, err
 	}
 	activation := conf["activation"].(string)
-	node.ActivationType, err = math.NodeActivators.ActivationTypeFromName(activation)
-	return node, err
+	if node.ActivationType, err = math.NodeActivators.ActivationTypeFromName(activation); err != nil {
+		return nil, err
+	}
+	// the node bias is optional
+	if bias, ok := conf["bias"]; ok {
+		if node.Bias, err = cast.ToFloat64E(bias); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
 }
 
 // Reads Trait configuration
@@ -128,6 +128,20 @@ func TestReadGene_ReadPlainNNode(t *testing.T) {
 	assert.Equal(t, genNodeLabel, node.NeuronType, "wrong node placement label (neuron type) found")
 }
 
+func TestReadGene_ReadPlainNNode_bias(t *testing.T) {
+	nodeStr := fmt.Sprintf("%d %d %d %d SigmoidSteepenedActivation 0.25", 4, 0, network.NeuronNode, network.OutputNeuron)
+
+	node, err := readPlainNetworkNode(strings.NewReader(nodeStr), nil)
+	require.NoError(t, err, "failed to read network node")
+	assert.Equal(t, 4, node.Id, "wrong node ID")
+	assert.Equal(t, 0.25, node.Bias, "wrong node bias")
+
+	// wrong bias value
+	nodeStr = fmt.Sprintf("%d %d %d %d SigmoidSteepenedActivation bias", 4, 0, network.NeuronNode, network.OutputNeuron)
+	_, err = readPlainNetworkNode(strings.NewReader(nodeStr), nil)
+	assert.Error(t, err)
+}
+
 func TestReadGene_ReadPlainNNode_readError(t *testing.T) {
 	trait := neat.NewTrait()
 	trait.Id = 10
@@ -173,6 +187,25 @@ func TestReadGene_ReadPlainGene(t *testing.T) {
 	assert.False(t, link.IsRecurrent)
 }
 
+func TestReadGene_ReadPlainGene_mutationPower(t *testing.T) {
+	nodes := []*network.NNode{
+		network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(4, network.HiddenNeuron),
+	}
+	gene, err := readPlainConnectionGene(strings.NewReader("0 1 4 1.5 false 1 0 true 0.25"), nil, nodes)
+	require.NoError(t, err, "failed to read gene")
+	assert.Equal(t, 0.25, gene.MutationPower, "wrong mutation power")
+
+	// the mutation power is optional
+	gene, err = readPlainConnectionGene(strings.NewReader("0 1 4 1.5 false 1 0 true"), nil, nodes)
+	require.NoError(t, err, "failed to read gene")
+	assert.Zero(t, gene.MutationPower, "wrong mutation power")
+
+	// wrong mutation power value
+	_, err = readPlainConnectionGene(strings.NewReader("0 1 4 1.5 false 1 0 true power"), nil, nodes)
+	assert.Error(t, err)
+}
+
 func TestReadGene_ReadPlainGene_readError(t *testing.T) {
 	trait := neat.NewTrait()
 	trait.Id = 1
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/network"
+	"math"
 	"math/rand"
 )
 
@@ -13,7 +14,7 @@ import (
 // the innovation number, the Gene is chosen randomly from either parent.  If one parent has an innovation absent in
 // the other, the baby may inherit the innovation if it is from the more fit parent.
 // The new Genome is given the id in the genomeId argument.
-func (g *Genome) mateMultipoint(og *Genome, genomeId int, fitness1, fitness2 float64) (*Genome, error) {
+func (g *Genome) mateMultipoint(og *Genome, genomeId int, fitness1, fitness2 float64, rng *rand.Rand) (*Genome, error) {
 	// Check if genomes has equal number of traits
 	if len(g.Traits) != len(og.Traits) {
 		return nil, fmt.Errorf("genomes has different traits count, %d != %d", len(g.Traits), len(og.Traits))
@@ -85,14 +86,14 @@ func (g *Genome) mateMultipoint(og *Genome, genomeId int, fitness1, fitness2 flo
 			p2innov := p2gene.InnovationNum
 
 			if p1innov == p2innov {
-				if rand.Float64() < 0.5 {
+				if rng.Float64() < 0.5 {
 					chosenGene = p1gene
 				} else {
 					chosenGene = p2gene
 				}
 
 				// If one is disabled, the corresponding gene in the offspring will likely be disabled
-				if !p1gene.IsEnabled || !p2gene.IsEnabled && rand.Float64() < 0.75 {
+				if !p1gene.IsEnabled || !p2gene.IsEnabled && rng.Float64() < 0.75 {
 					disable = true
 				}
 				i1++
@@ -185,6 +186,9 @@ func (g *Genome) mateMultipoint(og *Genome, genomeId int, fitness1, fitness2 flo
 		} // end SKIP
 	} // end FOR
 
+	// Mate the biases of nodes inherited from both parents
+	g.mateNodeBiases(og, newNodes, false, rng)
+
 	// check if parent's MIMO control genes should be inherited
 	if len(g.ControlGenes) != 0 || len(og.ControlGenes) != 0 {
 		// MIMO control genes found at least in one parent - append it to child if appropriate
@@ -204,7 +208,7 @@ func (g *Genome) mateMultipoint(og *Genome, genomeId int, fitness1, fitness2 flo
 
 // This method mates like multipoint but instead of selecting one or the other when the innovation numbers match,
 // it averages their weights.
-func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2 float64) (*Genome, error) {
+func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2 float64, rng *rand.Rand) (*Genome, error) {
 	// Check if genomes has equal number of traits
 	if len(g.Traits) != len(og.Traits) {
 		return nil, fmt.Errorf("genomes has different traits count, %d != %d", len(g.Traits), len(og.Traits))
@@ -281,24 +285,24 @@ func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2
 
 			if p1innov == p2innov {
 				// Average them into the avg_gene
-				if rand.Float64() > 0.5 {
+				if rng.Float64() > 0.5 {
 					avgGene.Link.Trait = p1gene.Link.Trait
 				} else {
 					avgGene.Link.Trait = p2gene.Link.Trait
 				}
 				avgGene.Link.ConnectionWeight = (p1gene.Link.ConnectionWeight + p2gene.Link.ConnectionWeight) / 2.0 // WEIGHTS AVERAGED HERE
 
-				if rand.Float64() > 0.5 {
+				if rng.Float64() > 0.5 {
 					avgGene.Link.InNode = p1gene.Link.InNode
 				} else {
 					avgGene.Link.InNode = p2gene.Link.InNode
 				}
-				if rand.Float64() > 0.5 {
+				if rng.Float64() > 0.5 {
 					avgGene.Link.OutNode = p1gene.Link.OutNode
 				} else {
 					avgGene.Link.OutNode = p2gene.Link.OutNode
 				}
-				if rand.Float64() > 0.5 {
+				if rng.Float64() > 0.5 {
 					avgGene.Link.IsRecurrent = p1gene.Link.IsRecurrent
 				} else {
 					avgGene.Link.IsRecurrent = p2gene.Link.IsRecurrent
@@ -306,7 +310,9 @@ func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2
 
 				avgGene.InnovationNum = p1innov
 				avgGene.MutationNum = (p1gene.MutationNum + p2gene.MutationNum) / 2.0
-				if !p1gene.IsEnabled || !p2gene.IsEnabled && rand.Float64() < 0.75 {
+				// the geometric mean of the log-normally adapted mutation powers
+				avgGene.MutationPower = math.Sqrt(p1gene.MutationPower * p2gene.MutationPower)
+				if !p1gene.IsEnabled || !p2gene.IsEnabled && rng.Float64() < 0.75 {
 					avgGene.IsEnabled = false
 				}
 
@@ -398,6 +404,9 @@ func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2
 			newGenes = append(newGenes, gene)
 		} // end SKIP
 	} // end FOR
+	// Average the biases of nodes inherited from both parents
+	g.mateNodeBiases(og, newNodes, true, rng)
+
 	// check if parent's MIMO control genes should be inherited
 	if len(g.ControlGenes) != 0 || len(og.ControlGenes) != 0 {
 		// MIMO control genes found at least in one parent - append it to child if appropriate
@@ -418,7 +427,7 @@ func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2
 // This method is similar to a standard single point CROSSOVER operator. Traits are averaged as in the previous two
 // mating methods. A Gene is chosen in the smaller Genome for splitting. When the Gene is reached, it is averaged with
 // the matching Gene from the larger Genome, if one exists. Then every other Gene is taken from the larger Genome.
-func (g *Genome) mateSinglePoint(og *Genome, genomeId int) (*Genome, error) {
+func (g *Genome) mateSinglePoint(og *Genome, genomeId int, rng *rand.Rand) (*Genome, error) {
 	// Check if genomes has equal number of traits
 	if len(g.Traits) != len(og.Traits) {
 		return nil, fmt.Errorf("genomes has different traits count, %d != %d", len(g.Traits), len(og.Traits))
@@ -461,14 +470,14 @@ func (g *Genome) mateSinglePoint(og *Genome, genomeId int) (*Genome, error) {
 	var p1genes, p2genes []*Gene
 	size1, size2 := len(g.Genes), len(og.Genes)
 	if size1 < size2 {
-		crossPoint = rand.Intn(size1)
+		crossPoint = rng.Intn(size1)
 		p1stop = size1
 		p2stop = size2
 		stopper = size2
 		p1genes = g.Genes
 		p2genes = og.Genes
 	} else {
-		crossPoint = rand.Intn(size2)
+		crossPoint = rng.Intn(size2)
 		p1stop = size2
 		p2stop = size1
 		stopper = size1
@@ -504,24 +513,24 @@ func (g *Genome) mateSinglePoint(og *Genome, genomeId int) (*Genome, error) {
 					chosenGene = p2gene
 				} else {
 					// We are at the crossPoint here - average genes into the avgene
-					if rand.Float64() > 0.5 {
+					if rng.Float64() > 0.5 {
 						avgGene.Link.Trait = p1gene.Link.Trait
 					} else {
 						avgGene.Link.Trait = p2gene.Link.Trait
 					}
 					avgGene.Link.ConnectionWeight = (p1gene.Link.ConnectionWeight + p2gene.Link.ConnectionWeight) / 2.0 // WEIGHTS AVERAGED HERE
 
-					if rand.Float64() > 0.5 {
+					if rng.Float64() > 0.5 {
 						avgGene.Link.InNode = p1gene.Link.InNode
 					} else {
 						avgGene.Link.InNode = p2gene.Link.InNode
 					}
-					if rand.Float64() > 0.5 {
+					if rng.Float64() > 0.5 {
 						avgGene.Link.OutNode = p1gene.Link.OutNode
 					} else {
 						avgGene.Link.OutNode = p2gene.Link.OutNode
 					}
-					if rand.Float64() > 0.5 {
+					if rng.Float64() > 0.5 {
 						avgGene.Link.IsRecurrent = p1gene.Link.IsRecurrent
 					} else {
 						avgGene.Link.IsRecurrent = p2gene.Link.IsRecurrent
@@ -529,7 +538,9 @@ func (g *Genome) mateSinglePoint(og *Genome, genomeId int) (*Genome, error) {
 
 					avgGene.InnovationNum = p1innov
 					avgGene.MutationNum = (p1gene.MutationNum + p2gene.MutationNum) / 2.0
-					if !p1gene.IsEnabled || !p2gene.IsEnabled && rand.Float64() < 0.75 {
+					// the geometric mean of the log-normally adapted mutation powers
+					avgGene.MutationPower = math.Sqrt(p1gene.MutationPower * p2gene.MutationPower)
+					if !p1gene.IsEnabled || !p2gene.IsEnabled && rng.Float64() < 0.75 {
 						avgGene.IsEnabled = false
 					}
 
@@ -626,6 +637,9 @@ func (g *Genome) mateSinglePoint(og *Genome, genomeId int) (*Genome, error) {
 			newGenes = append(newGenes, gene)
 		} // end SKIP
 	} // end FOR
+	// Mate the biases of nodes inherited from both parents
+	g.mateNodeBiases(og, newNodes, false, rng)
+
 	// check if parent's MIMO control genes should be inherited
 	if len(g.ControlGenes) != 0 || len(og.ControlGenes) != 0 {
 		// MIMO control genes found at least in one parent - append it to child if appropriate
@@ -686,6 +700,24 @@ func findModulesIntersection(nodes map[int]*network.NNode, genes []*MIMOControlG
 	return modules
 }
 
+// Sets the biases of the child nodes found in both parents either by averaging the parents' biases or by choosing
+// randomly the bias of one parent
+func (g *Genome) mateNodeBiases(og *Genome, childNodes []*network.NNode, average bool, rng *rand.Rand) {
+	for _, node := range childNodes {
+		node1, node2 := g.NodeWithId(node.Id), og.NodeWithId(node.Id)
+		if node1 == nil || node2 == nil || node1.Bias == node2.Bias {
+			continue
+		}
+		if average {
+			node.Bias = (node1.Bias + node2.Bias) / 2.0
+		} else if rng.Float64() < 0.5 {
+			node.Bias = node1.Bias
+		} else {
+			node.Bias = node2.Bias
+		}
+	}
+}
+
 // Builds array of traits for child genome during crossover
 func (g *Genome) mateTraits(og *Genome) ([]*neat.Trait, error) {
 	newTraits := make([]*neat.Trait, len(g.Traits))
@@ -92,7 +92,7 @@ func TestGenome_NewGenomeRand(t *testing.T) {
 		NodeActivatorsProb: []float64{1.0},
 	}
 
-	gnome, err := newGenomeRand(newId, in, out, n, 5, false, 0.5, opts)
+	gnome, err := newGenomeRand(newId, in, out, n, 5, false, 0.5, opts, neat.GlobalRand())
 	require.NoError(t, err, "failed to create random genome")
 	require.NotNil(t, gnome, "random genome expected")
 	assert.Len(t, gnome.Nodes, in+n+out, "failed to create nodes")
@@ -112,6 +112,16 @@ func TestGenome_Genesis(t *testing.T) {
 	assert.Equal(t, len(gnome.Genes), net.LinkCount(), "wrong links count")
 }
 
+func TestGenome_Genesis_nodeBias(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Nodes[3].Bias = 0.75
+
+	net, err := gnome.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	require.Len(t, net.Outputs, 1)
+	assert.Equal(t, 0.75, net.Outputs[0].Bias, "wrong bias of the output node")
+}
+
 func TestGenome_GenesisModular(t *testing.T) {
 	gnome := buildTestModularGenome(1)
 	netId := 10
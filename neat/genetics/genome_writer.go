@@ -111,6 +111,9 @@ func (wr *plainGenomeWriter) writeNetworkNode(n *network.NNode) error {
 		_, err = fmt.Fprintf(wr.w, "%d %d %d %d %s", n.Id, traitId, n.NodeType(),
 			n.NeuronType, actStr)
 	}
+	if err == nil && n.Bias != 0 {
+		_, err = fmt.Fprintf(wr.w, " %g", n.Bias)
+	}
 	return err
 }
 
@@ -131,6 +134,9 @@ func (wr *plainGenomeWriter) writeConnectionGene(g *Gene) error {
 
 	_, err := fmt.Fprintf(wr.w, "%d %d %d %g %t %d %g %t",
 		traitId, inNodeId, outNodeId, weight, recurrent, innovNum, mutNum, enabled)
+	if err == nil && g.MutationPower > 0 {
+		_, err = fmt.Fprintf(wr.w, " %g", g.MutationPower)
+	}
 	return err
 }
 
@@ -245,6 +251,9 @@ func (wr *yamlGenomeWriter) encodeConnectionGene(gene *Gene) map[string]interfac
 	gMap["innov_num"] = gene.InnovationNum
 	gMap["weight"] = gene.Link.ConnectionWeight
 	gMap["mut_num"] = gene.MutationNum
+	if gene.MutationPower > 0 {
+		gMap["mut_power"] = gene.MutationPower
+	}
 	gMap["recurrent"] = gene.Link.IsRecurrent
 	gMap["enabled"] = gene.IsEnabled
 	return gMap
@@ -259,6 +268,9 @@ func (wr *yamlGenomeWriter) encodeNetworkNode(node *network.NNode) (nMap map[str
 		nMap["trait_id"] = 0
 	}
 	nMap["type"] = network.NeuronTypeName(node.NeuronType)
+	if node.Bias != 0 {
+		nMap["bias"] = node.Bias
+	}
 	nMap["activation"], err = math.NodeActivators.ActivationNameFromType(node.ActivationType)
 	return nMap, err
 }
@@ -63,6 +63,26 @@ func TestPlainGenomeWriter_WriteNetworkNode(t *testing.T) {
 	assert.Equal(t, nodeStr, outStr, "Node serialization failed")
 }
 
+func TestPlainGenomeWriter_WriteNetworkNode_bias(t *testing.T) {
+	node := network.NewNNode(4, network.OutputNeuron)
+	node.Bias = -0.5
+	outBuffer := bytes.NewBufferString("")
+
+	wr := plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err := wr.writeNetworkNode(node)
+	require.NoError(t, err, "failed to write network node")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+
+	nodeStr := fmt.Sprintf("4 0 %d %d SigmoidSteepenedActivation -0.5", network.NeuronNode, network.OutputNeuron)
+	assert.Equal(t, nodeStr, outBuffer.String(), "Node serialization failed")
+
+	// read it back
+	readNode, err := readPlainNetworkNode(strings.NewReader(outBuffer.String()), nil)
+	require.NoError(t, err, "failed to read network node")
+	assert.Equal(t, node.Bias, readNode.Bias, "wrong node bias")
+}
+
 func TestPlainGenomeWriter_WriteNetworkNode_writeError(t *testing.T) {
 	errorWriter := ErrorWriter(1)
 	wr := plainGenomeWriter{w: bufio.NewWriterSize(&errorWriter, 1)}
@@ -96,6 +116,30 @@ func TestPlainGenomeWriter_WriteConnectionGene(t *testing.T) {
 	assert.Equal(t, geneStr, outStr, "Wrong Gene serialization")
 }
 
+func TestPlainGenomeWriter_WriteConnectionGene_mutationPower(t *testing.T) {
+	trait := neat.NewTrait()
+	trait.Id = 1
+	nodes := []*network.NNode{
+		network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(4, network.HiddenNeuron),
+	}
+	gene := NewGeneWithTrait(trait, 1.5, nodes[0], nodes[1], false, 1, 0)
+	gene.MutationPower = 0.125
+	outBuffer := bytes.NewBufferString("")
+
+	wr := plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err := wr.writeConnectionGene(gene)
+	require.NoError(t, err, "failed to write connection gene")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, "1 1 4 1.5 false 1 0 true 0.125", outBuffer.String(), "Wrong Gene serialization")
+
+	// read it back
+	readGene, err := readPlainConnectionGene(strings.NewReader(outBuffer.String()), []*neat.Trait{trait}, nodes)
+	require.NoError(t, err, "failed to read connection gene")
+	assert.Equal(t, gene.MutationPower, readGene.MutationPower, "wrong mutation power")
+}
+
 func TestPlainGenomeWriter_WriteConnectionGene_writeError(t *testing.T) {
 	errorWriter := ErrorWriter(1)
 	wr := plainGenomeWriter{w: bufio.NewWriterSize(&errorWriter, 1)}
@@ -141,6 +185,8 @@ func TestPlainGenomeWriter_WriteGenome_writeError(t *testing.T) {
 
 func TestYamlGenomeWriter_WriteGenome(t *testing.T) {
 	gnome := buildTestModularGenome(1)
+	gnome.Genes[1].MutationPower = 0.75
+	gnome.Nodes[3].Bias = -0.25
 
 	// encode genome
 	outBuf := bytes.NewBufferString("")
@@ -165,6 +211,7 @@ func TestYamlGenomeWriter_WriteGenome(t *testing.T) {
 		assert.True(t, g.Link.IsEqualGenetically(og.Link), "genes not equal genetically at: %d", i)
 		assert.Equal(t, g.IsEnabled, og.IsEnabled, "at: %d", i)
 		assert.Equal(t, g.MutationNum, og.MutationNum, "at: %d", i)
+		assert.Equal(t, g.MutationPower, og.MutationPower, "at: %d", i)
 		assert.Equal(t, g.InnovationNum, og.InnovationNum, "at: %d", i)
 	}
 
@@ -176,6 +223,7 @@ func TestYamlGenomeWriter_WriteGenome(t *testing.T) {
 		assert.Equal(t, n.Id, nd.Id, "wrong node ID at: %d", i)
 		assert.Equal(t, n.ActivationType, nd.ActivationType, "wrong node activation at: %d", i)
 		assert.Equal(t, n.NeuronType, nd.NeuronType, "wrong node neuron type at: %d", i)
+		assert.Equal(t, n.Bias, nd.Bias, "wrong node bias at: %d", i)
 	}
 
 	// check encoded traits
@@ -0,0 +1,69 @@
+package genetics
+
+import (
+	"github.com/pkg/errors"
+	"math/rand"
+)
+
+// HallOfFame The archive of champions of the past generations. It is used to sample opponents in competitive
+// coevolution, so that organisms are evaluated not only against the current opponents, but also against strategies
+// that were successful before, which prevents cycling and forgetting of the learned skills.
+type HallOfFame struct {
+	// The champions stored in the archive in order of addition
+	Champions []*Organism
+	// The maximal number of champions stored, the oldest champions are removed when it is exceeded.
+	// Zero means unlimited archive.
+	MaxSize int
+}
+
+// NewHallOfFame Creates new empty hall of fame with given maximal size, zero size means unlimited archive
+func NewHallOfFame(maxSize int) *HallOfFame {
+	return &HallOfFame{
+		Champions: make([]*Organism, 0),
+		MaxSize:   maxSize,
+	}
+}
+
+// Add is to store the copy of given champion organism in the archive. If the archive is full, the oldest champion
+// is removed.
+func (h *HallOfFame) Add(champion *Organism) error {
+	if champion == nil || champion.Genotype == nil {
+		return errors.New("champion organism with genome expected")
+	}
+	org, err := copyOrganism(champion)
+	if err != nil {
+		return errors.Wrap(err, "failed to copy champion into hall of fame")
+	}
+	h.Champions = append(h.Champions, org)
+	if h.MaxSize > 0 && len(h.Champions) > h.MaxSize {
+		h.Champions = h.Champions[len(h.Champions)-h.MaxSize:]
+	}
+	return nil
+}
+
+// Sample Returns given number of champions randomly selected from the archive without replacement using provided
+// random numbers generator. If the archive has fewer champions, all of them are returned.
+func (h *HallOfFame) Sample(count int, rng *rand.Rand) []*Organism {
+	return sampleOrganisms(h.Champions, count, rng)
+}
+
+// Size Returns the number of champions stored in the archive
+func (h *HallOfFame) Size() int {
+	return len(h.Champions)
+}
+
+// sampleOrganisms is to randomly select given number of organisms from the list without replacement. If the list
+// has fewer organisms, all of them are returned in random order.
+func sampleOrganisms(organisms []*Organism, count int, rng *rand.Rand) []*Organism {
+	if count > len(organisms) {
+		count = len(organisms)
+	}
+	if count <= 0 {
+		return make([]*Organism, 0)
+	}
+	sample := make([]*Organism, count)
+	for i, index := range rng.Perm(len(organisms))[:count] {
+		sample[i] = organisms[index]
+	}
+	return sample
+}
@@ -0,0 +1,65 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"math/rand"
+	"testing"
+)
+
+func TestHallOfFame_Add(t *testing.T) {
+	hof := NewHallOfFame(2)
+	champions := make([]*Organism, 3)
+	for i := range champions {
+		org, err := NewOrganism(float64(i), buildTestGenome(i+1), i)
+		require.NoError(t, err)
+		champions[i] = org
+		require.NoError(t, hof.Add(org))
+	}
+
+	// the oldest champion removed
+	require.Equal(t, 2, hof.Size())
+	for i, org := range hof.Champions {
+		expected := champions[i+1]
+		assert.Equal(t, expected.Fitness, org.Fitness)
+		assert.Equal(t, expected.Generation, org.Generation)
+		assert.NotSame(t, expected.Genotype, org.Genotype, "genome should be copied")
+		assert.Nil(t, org.Species)
+	}
+
+	assert.Error(t, hof.Add(nil))
+}
+
+func TestHallOfFame_Add_unlimited(t *testing.T) {
+	hof := NewHallOfFame(0)
+	for i := 0; i < 10; i++ {
+		org, err := NewOrganism(float64(i), buildTestGenome(i+1), i)
+		require.NoError(t, err)
+		require.NoError(t, hof.Add(org))
+	}
+	assert.Equal(t, 10, hof.Size())
+}
+
+func TestHallOfFame_Sample(t *testing.T) {
+	rand.Seed(42)
+	hof := NewHallOfFame(0)
+	for i := 0; i < 5; i++ {
+		org, err := NewOrganism(float64(i), buildTestGenome(i+1), i)
+		require.NoError(t, err)
+		require.NoError(t, hof.Add(org))
+	}
+
+	sample := hof.Sample(3, neat.GlobalRand())
+	require.Len(t, sample, 3)
+	seen := make(map[*Organism]bool)
+	for _, org := range sample {
+		assert.Contains(t, hof.Champions, org)
+		assert.False(t, seen[org], "organism sampled twice")
+		seen[org] = true
+	}
+
+	assert.Len(t, hof.Sample(10, neat.GlobalRand()), 5)
+	assert.Empty(t, hof.Sample(0, neat.GlobalRand()))
+	assert.Empty(t, NewHallOfFame(10).Sample(3, neat.GlobalRand()))
+}
@@ -6,13 +6,18 @@ type InnovationsObserver interface {
 	StoreInnovation(innovation Innovation)
 	// Innovations is to get list of known innovations
 	Innovations() []Innovation
+	// FindLinkInnovation is to find known innovation of new link between nodes with given IDs
+	FindLinkInnovation(inNodeId, outNodeId int, recurrent bool) (Innovation, bool)
+	// FindNodeInnovation is to find known innovation of new node splitting the gene with given innovation number
+	// between nodes with given IDs
+	FindNodeInnovation(inNodeId, outNodeId int, oldInnovNum int64) (Innovation, bool)
 	// NextInnovationNumber is to get next unique global innovation number
 	NextInnovationNumber() int64
 }
 
 // Innovation serves as a way to record innovations specifically, so that an innovation in one genome can be
-// compared with other innovations in the same epoch, and if they are the same innovation, they can both be assigned the
-// same innovation number.
+// compared with other innovations in the same epoch (or during the whole run if innovations are kept across
+// generations), and if they are the same innovation, they can both be assigned the same innovation number.
 //
 // This class can encode innovations that represent a new link forming, or a new node being added.  In each case, two
 // nodes fully specify the innovation and where it must have occurred (between them).
@@ -0,0 +1,114 @@
+package genetics
+
+import "sync"
+
+// innovationKey The key to index innovations in the innovation store. The innovation is fully specified by its type,
+// the nodes between which it took place, and whether the new link is recurrent. The new node innovation is specified
+// by the innovation number of the gene it split as well.
+type innovationKey struct {
+	innovationType innovationType
+	inNodeId       int
+	outNodeId      int
+	isRecurrent    bool
+	oldInnovNum    int64
+}
+
+// InnovationStore The indexed store of innovations, which provides constant time lookup of the innovation by its type
+// and the nodes where it took place. It is safe for concurrent use.
+type InnovationStore struct {
+	// The list of innovations in order of storing
+	innovations []Innovation
+	// The index of innovations in the list by its key
+	index map[innovationKey]int
+	// The mutex to guard against concurrent modifications
+	mutex sync.RWMutex
+}
+
+// NewInnovationStore Creates new empty innovation store
+func NewInnovationStore() *InnovationStore {
+	return &InnovationStore{
+		innovations: make([]Innovation, 0),
+		index:       make(map[innovationKey]int),
+	}
+}
+
+// Store is to store specific innovation. If the same innovation already stored, the first one is kept, so that all
+// identical structures get the same innovation numbers.
+func (s *InnovationStore) Store(innovation Innovation) {
+	key := keyOfInnovation(&innovation)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.index[key]; ok {
+		return
+	}
+	s.index[key] = len(s.innovations)
+	s.innovations = append(s.innovations, innovation)
+}
+
+// FindLinkInnovation Returns the innovation of new link between nodes with given IDs if it was stored before
+func (s *InnovationStore) FindLinkInnovation(inNodeId, outNodeId int, recurrent bool) (Innovation, bool) {
+	return s.find(innovationKey{
+		innovationType: newLinkInnType,
+		inNodeId:       inNodeId,
+		outNodeId:      outNodeId,
+		isRecurrent:    recurrent,
+	})
+}
+
+// FindNodeInnovation Returns the innovation of new node splitting the gene with given innovation number between
+// nodes with given IDs if it was stored before
+func (s *InnovationStore) FindNodeInnovation(inNodeId, outNodeId int, oldInnovNum int64) (Innovation, bool) {
+	return s.find(innovationKey{
+		innovationType: newNodeInnType,
+		inNodeId:       inNodeId,
+		outNodeId:      outNodeId,
+		oldInnovNum:    oldInnovNum,
+	})
+}
+
+// Innovations Returns the list of stored innovations in order of storing
+func (s *InnovationStore) Innovations() []Innovation {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.innovations
+}
+
+// Len Returns the number of stored innovations
+func (s *InnovationStore) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.innovations)
+}
+
+// Reset is to remove all stored innovations
+func (s *InnovationStore) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.innovations = make([]Innovation, 0)
+	s.index = make(map[innovationKey]int)
+}
+
+func (s *InnovationStore) find(key innovationKey) (Innovation, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if i, ok := s.index[key]; ok {
+		return s.innovations[i], true
+	}
+	return Innovation{}, false
+}
+
+// keyOfInnovation is to create the index key of the innovation
+func keyOfInnovation(innovation *Innovation) innovationKey {
+	key := innovationKey{
+		innovationType: innovation.innovationType,
+		inNodeId:       innovation.InNodeId,
+		outNodeId:      innovation.OutNodeId,
+	}
+	if innovation.innovationType == newNodeInnType {
+		key.oldInnovNum = innovation.OldInnovNum
+	} else {
+		key.isRecurrent = innovation.IsRecurrent
+	}
+	return key
+}
@@ -0,0 +1,91 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestInnovationStore_FindLinkInnovation(t *testing.T) {
+	store := NewInnovationStore()
+	store.Store(*NewInnovationForRecurrentLink(1, 4, 10, 0.5, 1, false))
+	store.Store(*NewInnovationForRecurrentLink(1, 4, 11, 0.7, 2, true))
+
+	inn, found := store.FindLinkInnovation(1, 4, false)
+	require.True(t, found)
+	assert.EqualValues(t, 10, inn.InnovationNum)
+	assert.Equal(t, 0.5, inn.NewWeight)
+	assert.Equal(t, 1, inn.NewTraitNum)
+
+	inn, found = store.FindLinkInnovation(1, 4, true)
+	require.True(t, found)
+	assert.EqualValues(t, 11, inn.InnovationNum)
+
+	_, found = store.FindLinkInnovation(4, 1, false)
+	assert.False(t, found)
+
+	// the node innovation between the same nodes is not the link innovation
+	_, found = store.FindNodeInnovation(1, 4, 10)
+	assert.False(t, found)
+}
+
+func TestInnovationStore_FindNodeInnovation(t *testing.T) {
+	store := NewInnovationStore()
+	store.Store(*NewInnovationForNode(1, 4, 12, 13, 5, 1))
+
+	inn, found := store.FindNodeInnovation(1, 4, 1)
+	require.True(t, found)
+	assert.EqualValues(t, 12, inn.InnovationNum)
+	assert.EqualValues(t, 13, inn.InnovationNum2)
+	assert.Equal(t, 5, inn.NewNodeId)
+
+	// split of another gene between the same nodes
+	_, found = store.FindNodeInnovation(1, 4, 2)
+	assert.False(t, found)
+
+	_, found = store.FindLinkInnovation(1, 4, false)
+	assert.False(t, found)
+}
+
+func TestInnovationStore_Store_duplicate(t *testing.T) {
+	store := NewInnovationStore()
+	store.Store(*NewInnovationForLink(1, 4, 10, 0.5, 1))
+	store.Store(*NewInnovationForLink(1, 4, 20, 0.1, 0))
+	assert.Equal(t, 1, store.Len())
+
+	// the first innovation is kept
+	inn, found := store.FindLinkInnovation(1, 4, false)
+	require.True(t, found)
+	assert.EqualValues(t, 10, inn.InnovationNum)
+}
+
+func TestInnovationStore_Reset(t *testing.T) {
+	store := NewInnovationStore()
+	store.Store(*NewInnovationForLink(1, 4, 10, 0.5, 1))
+	store.Store(*NewInnovationForNode(1, 4, 12, 13, 5, 1))
+	require.Len(t, store.Innovations(), 2)
+
+	store.Reset()
+	assert.Equal(t, 0, store.Len())
+	_, found := store.FindLinkInnovation(1, 4, false)
+	assert.False(t, found)
+}
+
+func TestInnovationStore_concurrent(t *testing.T) {
+	store := NewInnovationStore()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(in int) {
+			defer wg.Done()
+			for out := 0; out < 100; out++ {
+				store.Store(*NewInnovationForLink(in, out, int64(in*100+out), 0, 0))
+				_, found := store.FindLinkInnovation(in, out, false)
+				assert.True(t, found)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, store.Len())
+}
@@ -0,0 +1,176 @@
+package genetics
+
+import (
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"sort"
+)
+
+// innovationsTracker The tracker of innovations and IDs of new nodes used during reproduction
+type innovationsTracker interface {
+	InnovationsObserver
+	network.NodeIdGenerator
+}
+
+// innovationsJournal The journal of innovations made during reproduction of a single species. It allows species to be
+// reproduced independently, e.g. concurrently, and still to get the same innovation numbers and node IDs regardless of
+// the execution order. The journal looks up the innovations of population, which are not modified during reproduction,
+// and its own ones. The new innovations get provisional negative innovation numbers and node IDs, which are replaced
+// by the final ones when journals of all species are committed to the population in order of species.
+type innovationsJournal struct {
+	// The population which innovations are looked up
+	pop *Population
+	// The innovations made during reproduction of species
+	innovations *InnovationStore
+	// The number of provisional innovation numbers allocated
+	innovNumsCount int64
+	// The number of provisional node IDs allocated
+	nodeIdsCount int
+}
+
+// newInnovationsJournal Creates new empty innovations journal for given population
+func newInnovationsJournal(pop *Population) *innovationsJournal {
+	return &innovationsJournal{
+		pop:         pop,
+		innovations: NewInnovationStore(),
+	}
+}
+
+func (j *innovationsJournal) StoreInnovation(innovation Innovation) {
+	j.innovations.Store(innovation)
+}
+
+func (j *innovationsJournal) Innovations() []Innovation {
+	innovations := j.pop.Innovations()
+	res := make([]Innovation, 0, len(innovations)+j.innovations.Len())
+	res = append(res, innovations...)
+	return append(res, j.innovations.Innovations()...)
+}
+
+func (j *innovationsJournal) FindLinkInnovation(inNodeId, outNodeId int, recurrent bool) (Innovation, bool) {
+	if innovation, ok := j.innovations.FindLinkInnovation(inNodeId, outNodeId, recurrent); ok {
+		return innovation, ok
+	}
+	return j.pop.FindLinkInnovation(inNodeId, outNodeId, recurrent)
+}
+
+func (j *innovationsJournal) FindNodeInnovation(inNodeId, outNodeId int, oldInnovNum int64) (Innovation, bool) {
+	if innovation, ok := j.innovations.FindNodeInnovation(inNodeId, outNodeId, oldInnovNum); ok {
+		return innovation, ok
+	}
+	return j.pop.FindNodeInnovation(inNodeId, outNodeId, oldInnovNum)
+}
+
+// NextInnovationNumber Returns the next provisional innovation number
+func (j *innovationsJournal) NextInnovationNumber() int64 {
+	j.innovNumsCount++
+	return -j.innovNumsCount
+}
+
+// NextNodeId Returns the next provisional node ID
+func (j *innovationsJournal) NextNodeId() int {
+	j.nodeIdsCount++
+	return -j.nodeIdsCount
+}
+
+// commit is to store the innovations of this journal into the population and to replace provisional innovation
+// numbers and node IDs in the genomes of provided babies by the final ones. If the same innovation was committed
+// earlier by another journal, its innovation numbers and node ID are reused.
+func (j *innovationsJournal) commit(babies []*Organism) {
+	innovNums := make(map[int64]int64, j.innovNumsCount)
+	nodeIds := make(map[int]int, j.nodeIdsCount)
+	resolveNodeId := func(id int) int {
+		if final, ok := nodeIds[id]; ok {
+			return final
+		}
+		return id
+	}
+	resolveInnovNum := func(num int64) int64 {
+		if final, ok := innovNums[num]; ok {
+			return final
+		}
+		return num
+	}
+
+	for _, innovation := range j.innovations.Innovations() {
+		innovation.InNodeId = resolveNodeId(innovation.InNodeId)
+		innovation.OutNodeId = resolveNodeId(innovation.OutNodeId)
+		if innovation.innovationType == newNodeInnType {
+			innovation.OldInnovNum = resolveInnovNum(innovation.OldInnovNum)
+			if existing, ok := j.pop.FindNodeInnovation(innovation.InNodeId, innovation.OutNodeId, innovation.OldInnovNum); ok {
+				innovNums[innovation.InnovationNum] = existing.InnovationNum
+				innovNums[innovation.InnovationNum2] = existing.InnovationNum2
+				nodeIds[innovation.NewNodeId] = existing.NewNodeId
+				continue
+			}
+			nodeIds[innovation.NewNodeId] = j.pop.NextNodeId()
+			innovNums[innovation.InnovationNum] = j.pop.NextInnovationNumber()
+			innovNums[innovation.InnovationNum2] = j.pop.NextInnovationNumber()
+			innovation.NewNodeId = nodeIds[innovation.NewNodeId]
+			innovation.InnovationNum2 = innovNums[innovation.InnovationNum2]
+		} else {
+			if existing, ok := j.pop.FindLinkInnovation(innovation.InNodeId, innovation.OutNodeId, innovation.IsRecurrent); ok {
+				innovNums[innovation.InnovationNum] = existing.InnovationNum
+				continue
+			}
+			innovNums[innovation.InnovationNum] = j.pop.NextInnovationNumber()
+		}
+		innovation.InnovationNum = innovNums[innovation.InnovationNum]
+		j.pop.StoreInnovation(innovation)
+	}
+
+	// assign final values to the provisional ones which are not referred by innovations
+	for num := int64(-1); num >= -j.innovNumsCount; num-- {
+		if _, ok := innovNums[num]; !ok {
+			innovNums[num] = j.pop.NextInnovationNumber()
+		}
+	}
+	for id := -1; id >= -j.nodeIdsCount; id-- {
+		if _, ok := nodeIds[id]; !ok {
+			nodeIds[id] = j.pop.NextNodeId()
+		}
+	}
+
+	if len(innovNums) == 0 && len(nodeIds) == 0 {
+		return
+	}
+	for _, baby := range babies {
+		if baby.Genotype.renumber(innovNums, nodeIds) {
+			// the phenotype should be rebuilt with final node IDs
+			baby.orgPhenotype = nil
+		}
+	}
+}
+
+// renumber is to replace innovation numbers of genes and IDs of nodes of this genome using provided mappings, keeping
+// genes and nodes ordered. Returns true if genome was changed.
+func (g *Genome) renumber(innovNums map[int64]int64, nodeIds map[int]int) bool {
+	changed := false
+	for _, node := range g.Nodes {
+		if id, ok := nodeIds[node.Id]; ok {
+			node.Id = id
+			changed = true
+		}
+	}
+	for _, gene := range g.Genes {
+		if num, ok := innovNums[gene.InnovationNum]; ok {
+			gene.InnovationNum = num
+			changed = true
+		}
+	}
+	if !changed {
+		return false
+	}
+
+	sort.SliceStable(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].Id < g.Nodes[j].Id
+	})
+	sort.SliceStable(g.Genes, func(i, j int) bool {
+		return g.Genes[i].InnovationNum < g.Genes[j].InnovationNum
+	})
+	g.nodeByIdMap = make(map[int]*network.NNode, len(g.Nodes))
+	for _, node := range g.Nodes {
+		g.nodeByIdMap[node.Id] = node
+	}
+	g.Phenotype = nil
+	return true
+}
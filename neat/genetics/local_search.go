@@ -0,0 +1,75 @@
+package genetics
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+)
+
+// TrainPhenotype Trains weights and biases of the acyclic phenotype of this organism by the gradient descent against provided
+// supervised dataset. If Lamarckian local search requested, the learned weights are written back into the genome
+// and will be inherited by offspring. Otherwise, (Baldwinian) the learned weights only kept by the phenotype used
+// for fitness evaluation. Returns false if phenotype can not be trained, because it's not acyclic.
+func (o *Organism) TrainPhenotype(dataset []network.TrainingSample, opts *neat.LocalSearchOptions) (bool, error) {
+	phenotype, err := o.Phenotype()
+	if err != nil {
+		return false, err
+	}
+	loss, err := phenotype.BackPropagate(dataset, opts.LearningRate, opts.Epochs)
+	if errors.Is(err, network.ErrNetworkNotFeedForward) {
+		neat.DebugLog(fmt.Sprintf("ORGANISM: local search skipped for organism with genome: %d, reason: %s",
+			o.Genotype.Id, err))
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if neat.LogLevel == neat.LogLevelDebug {
+		neat.DebugLog(fmt.Sprintf("ORGANISM: local search of organism with genome: %d finished, loss: %f",
+			o.Genotype.Id, loss))
+	}
+
+	if opts.Lamarckian {
+		err = o.Genotype.updateWeights(phenotype)
+	}
+	return err == nil, err
+}
+
+// updateWeights is to write weights of the phenotype links and biases of the phenotype nodes back into the
+// corresponding genes and nodes of this genome
+func (g *Genome) updateWeights(phenotype *network.Network) error {
+	nodes := make(map[int]*network.NNode, len(phenotype.AllNodes()))
+	for _, node := range phenotype.AllNodes() {
+		nodes[node.Id] = node
+	}
+	for _, node := range g.Nodes {
+		if pNode, ok := nodes[node.Id]; ok {
+			node.Bias = pNode.Bias
+		}
+	}
+	used := make(map[*network.Link]bool)
+	for _, gene := range g.Genes {
+		if !gene.IsEnabled {
+			continue
+		}
+		outNode, ok := nodes[gene.Link.OutNode.Id]
+		if !ok {
+			return errors.Errorf("phenotype has no node with ID: %d", gene.Link.OutNode.Id)
+		}
+		var link *network.Link
+		for _, l := range outNode.Incoming {
+			if !used[l] && l.InNode.Id == gene.Link.InNode.Id {
+				link = l
+				break
+			}
+		}
+		if link == nil {
+			return errors.Errorf("phenotype has no link corresponding to the gene: %s", gene)
+		}
+		used[link] = true
+		gene.Link.ConnectionWeight = link.ConnectionWeight
+		// Record the mutation
+		gene.MutationNum = link.ConnectionWeight
+	}
+	return nil
+}
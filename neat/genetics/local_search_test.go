@@ -0,0 +1,93 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"testing"
+)
+
+// the dataset of logical OR with two inputs
+var testTrainingDataset = []network.TrainingSample{
+	{Inputs: []float64{0, 0}, Outputs: []float64{0}},
+	{Inputs: []float64{0, 1}, Outputs: []float64{1}},
+	{Inputs: []float64{1, 0}, Outputs: []float64{1}},
+	{Inputs: []float64{1, 1}, Outputs: []float64{1}},
+}
+
+func buildTestTrainableOrganism(t *testing.T) *Organism {
+	gnome := buildTestGenome(1)
+	for _, gene := range gnome.Genes {
+		gene.Link.ConnectionWeight = 0.1
+	}
+	org, err := NewOrganism(0.0, gnome, 1)
+	require.NoError(t, err)
+	return org
+}
+
+func TestOrganism_TrainPhenotype_baldwinian(t *testing.T) {
+	org := buildTestTrainableOrganism(t)
+	opts := &neat.LocalSearchOptions{LearningRate: 0.5, Epochs: 10}
+
+	res, err := org.TrainPhenotype(testTrainingDataset, opts)
+	require.NoError(t, err)
+	require.True(t, res)
+
+	// the genome is unchanged, while phenotype learned
+	phenotype, err := org.Phenotype()
+	require.NoError(t, err)
+	learned := false
+	for _, gene := range org.Genotype.Genes {
+		assert.Equal(t, 0.1, gene.Link.ConnectionWeight)
+	}
+	for _, node := range phenotype.Outputs {
+		for _, link := range node.Incoming {
+			if link.ConnectionWeight != 0.1 {
+				learned = true
+			}
+		}
+	}
+	assert.True(t, learned, "phenotype weights not trained")
+}
+
+func TestOrganism_TrainPhenotype_lamarckian(t *testing.T) {
+	org := buildTestTrainableOrganism(t)
+	opts := &neat.LocalSearchOptions{LearningRate: 0.5, Epochs: 10, Lamarckian: true}
+
+	res, err := org.TrainPhenotype(testTrainingDataset, opts)
+	require.NoError(t, err)
+	require.True(t, res)
+
+	// the learned weights written back into the genome
+	phenotype, err := org.Phenotype()
+	require.NoError(t, err)
+	out := phenotype.Outputs[0]
+	require.Len(t, out.Incoming, len(org.Genotype.Genes))
+	for i, gene := range org.Genotype.Genes {
+		assert.NotEqual(t, 0.1, gene.Link.ConnectionWeight)
+		assert.Equal(t, out.Incoming[i].ConnectionWeight, gene.Link.ConnectionWeight, "at: %d", i)
+		assert.Equal(t, gene.Link.ConnectionWeight, gene.MutationNum, "at: %d", i)
+	}
+	// the learned bias written back into the genome
+	assert.NotZero(t, out.Bias)
+	assert.Equal(t, out.Bias, org.Genotype.Nodes[3].Bias)
+
+	// the phenotype of offspring has learned weights
+	child, err := org.Genotype.Genesis(2)
+	require.NoError(t, err)
+	for i, link := range child.Outputs[0].Incoming {
+		assert.Equal(t, out.Incoming[i].ConnectionWeight, link.ConnectionWeight, "at: %d", i)
+	}
+}
+
+func TestOrganism_TrainPhenotype_recurrent(t *testing.T) {
+	org := buildTestTrainableOrganism(t)
+	org.Genotype.Genes[0].Link.IsRecurrent = true
+	err := org.UpdatePhenotype()
+	require.NoError(t, err)
+
+	res, err := org.TrainPhenotype(testTrainingDataset, &neat.LocalSearchOptions{LearningRate: 0.5, Epochs: 10})
+	require.NoError(t, err)
+	assert.False(t, res, "recurrent phenotype must not be trained")
+}
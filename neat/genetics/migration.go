@@ -0,0 +1,120 @@
+package genetics
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"sort"
+)
+
+// ShareInnovations makes this population to share the database of innovations as well as the counters of innovation
+// numbers and node IDs with the given population. It should be used when organisms are exchanged between populations,
+// e.g. by islands of the island model, so that the genes of migrants can be aligned with the genes of native
+// organisms by innovation numbers during crossover. The shared innovations are not removed by the epoch turnover of
+// the population, and should be removed with ResetInnovations once all populations sharing them were turned over.
+func (p *Population) ShareInnovations(other *Population) {
+	owner := other.innovationsOwner()
+	if owner == p.innovationsOwner() {
+		return
+	}
+	// make sure that numbers already allocated by this population will not be reused
+	if p.nextNodeId > owner.nextNodeId {
+		owner.nextNodeId = p.nextNodeId
+	}
+	if p.nextInnovNum > owner.nextInnovNum {
+		owner.nextInnovNum = p.nextInnovNum
+	}
+	p.sharedInnovations = owner
+	p.innovations = owner.innovations
+	owner.innovationsShared = true
+}
+
+// ResetInnovations is to remove the innovations of the newest generation from the innovations database used by this
+// population, which may be shared with other populations.
+func (p *Population) ResetInnovations() {
+	p.innovations.Reset()
+}
+
+// sharesInnovations Returns true if innovations database used by this population is shared with other populations
+func (p *Population) sharesInnovations() bool {
+	return p.innovationsOwner().innovationsShared
+}
+
+// Emigrants Returns the copies of the given number of the most fit organisms of this population to be migrated into
+// another population. The organisms of this population should be evaluated before.
+func (p *Population) Emigrants(count int) ([]*Organism, error) {
+	if count > len(p.Organisms) {
+		return nil, errors.Errorf("not enough organisms in population for emigration, requested: %d, available: %d",
+			count, len(p.Organisms))
+	}
+	sorted := make(Organisms, len(p.Organisms))
+	copy(sorted, p.Organisms)
+	sort.Stable(sort.Reverse(sorted))
+
+	emigrants := make([]*Organism, count)
+	for i, org := range sorted[:count] {
+		emigrant, err := copyOrganism(org)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to copy emigrant")
+		}
+		emigrants[i] = emigrant
+	}
+	return emigrants, nil
+}
+
+// ReceiveMigrants Replaces the least fit organisms of this population by the given migrants and assigns migrants to
+// the species of this population. The organisms of this population and migrants should be evaluated before, so that
+// migrants compete with native organisms for the offspring in the next epoch.
+func (p *Population) ReceiveMigrants(ctx context.Context, migrants []*Organism) error {
+	if len(migrants) == 0 {
+		return nil
+	}
+	if len(migrants) >= len(p.Organisms) {
+		return errors.Errorf("too many migrants to receive: %d, population size: %d", len(migrants), len(p.Organisms))
+	}
+
+	// find the least fit organisms to be replaced
+	indexes := make([]int, len(p.Organisms))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return Organisms(p.Organisms).Less(indexes[i], indexes[j])
+	})
+
+	for i, migrant := range migrants {
+		index := indexes[i]
+		replaced := p.Organisms[index]
+		if _, err := replaced.Species.removeOrganism(replaced); err != nil {
+			return err
+		}
+		migrant.Genotype.Id = replaced.Genotype.Id
+		p.Organisms[index] = migrant
+
+		if neat.LogLevel == neat.LogLevelDebug {
+			neat.DebugLog(fmt.Sprintf("POPULATION: Organism [%d] with fitness: %f replaced by migrant with fitness: %f",
+				replaced.Genotype.Id, replaced.Fitness, migrant.Fitness))
+		}
+	}
+
+	// remove species left without organisms
+	speciesToKeep := make([]*Species, 0, len(p.Species))
+	for _, sp := range p.Species {
+		if len(sp.Organisms) > 0 {
+			speciesToKeep = append(speciesToKeep, sp)
+		}
+	}
+	p.Species = speciesToKeep
+
+	return p.speciate(ctx, migrants)
+}
+
+// innovationsOwner Returns the population which owns the innovations database and counters used by this population
+func (p *Population) innovationsOwner() *Population {
+	owner := p
+	for owner.sharedInnovations != nil {
+		owner = owner.sharedInnovations
+	}
+	return owner
+}
@@ -0,0 +1,140 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
+	"testing"
+)
+
+func buildTestMigrationPopulation(t *testing.T, seed int64) (*Population, *neat.Options) {
+	rand.Seed(seed)
+	conf := &neat.Options{
+		CompatThreshold:    0.5,
+		PopSize:            10,
+		NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+	gen, err := newGenomeRand(1, 3, 2, 3, 5, false, 0.5, conf, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	pop, err := NewPopulation(gen, conf)
+	require.NoError(t, err, "failed to create population")
+
+	// assign fitness
+	for i, org := range pop.Organisms {
+		org.Fitness = float64(i + 1)
+	}
+	return pop, conf
+}
+
+func TestPopulation_ShareInnovations(t *testing.T) {
+	owner, _ := buildTestMigrationPopulation(t, 42)
+	pop, _ := buildTestMigrationPopulation(t, 42)
+	pop.nextNodeId += 10
+	pop.nextInnovNum += 10
+
+	pop.ShareInnovations(owner)
+
+	// the counters of owner advanced to not reuse numbers allocated by shared population
+	assert.Equal(t, pop.nextNodeId, owner.nextNodeId)
+	assert.Equal(t, pop.nextInnovNum, owner.nextInnovNum)
+
+	expectedNodeId := int(owner.nextNodeId) + 1
+	assert.Equal(t, expectedNodeId, pop.NextNodeId())
+	assert.Equal(t, expectedNodeId+1, owner.NextNodeId())
+
+	expectedInnovNum := owner.nextInnovNum + 1
+	assert.Equal(t, expectedInnovNum, pop.NextInnovationNumber())
+	assert.Equal(t, expectedInnovNum+1, owner.NextInnovationNumber())
+
+	// the innovations database is shared
+	pop.StoreInnovation(*NewInnovationForLink(1, 4, 100, 0.5, 1))
+	_, found := owner.FindLinkInnovation(1, 4, false)
+	assert.True(t, found)
+
+	// sharing through the chain ends at the owner
+	other, _ := buildTestMigrationPopulation(t, 42)
+	other.ShareInnovations(pop)
+	assert.Equal(t, owner, other.sharedInnovations)
+}
+
+func TestPopulation_ShareInnovations_reset(t *testing.T) {
+	owner, conf := buildTestMigrationPopulation(t, 42)
+	pop, _ := buildTestMigrationPopulation(t, 42)
+	assert.False(t, owner.sharesInnovations())
+	pop.ShareInnovations(owner)
+	assert.True(t, owner.sharesInnovations())
+	assert.True(t, pop.sharesInnovations())
+
+	// the shared innovations are not removed by the epoch turnover of one population
+	owner.StoreInnovation(*NewInnovationForLink(1, 4, 100, 0.5, 1))
+	require.False(t, conf.KeepInnovations)
+	err := (&SequentialPopulationEpochExecutor{}).NextEpoch(conf.NeatContext(), 1, pop)
+	require.NoError(t, err)
+	_, found := owner.FindLinkInnovation(1, 4, false)
+	assert.True(t, found)
+
+	pop.ResetInnovations()
+	_, found = owner.FindLinkInnovation(1, 4, false)
+	assert.False(t, found)
+}
+
+func TestPopulation_Emigrants(t *testing.T) {
+	pop, _ := buildTestMigrationPopulation(t, 42)
+
+	emigrants, err := pop.Emigrants(3)
+	require.NoError(t, err)
+	require.Len(t, emigrants, 3)
+	for i, emigrant := range emigrants {
+		expected := pop.Organisms[len(pop.Organisms)-1-i]
+		assert.Equal(t, expected.Fitness, emigrant.Fitness)
+		assert.NotSame(t, expected.Genotype, emigrant.Genotype, "genome should be copied")
+		equal, err := expected.Genotype.IsEqual(emigrant.Genotype)
+		assert.NoError(t, err)
+		assert.True(t, equal, "genome of emigrant is different at: %d", i)
+	}
+
+	_, err = pop.Emigrants(len(pop.Organisms) + 1)
+	assert.Error(t, err)
+}
+
+func TestPopulation_ReceiveMigrants(t *testing.T) {
+	source, _ := buildTestMigrationPopulation(t, 42)
+	pop, conf := buildTestMigrationPopulation(t, 41)
+	pop.ShareInnovations(source)
+	for _, org := range source.Organisms {
+		org.Fitness += 100
+	}
+
+	migrants, err := source.Emigrants(2)
+	require.NoError(t, err)
+	err = pop.ReceiveMigrants(conf.NeatContext(), migrants)
+	require.NoError(t, err)
+
+	// the least fit organisms replaced
+	require.Len(t, pop.Organisms, conf.PopSize)
+	assert.Same(t, migrants[0], pop.Organisms[0])
+	assert.Same(t, migrants[1], pop.Organisms[1])
+	assert.Equal(t, 0, migrants[0].Genotype.Id)
+	assert.Equal(t, 1, migrants[1].Genotype.Id)
+
+	// all organisms belong to species of population
+	count := 0
+	for _, sp := range pop.Species {
+		assert.NotEmpty(t, sp.Organisms, "empty species: %d", sp.Id)
+		count += len(sp.Organisms)
+	}
+	assert.Equal(t, conf.PopSize, count)
+	for _, org := range pop.Organisms {
+		require.NotNil(t, org.Species)
+		assert.Contains(t, pop.Species, org.Species)
+	}
+
+	// too many migrants
+	migrants, err = source.Emigrants(conf.PopSize)
+	require.NoError(t, err)
+	err = pop.ReceiveMigrants(conf.NeatContext(), migrants)
+	assert.Error(t, err)
+}
@@ -0,0 +1,298 @@
+package genetics
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"math/rand"
+)
+
+// The names of the built-in mutation operators
+const (
+	MutationAddNode        = "add_node"
+	MutationAddLink        = "add_link"
+	MutationConnectSensors = "connect_sensors"
+	MutationRandomTrait    = "random_trait"
+	MutationLinkTrait      = "link_trait"
+	MutationNodeTrait      = "node_trait"
+	MutationLinkWeights    = "link_weights"
+	MutationToggleEnable   = "toggle_enable"
+	MutationGeneReenable   = "gene_reenable"
+	MutationDeleteLink     = "delete_link"
+	MutationDeleteNode     = "delete_node"
+	MutationNodeActivation = "node_activation"
+	MutationNodeBiases     = "node_biases"
+)
+
+// MutationKind defines the kind of mutation operator
+type MutationKind byte
+
+const (
+	// StructuralMutation the mutation changing the topology of the genome. Only one structural mutation is applied
+	// to the genome at a time.
+	StructuralMutation MutationKind = iota + 1
+	// NonStructuralMutation the mutation changing the parameters of the genome. Applied only if no structural mutation
+	// was applied, each one independently of others.
+	NonStructuralMutation
+)
+
+// MutationEnvironment The environment of the genome mutation, which provides access to the population-wide state
+type MutationEnvironment struct {
+	// The observer of the innovations to track the new structural innovations
+	Innovations InnovationsObserver
+	// The generator of IDs for new nodes
+	NodeIdGenerator network.NodeIdGenerator
+	// The current generation
+	Generation int
+	// The NEAT options
+	Options *neat.Options
+	// The random numbers generator. If not set, the generator backed by the global generator of math/rand is used.
+	Rand *rand.Rand
+}
+
+// MutationOperator The function to apply mutation to the genome. Returns true if genome was mutated.
+type MutationOperator func(g *Genome, env *MutationEnvironment) (bool, error)
+
+// MutationProbability The function to get the probability of mutation from the NEAT options
+type MutationProbability func(opts *neat.Options) float64
+
+// FixedProbability Returns the mutation probability function, which always returns the provided value
+func FixedProbability(probability float64) MutationProbability {
+	return func(_ *neat.Options) float64 {
+		return probability
+	}
+}
+
+// MutationInfo The mutation operator registered with the mutation registry
+type MutationInfo struct {
+	// The unique name of the mutation
+	Name string
+	// The kind of the mutation
+	Kind MutationKind
+	// The probability of the mutation
+	Probability MutationProbability
+	// The mutation operator
+	Operator MutationOperator
+}
+
+// MutationRegistry The registry of mutation operators applied to the genomes of offspring during reproduction.
+// The structural mutations are tried in order of registration, and the first one passing its probability check is
+// applied. If no structural mutation was applied, each non-structural mutation is applied with its probability.
+type MutationRegistry struct {
+	mutations []*MutationInfo
+}
+
+// NewMutationRegistry Creates new empty mutation registry
+func NewMutationRegistry() *MutationRegistry {
+	return &MutationRegistry{mutations: make([]*MutationInfo, 0)}
+}
+
+// NewDefaultMutationRegistry Creates new mutation registry with all built-in mutation operators registered with
+// probabilities defined by the NEAT options.
+func NewDefaultMutationRegistry() *MutationRegistry {
+	r := NewMutationRegistry()
+	// As in the original NEAT, the structural mutation attempt counts even if no new structure was found
+	r.mustRegister(MutationAddNode, StructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateAddNodeProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		_, err := g.mutateAddNode(env.Innovations, env.NodeIdGenerator, env.Options, env.Rand)
+		return true, err
+	})
+	r.mustRegister(MutationAddLink, StructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateAddLinkProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		_, err := g.mutateAddLink(env.Innovations, env.Generation, env.Options, env.Rand)
+		return true, err
+	})
+	r.mustRegister(MutationConnectSensors, StructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateConnectSensors
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateConnectSensors(env.Innovations, env.Options, env.Rand)
+	})
+	r.mustRegister(MutationDeleteNode, StructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateDeleteNodeProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateDeleteNode(env.Rand)
+	})
+	r.mustRegister(MutationDeleteLink, StructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateDeleteLinkProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateDeleteLink(env.Rand)
+	})
+
+	r.mustRegister(MutationRandomTrait, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateRandomTraitProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateRandomTrait(env.Options, env.Rand)
+	})
+	r.mustRegister(MutationLinkTrait, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateLinkTraitProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateLinkTrait(1, env.Rand)
+	})
+	r.mustRegister(MutationNodeTrait, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateNodeTraitProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateNodeTrait(1, env.Rand)
+	})
+	r.mustRegister(MutationLinkWeights, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateLinkWeightsProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		if env.Options.WeightMutPowerAdaptive {
+			return g.mutateLinkWeightsAdaptive(env.Options, env.Rand)
+		}
+		return g.mutateLinkWeights(env.Options.WeightMutPower, 1.0, gaussianMutator, env.Rand)
+	})
+	r.mustRegister(MutationToggleEnable, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateToggleEnableProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateToggleEnable(1, env.Rand)
+	})
+	r.mustRegister(MutationGeneReenable, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateGeneReenableProb
+	}, func(g *Genome, _ *MutationEnvironment) (bool, error) {
+		return g.mutateGeneReEnable()
+	})
+	r.mustRegister(MutationNodeActivation, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateNodeActivationProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateNodeActivation(env.Options, env.Rand)
+	})
+	r.mustRegister(MutationNodeBiases, NonStructuralMutation, func(opts *neat.Options) float64 {
+		return opts.MutateNodeBiasProb
+	}, func(g *Genome, env *MutationEnvironment) (bool, error) {
+		return g.mutateNodeBiases(env.Options.NodeBiasMutationPower(), env.Rand)
+	})
+	return r
+}
+
+// NewSimplifyingMutationRegistry Creates new mutation registry for the simplifying phase of the phased search derived
+// from the default registry. See Simplifying for details.
+func NewSimplifyingMutationRegistry() *MutationRegistry {
+	return NewDefaultMutationRegistry().Simplifying()
+}
+
+// Simplifying Returns new mutation registry derived from this one to be used during the simplifying phase of
+// the phased search. The only structural mutations of the derived registry are the deletion mutations of this one,
+// i.e. registered as MutationDeleteNode and MutationDeleteLink, with probabilities defined by the phased search
+// options. The non-structural mutations of this registry are kept, so that the offspring is still mutated when
+// no deletion applies.
+func (r *MutationRegistry) Simplifying() *MutationRegistry {
+	simplifying := NewMutationRegistry()
+	for _, m := range r.mutations {
+		switch {
+		case m.Name == MutationDeleteNode:
+			simplifying.mutations = append(simplifying.mutations, &MutationInfo{
+				Name: m.Name, Kind: StructuralMutation, Operator: m.Operator,
+				Probability: func(opts *neat.Options) float64 {
+					return opts.PhasedSearch.DeleteNodeProb
+				},
+			})
+		case m.Name == MutationDeleteLink:
+			simplifying.mutations = append(simplifying.mutations, &MutationInfo{
+				Name: m.Name, Kind: StructuralMutation, Operator: m.Operator,
+				Probability: func(opts *neat.Options) float64 {
+					return opts.PhasedSearch.DeleteLinkProb
+				},
+			})
+		case m.Kind == NonStructuralMutation:
+			simplifying.mutations = append(simplifying.mutations, m)
+		}
+	}
+	return simplifying
+}
+
+// Register is to register the mutation operator of given kind with given unique name and probability
+func (r *MutationRegistry) Register(name string, kind MutationKind, probability MutationProbability, operator MutationOperator) error {
+	if kind != StructuralMutation && kind != NonStructuralMutation {
+		return errors.Errorf("unsupported mutation kind: %d", kind)
+	}
+	if probability == nil || operator == nil {
+		return errors.Errorf("mutation [%s] must have probability and operator", name)
+	}
+	if r.Find(name) != nil {
+		return errors.Errorf("mutation [%s] already registered", name)
+	}
+	r.mutations = append(r.mutations, &MutationInfo{
+		Name:        name,
+		Kind:        kind,
+		Probability: probability,
+		Operator:    operator,
+	})
+	return nil
+}
+
+// Unregister is to remove the mutation operator with given name. Returns false if mutation was not registered.
+func (r *MutationRegistry) Unregister(name string) bool {
+	for i, m := range r.mutations {
+		if m.Name == name {
+			r.mutations = append(r.mutations[:i], r.mutations[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Find Returns the registered mutation with given name or nil if not found
+func (r *MutationRegistry) Find(name string) *MutationInfo {
+	for _, m := range r.mutations {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Mutations Returns the list of registered mutations in order of registration
+func (r *MutationRegistry) Mutations() []*MutationInfo {
+	return r.mutations
+}
+
+// Mutate is to apply registered mutations to the genome. At most one structural mutation is applied, and if none
+// applied, all non-structural mutations are tried. Returns true if structural mutation was applied.
+func (r *MutationRegistry) Mutate(g *Genome, env *MutationEnvironment) (bool, error) {
+	if env.Rand == nil {
+		withRand := *env
+		withRand.Rand = neat.GlobalRand()
+		env = &withRand
+	}
+	structural := false
+	for _, m := range r.mutations {
+		if m.Kind != StructuralMutation {
+			continue
+		}
+		if env.Rand.Float64() < m.Probability(env.Options) {
+			neat.DebugLog(fmt.Sprintf("MUTATION: ---> %s", m.Name))
+			mutated, err := m.Operator(g, env)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to apply mutation [%s]", m.Name)
+			}
+			structural = mutated
+			break
+		}
+	}
+	if structural {
+		return true, nil
+	}
+
+	neat.DebugLog("MUTATION: ---> non structural")
+	for _, m := range r.mutations {
+		if m.Kind != NonStructuralMutation {
+			continue
+		}
+		if env.Rand.Float64() < m.Probability(env.Options) {
+			if _, err := m.Operator(g, env); err != nil {
+				return false, errors.Wrapf(err, "failed to apply mutation [%s]", m.Name)
+			}
+		}
+	}
+	return false, nil
+}
+
+// mustRegister is to register the built-in mutation operator
+func (r *MutationRegistry) mustRegister(name string, kind MutationKind, probability MutationProbability, operator MutationOperator) {
+	if err := r.Register(name, kind, probability, operator); err != nil {
+		panic(err)
+	}
+}
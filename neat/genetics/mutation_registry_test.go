@@ -0,0 +1,157 @@
+package genetics
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
+	"testing"
+)
+
+func buildTestMutationEnvironment(t *testing.T, gnome *Genome) *MutationEnvironment {
+	opts := &neat.Options{
+		PopSize:            1,
+		NodeActivators:     []math.NodeActivationType{math.SigmoidSteepenedActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+	pop := newPopulation()
+	err := pop.spawn(opts.NeatContext(), gnome)
+	require.NoError(t, err, "failed to spawn population")
+	return &MutationEnvironment{
+		Innovations:     pop,
+		NodeIdGenerator: pop,
+		Generation:      1,
+		Options:         opts,
+	}
+}
+
+func TestNewDefaultMutationRegistry(t *testing.T) {
+	r := NewDefaultMutationRegistry()
+	expected := []string{
+		MutationAddNode, MutationAddLink, MutationConnectSensors, MutationDeleteNode, MutationDeleteLink,
+		MutationRandomTrait, MutationLinkTrait, MutationNodeTrait, MutationLinkWeights, MutationToggleEnable, MutationGeneReenable,
+		MutationNodeActivation, MutationNodeBiases,
+	}
+	require.Len(t, r.Mutations(), len(expected))
+	for i, name := range expected {
+		assert.Equal(t, name, r.Mutations()[i].Name)
+	}
+	assert.Equal(t, StructuralMutation, r.Find(MutationAddNode).Kind)
+	assert.Equal(t, NonStructuralMutation, r.Find(MutationLinkWeights).Kind)
+
+	// check probabilities read from options
+	opts := &neat.Options{MutateAddNodeProb: 0.3, MutateLinkWeightsProb: 0.7}
+	assert.Equal(t, 0.3, r.Find(MutationAddNode).Probability(opts))
+	assert.Equal(t, 0.7, r.Find(MutationLinkWeights).Probability(opts))
+}
+
+func TestMutationRegistry_Register(t *testing.T) {
+	r := NewMutationRegistry()
+	op := func(_ *Genome, _ *MutationEnvironment) (bool, error) {
+		return true, nil
+	}
+	err := r.Register("custom", StructuralMutation, FixedProbability(0.5), op)
+	require.NoError(t, err)
+	require.NotNil(t, r.Find("custom"))
+	assert.Equal(t, 0.5, r.Find("custom").Probability(nil))
+
+	// duplicate
+	err = r.Register("custom", NonStructuralMutation, FixedProbability(0.5), op)
+	assert.Error(t, err)
+	// wrong kind
+	err = r.Register("other", MutationKind(0), FixedProbability(0.5), op)
+	assert.Error(t, err)
+	// no operator
+	err = r.Register("other", NonStructuralMutation, FixedProbability(0.5), nil)
+	assert.Error(t, err)
+
+	assert.True(t, r.Unregister("custom"))
+	assert.False(t, r.Unregister("custom"))
+	assert.Nil(t, r.Find("custom"))
+	assert.Empty(t, r.Mutations())
+}
+
+func TestMutationRegistry_Mutate(t *testing.T) {
+	gnome := buildTestGenome(1)
+	env := buildTestMutationEnvironment(t, gnome)
+
+	calls := make([]string, 0)
+	operator := func(name string, result bool) MutationOperator {
+		return func(_ *Genome, _ *MutationEnvironment) (bool, error) {
+			calls = append(calls, name)
+			return result, nil
+		}
+	}
+
+	// only the first structural mutation is applied
+	r := NewMutationRegistry()
+	require.NoError(t, r.Register("never", StructuralMutation, FixedProbability(0), operator("never", true)))
+	require.NoError(t, r.Register("first", StructuralMutation, FixedProbability(1), operator("first", true)))
+	require.NoError(t, r.Register("second", StructuralMutation, FixedProbability(1), operator("second", true)))
+	require.NoError(t, r.Register("weights", NonStructuralMutation, FixedProbability(1), operator("weights", true)))
+	structural, err := r.Mutate(gnome, env)
+	require.NoError(t, err)
+	assert.True(t, structural)
+	assert.Equal(t, []string{"first"}, calls)
+
+	// the structural mutation failed - all non-structural applied
+	calls = calls[:0]
+	r = NewMutationRegistry()
+	require.NoError(t, r.Register("first", StructuralMutation, FixedProbability(1), operator("first", false)))
+	require.NoError(t, r.Register("weights", NonStructuralMutation, FixedProbability(1), operator("weights", true)))
+	require.NoError(t, r.Register("traits", NonStructuralMutation, FixedProbability(1), operator("traits", true)))
+	require.NoError(t, r.Register("never", NonStructuralMutation, FixedProbability(0), operator("never", true)))
+	structural, err = r.Mutate(gnome, env)
+	require.NoError(t, err)
+	assert.False(t, structural)
+	assert.Equal(t, []string{"first", "weights", "traits"}, calls)
+}
+
+func TestMutationRegistry_Mutate_error(t *testing.T) {
+	gnome := buildTestGenome(1)
+	env := buildTestMutationEnvironment(t, gnome)
+	errFoo := errors.New("foo")
+
+	r := NewMutationRegistry()
+	err := r.Register("failing", NonStructuralMutation, FixedProbability(1), func(_ *Genome, _ *MutationEnvironment) (bool, error) {
+		return false, errFoo
+	})
+	require.NoError(t, err)
+	_, err = r.Mutate(gnome, env)
+	assert.ErrorIs(t, err, errFoo)
+}
+
+func TestMutationRegistry_Mutate_builtIn(t *testing.T) {
+	gnome := buildTestGenome(1)
+	env := buildTestMutationEnvironment(t, gnome)
+	env.Options.MutateAddNodeProb = 1.0
+
+	structural, err := NewDefaultMutationRegistry().Mutate(gnome, env)
+	require.NoError(t, err)
+	assert.True(t, structural)
+	assert.Len(t, gnome.Genes, 5, "new node must be added")
+	assert.Len(t, gnome.Nodes, 5, "new node must be added")
+}
+
+func TestPopulationEpochExecutor_NextEpoch_customMutation(t *testing.T) {
+	rand.Seed(42)
+	opts := speciationTestOptions()
+	gen, err := newGenomeRand(1, 3, 2, 3, 15, false, 0.8, opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	pop, err := NewPopulation(gen, opts)
+	require.NoError(t, err, "failed to create population")
+
+	calls := 0
+	pop.Mutations = NewDefaultMutationRegistry()
+	err = pop.Mutations.Register("custom", NonStructuralMutation, FixedProbability(1.0), func(g *Genome, _ *MutationEnvironment) (bool, error) {
+		calls++
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	err = (&SequentialPopulationEpochExecutor{}).NextEpoch(opts.NeatContext(), 1, pop)
+	require.NoError(t, err)
+	assert.True(t, calls > 0, "custom mutation must be invoked")
+}
@@ -0,0 +1,170 @@
+package genetics
+
+import (
+	"fmt"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"math"
+	"sort"
+)
+
+// BehaviorDistanceFunc The function to calculate the distance between two behavior descriptors
+type BehaviorDistanceFunc func(a, b []float64) (float64, error)
+
+// EuclideanBehaviorDistance Returns the Euclidean distance between two behavior descriptors
+func EuclideanBehaviorDistance(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("behavior descriptors size mismatch: %d != %d", len(a), len(b))
+	}
+	sum := 0.0
+	for i := range a {
+		diff := a[i] - b[i]
+		sum += diff * diff
+	}
+	return math.Sqrt(sum), nil
+}
+
+// NoveltyItem The behavior stored in the novelty archive
+type NoveltyItem struct {
+	// The behavior descriptor
+	Behavior []float64
+	// The novelty score of the behavior when it was added to the archive
+	Novelty float64
+	// The generation when the behavior was added to the archive
+	Generation int
+	// The ID of the genome of the organism demonstrated the behavior
+	GenomeId int
+}
+
+// NoveltyArchive The archive of novel behaviors found during the novelty search. The novelty of the organism's behavior
+// is estimated as its sparseness, i.e. the average distance to the k-nearest neighbors among behaviors of the
+// current population and the archive.
+type NoveltyArchive struct {
+	// The novel behaviors stored in the archive
+	Items []*NoveltyItem
+	// The current novelty threshold to add behavior to the archive
+	Threshold float64
+	// The function to calculate the distance between behaviors
+	DistanceFunc BehaviorDistanceFunc
+
+	// The number of generations without additions to the archive
+	generationsWithoutAdds int
+	// The novelty search options
+	opts *neat.NoveltySearchOptions
+}
+
+// NewNoveltyArchive Creates new novelty archive with given options, which uses Euclidean distance between behaviors
+func NewNoveltyArchive(opts *neat.NoveltySearchOptions) *NoveltyArchive {
+	return &NoveltyArchive{
+		Items:        make([]*NoveltyItem, 0),
+		Threshold:    opts.ArchiveThreshold,
+		DistanceFunc: EuclideanBehaviorDistance,
+		opts:         opts,
+	}
+}
+
+// Sparseness Returns the sparseness of the behavior, i.e. the average distance to its k-nearest neighbors among
+// provided behaviors and behaviors stored in the archive.
+func (a *NoveltyArchive) Sparseness(behavior []float64, others [][]float64) (float64, error) {
+	distances := make([]float64, 0, len(others)+len(a.Items))
+	for _, other := range others {
+		distance, err := a.DistanceFunc(behavior, other)
+		if err != nil {
+			return 0, err
+		}
+		distances = append(distances, distance)
+	}
+	for _, item := range a.Items {
+		distance, err := a.DistanceFunc(behavior, item.Behavior)
+		if err != nil {
+			return 0, err
+		}
+		distances = append(distances, distance)
+	}
+	if len(distances) == 0 {
+		return 0, nil
+	}
+	sort.Float64s(distances)
+
+	k := a.opts.KNearest
+	if k > len(distances) {
+		k = len(distances)
+	}
+	sum := 0.0
+	for _, d := range distances[:k] {
+		sum += d
+	}
+	return sum / float64(k), nil
+}
+
+// EvaluatePopulation is to estimate the novelty of each organism with behavior descriptor and to add the most novel
+// behaviors to the archive. The novelty threshold is adjusted afterwards depending on the number of additions.
+func (a *NoveltyArchive) EvaluatePopulation(organisms []*Organism, generation int) error {
+	evaluated := make([]*Organism, 0, len(organisms))
+	behaviors := make([][]float64, 0, len(organisms))
+	for _, org := range organisms {
+		org.Novelty = 0
+		if org.Behavior != nil {
+			evaluated = append(evaluated, org)
+			behaviors = append(behaviors, org.Behavior)
+		}
+	}
+
+	// estimate novelty against the archive state at the start of generation
+	others := make([][]float64, 0, len(behaviors))
+	for i, org := range evaluated {
+		others = append(others[:0], behaviors[:i]...)
+		others = append(others, behaviors[i+1:]...)
+		novelty, err := a.Sparseness(org.Behavior, others)
+		if err != nil {
+			return err
+		}
+		org.Novelty = novelty
+	}
+
+	added := 0
+	for _, org := range evaluated {
+		if org.Novelty > a.Threshold {
+			a.Items = append(a.Items, &NoveltyItem{
+				Behavior:   org.Behavior,
+				Novelty:    org.Novelty,
+				Generation: generation,
+				GenomeId:   org.Genotype.Id,
+			})
+			added++
+		}
+	}
+	a.adjustThreshold(added)
+
+	// remove the oldest behaviors if archive is too big
+	if a.opts.ArchiveMaxSize > 0 && len(a.Items) > a.opts.ArchiveMaxSize {
+		a.Items = a.Items[len(a.Items)-a.opts.ArchiveMaxSize:]
+	}
+
+	if neat.LogLevel == neat.LogLevelDebug {
+		neat.DebugLog(fmt.Sprintf("NOVELTY: added %d behaviors to the archive, archive size: %d, threshold: %f\n",
+			added, len(a.Items), a.Threshold))
+	}
+	return nil
+}
+
+// adjustThreshold is to adjust the novelty threshold depending on the number of behaviors added to the archive
+// during the last generation
+func (a *NoveltyArchive) adjustThreshold(added int) {
+	if added == 0 {
+		a.generationsWithoutAdds++
+		if a.generationsWithoutAdds > a.opts.ArchiveStagnationTime {
+			a.Threshold = math.Max(a.Threshold*a.opts.ArchiveThresholdLower, a.opts.ArchiveThresholdMin)
+			a.generationsWithoutAdds = 0
+		}
+		return
+	}
+	a.generationsWithoutAdds = 0
+	if a.opts.ArchiveMaxAdds > 0 && added > a.opts.ArchiveMaxAdds {
+		a.Threshold *= a.opts.ArchiveThresholdRaise
+	}
+}
+
+// noveltyFitness Returns the objective fitness of organism blended with its novelty score
+func noveltyFitness(org *Organism, opts *neat.NoveltySearchOptions) float64 {
+	return opts.ObjectiveWeight*org.Fitness + (1-opts.ObjectiveWeight)*org.Novelty
+}
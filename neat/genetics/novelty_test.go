@@ -0,0 +1,185 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
+	"testing"
+)
+
+func testNoveltySearchOptions() *neat.NoveltySearchOptions {
+	return &neat.NoveltySearchOptions{
+		KNearest:              2,
+		ArchiveThreshold:      1.0,
+		ArchiveThresholdMin:   0.1,
+		ArchiveThresholdRaise: 1.2,
+		ArchiveThresholdLower: 0.5,
+		ArchiveMaxAdds:        1,
+		ArchiveStagnationTime: 1,
+		ObjectiveWeight:       0.5,
+	}
+}
+
+func buildTestBehaviorOrganisms(behaviors [][]float64) []*Organism {
+	organisms := make([]*Organism, len(behaviors))
+	for i, b := range behaviors {
+		organisms[i] = &Organism{Genotype: buildTestGenome(i + 1), Behavior: b}
+	}
+	return organisms
+}
+
+func TestEuclideanBehaviorDistance(t *testing.T) {
+	distance, err := EuclideanBehaviorDistance([]float64{0, 0}, []float64{3, 4})
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, distance)
+
+	_, err = EuclideanBehaviorDistance([]float64{0, 0}, []float64{3})
+	assert.Error(t, err)
+}
+
+func TestNoveltyArchive_Sparseness(t *testing.T) {
+	archive := NewNoveltyArchive(testNoveltySearchOptions())
+	archive.Items = append(archive.Items, &NoveltyItem{Behavior: []float64{0, 1}})
+
+	others := [][]float64{{0, 2}, {0, 10}, {0, 4}}
+	sparseness, err := archive.Sparseness([]float64{0, 0}, others)
+	require.NoError(t, err)
+	// two nearest are archived {0, 1} and {0, 2}
+	assert.Equal(t, 1.5, sparseness)
+
+	// less neighbors than k
+	sparseness, err = archive.Sparseness([]float64{0, 0}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, sparseness)
+
+	// size mismatch
+	_, err = archive.Sparseness([]float64{0}, others)
+	assert.Error(t, err)
+}
+
+func TestNoveltyArchive_EvaluatePopulation(t *testing.T) {
+	archive := NewNoveltyArchive(testNoveltySearchOptions())
+	organisms := buildTestBehaviorOrganisms([][]float64{{0}, {1}, {3}, {10}, nil})
+
+	err := archive.EvaluatePopulation(organisms, 1)
+	require.NoError(t, err)
+
+	expected := []float64{2.0, 1.5, 2.5, 8.0, 0.0}
+	for i, org := range organisms {
+		assert.Equal(t, expected[i], org.Novelty, "wrong novelty at: %d", i)
+	}
+	// all organisms with behavior are above threshold
+	require.Len(t, archive.Items, 4)
+	assert.Equal(t, []float64{0}, archive.Items[0].Behavior)
+	assert.Equal(t, 1, archive.Items[0].Generation)
+	assert.Equal(t, organisms[0].Genotype.Id, archive.Items[0].GenomeId)
+	// too many additions - threshold raised
+	assert.InDelta(t, 1.2, archive.Threshold, 1e-9)
+}
+
+func TestNoveltyArchive_EvaluatePopulation_thresholdLowered(t *testing.T) {
+	archive := NewNoveltyArchive(testNoveltySearchOptions())
+	organisms := buildTestBehaviorOrganisms([][]float64{{0}, {0.1}, {0.2}})
+
+	// no additions during stagnation time
+	err := archive.EvaluatePopulation(organisms, 1)
+	require.NoError(t, err)
+	assert.Empty(t, archive.Items)
+	assert.Equal(t, 1.0, archive.Threshold)
+
+	err = archive.EvaluatePopulation(organisms, 2)
+	require.NoError(t, err)
+	assert.Empty(t, archive.Items)
+	assert.Equal(t, 0.5, archive.Threshold)
+
+	// threshold can not be lowered below minimal
+	for i := 3; i < 10; i++ {
+		err = archive.EvaluatePopulation(organisms, i)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0.1, archive.Threshold)
+}
+
+func TestNoveltyArchive_EvaluatePopulation_maxSize(t *testing.T) {
+	opts := testNoveltySearchOptions()
+	opts.ArchiveThreshold = 0
+	opts.ArchiveThresholdMin = 0
+	opts.ArchiveMaxSize = 2
+	archive := NewNoveltyArchive(opts)
+	organisms := buildTestBehaviorOrganisms([][]float64{{0}, {1}, {3}})
+
+	err := archive.EvaluatePopulation(organisms, 1)
+	require.NoError(t, err)
+	require.Len(t, archive.Items, 2)
+	assert.Equal(t, []float64{1}, archive.Items[0].Behavior)
+	assert.Equal(t, []float64{3}, archive.Items[1].Behavior)
+}
+
+func TestNoveltyFitness(t *testing.T) {
+	opts := testNoveltySearchOptions()
+	assert.Equal(t, 6.0, noveltyFitness(&Organism{Fitness: 10, Novelty: 2}, opts))
+	assert.Equal(t, 2.0, noveltyFitness(&Organism{Fitness: 0, Novelty: 4}, opts))
+
+	opts.ObjectiveWeight = 0
+	assert.Equal(t, 2.0, noveltyFitness(&Organism{Fitness: 10, Novelty: 2}, opts))
+}
+
+func TestSpecies_adjustFitness_noveltySearch(t *testing.T) {
+	sp, err := buildSpeciesWithOrganisms(1)
+	require.NoError(t, err, "failed to build species")
+	// the novelty orders organisms opposite to their objective fitness
+	for i, o := range sp.Organisms {
+		o.Novelty = float64(30 - 10*i)
+	}
+
+	conf := neat.Options{
+		DropOffAge:      5,
+		SurvivalThresh:  0.5,
+		AgeSignificance: 1,
+		NoveltySearch:   &neat.NoveltySearchOptions{ObjectiveWeight: 0},
+	}
+	sp.adjustFitness(&conf)
+
+	// the selection follows the novelty, while the progress is tracked by the objective fitness
+	assert.Equal(t, 10.0, sp.Organisms[0].Fitness)
+	assert.Equal(t, 5.0, sp.Organisms[0].originalFitness)
+	assert.Equal(t, 15.0, sp.MaxFitnessEver)
+}
+
+func TestPopulationEpochExecutor_NextEpoch_noveltySearch(t *testing.T) {
+	rand.Seed(42)
+	in, out, maxHidden, n := 3, 2, 15, 3
+	linkProb := 0.8
+	conf := &neat.Options{
+		CompatThreshold:    0.5,
+		DropOffAge:         1,
+		PopSize:            30,
+		BabiesStolen:       10,
+		RecurOnlyProb:      0.2,
+		NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb: []float64{1.0},
+		NoveltySearch:      testNoveltySearchOptions(),
+	}
+	neat.LogLevel = neat.LogLevelInfo
+	gen, err := newGenomeRand(1, in, out, n, maxHidden, false, linkProb, conf, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+
+	pop, err := NewPopulation(gen, conf)
+	require.NoError(t, err, "failed to create population")
+
+	executors := []PopulationEpochExecutor{&SequentialPopulationEpochExecutor{}, &ParallelPopulationEpochExecutor{}}
+	for _, ex := range executors {
+		for i := 0; i < 10; i++ {
+			for _, org := range pop.Organisms {
+				org.Fitness = rand.Float64()
+				org.Behavior = []float64{rand.Float64() * 10, rand.Float64() * 10}
+			}
+			err = ex.NextEpoch(conf.NeatContext(), i+1, pop)
+			require.NoError(t, err, "failed at: %d epoch", i)
+		}
+	}
+	require.NotNil(t, pop.NoveltyArchive)
+	assert.NotEmpty(t, pop.NoveltyArchive.Items)
+}
@@ -3,6 +3,7 @@ package genetics
 import (
 	"bytes"
 	"fmt"
+	"github.com/yaricom/goNEAT/v4/neat"
 	"github.com/yaricom/goNEAT/v4/neat/network"
 )
 
@@ -25,6 +26,17 @@ type Organism struct {
 	// Win marker (if needed for a particular task)
 	IsWinner bool
 
+	// The behavior descriptor of the organism, i.e. the characterization of its behavior used by novelty search
+	Behavior []float64
+	// The novelty score of the organism's behavior, i.e. its sparseness among behaviors of population and archive
+	Novelty float64
+	// The vector of objectives to be maximized by the multi-objective selection, e.g. fitness, negated complexity, etc.
+	Objectives []float64
+	// The scalar reflecting the Pareto front and the crowding distance of the organism's objectives within its
+	// generation. It's set by the multi-objective selection and replaces the fitness only for the fitness sharing and
+	// selection of parents, as it's not comparable between generations.
+	ParetoFitness float64
+
 	// The Organism's genotype
 	Genotype *Genome
 	// The Species of the Organism
@@ -34,6 +46,9 @@ type Organism struct {
 	ExpectedOffspring float64
 	// Tells which generation this Organism is from
 	Generation int
+	// The time this Organism was evaluated for, e.g. the number of simulation ticks. It's used by the real-time NEAT
+	// to find organisms evaluated enough to be removed.
+	TimeAlive int
 
 	// The utility data transfer object to be used by different GA implementations to hold additional data.
 	// Implemented as ANY to allow implementation specific objects.
@@ -60,6 +75,11 @@ type Organism struct {
 	// DEBUG variable - highest fitness of champ
 	highestFitness float64
 
+	// The index of the Pareto front this organism belongs to (zero is the non-dominated front)
+	paretoRank int
+	// The crowding distance of this organism within its Pareto front
+	crowdingDistance float64
+
 	// Track its origin - for debugging or analysis - we can tell how the organism was born
 	mutationStructBaby bool
 	mateBaby           bool
@@ -79,6 +99,25 @@ func NewOrganism(fit float64, g *Genome, generation int) (org *Organism, err err
 	return org, nil
 }
 
+// copyOrganism is to create the copy of given organism with duplicate genome detached from species and population.
+// The evaluation results of organism are copied as well.
+func copyOrganism(org *Organism) (*Organism, error) {
+	genome, err := org.Genotype.duplicate(org.Genotype.Id)
+	if err != nil {
+		return nil, err
+	}
+	orgCopy, err := NewOrganism(org.Fitness, genome, org.Generation)
+	if err != nil {
+		return nil, err
+	}
+	orgCopy.Error = org.Error
+	orgCopy.IsWinner = org.IsWinner
+	orgCopy.Behavior = org.Behavior
+	orgCopy.Novelty = org.Novelty
+	orgCopy.Objectives = org.Objectives
+	return orgCopy, nil
+}
+
 // Phenotype is to get phenotype of this organism. If phenotype was not built yet, then it would be created first
 // as a result of this method invocation
 func (o *Organism) Phenotype() (*network.Network, error) {
@@ -158,6 +197,9 @@ func (o *Organism) Dump() string {
 	_, _ = fmt.Fprintln(b, "Fitness: ", o.Fitness)
 	_, _ = fmt.Fprintln(b, "Error: ", o.Error)
 	_, _ = fmt.Fprintln(b, "IsWinner: ", o.IsWinner)
+	_, _ = fmt.Fprintln(b, "Behavior: ", o.Behavior)
+	_, _ = fmt.Fprintln(b, "Novelty: ", o.Novelty)
+	_, _ = fmt.Fprintln(b, "Objectives: ", o.Objectives)
 	_, _ = fmt.Fprintln(b, "Phenotype: ", o.orgPhenotype)
 	_, _ = fmt.Fprintln(b, "Genotype: ", o.Genotype)
 	_, _ = fmt.Fprintln(b, "Species: ", o.Species)
@@ -171,6 +213,8 @@ func (o *Organism) Dump() string {
 	_, _ = fmt.Fprintln(b, "isPopulationChampion: ", o.isPopulationChampion)
 	_, _ = fmt.Fprintln(b, "isPopulationChampionChild: ", o.isPopulationChampionChild)
 	_, _ = fmt.Fprintln(b, "highestFitness: ", o.highestFitness)
+	_, _ = fmt.Fprintln(b, "paretoRank: ", o.paretoRank)
+	_, _ = fmt.Fprintln(b, "crowdingDistance: ", o.crowdingDistance)
 	_, _ = fmt.Fprintln(b, "mutationStructBaby: ", o.mutationStructBaby)
 	_, _ = fmt.Fprintln(b, "mateBaby: ", o.mateBaby)
 	_, _ = fmt.Fprintln(b, "Flag: ", o.Flag)
@@ -196,3 +240,16 @@ func (f Organisms) Less(i, j int) bool {
 	}
 	return false
 }
+
+// selectionFitness Returns the fitness driving the fitness sharing and the selection of parents of this organism.
+// It's the Pareto fitness if multi-objective selection enabled, the objective fitness blended with novelty if novelty
+// search enabled, or the objective fitness otherwise.
+func (o *Organism) selectionFitness(opts *neat.Options) float64 {
+	if opts.MultiObjectiveSelection {
+		return o.ParetoFitness
+	}
+	if opts.NoveltySearch != nil {
+		return noveltyFitness(o, opts.NoveltySearch)
+	}
+	return o.Fitness
+}
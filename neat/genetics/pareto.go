@@ -0,0 +1,151 @@
+package genetics
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// dominates Returns true if objectives a Pareto dominates objectives b, i.e. a is not worse than b in all objectives
+// and strictly better in at least one. All objectives are maximized.
+func dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		} else if a[i] > b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// nonDominatedSort is to sort organisms into Pareto fronts by their objectives (NSGA-II fast non-dominated sorting).
+// The Pareto rank of each organism is set to the index of its front. Returns the list of fronts starting from
+// the non-dominated one.
+func nonDominatedSort(organisms []*Organism) [][]*Organism {
+	dominatedBy := make([][]int, len(organisms))
+	dominationCount := make([]int, len(organisms))
+	fronts := make([][]*Organism, 0)
+	current := make([]int, 0)
+	for i, p := range organisms {
+		for j, q := range organisms {
+			if i == j {
+				continue
+			}
+			if dominates(p.Objectives, q.Objectives) {
+				dominatedBy[i] = append(dominatedBy[i], j)
+			} else if dominates(q.Objectives, p.Objectives) {
+				dominationCount[i]++
+			}
+		}
+		if dominationCount[i] == 0 {
+			current = append(current, i)
+		}
+	}
+
+	for rank := 0; len(current) > 0; rank++ {
+		front := make([]*Organism, len(current))
+		next := make([]int, 0)
+		for k, i := range current {
+			organisms[i].paretoRank = rank
+			front[k] = organisms[i]
+			for _, j := range dominatedBy[i] {
+				dominationCount[j]--
+				if dominationCount[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		fronts = append(fronts, front)
+		current = next
+	}
+	return fronts
+}
+
+// assignCrowdingDistance is to assign the crowding distance to each organism of the Pareto front. The boundary
+// organisms of each objective get infinite distance to be always preferred.
+func assignCrowdingDistance(front []*Organism) {
+	for _, o := range front {
+		o.crowdingDistance = 0
+	}
+	if len(front) == 0 {
+		return
+	}
+	sorted := make([]*Organism, len(front))
+	copy(sorted, front)
+	for m := range front[0].Objectives {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Objectives[m] < sorted[j].Objectives[m]
+		})
+		last := len(sorted) - 1
+		sorted[0].crowdingDistance = math.Inf(1)
+		sorted[last].crowdingDistance = math.Inf(1)
+		objRange := sorted[last].Objectives[m] - sorted[0].Objectives[m]
+		if objRange == 0 {
+			continue
+		}
+		for i := 1; i < last; i++ {
+			sorted[i].crowdingDistance += (sorted[i+1].Objectives[m] - sorted[i-1].Objectives[m]) / objRange
+		}
+	}
+}
+
+// crowdedBetter Returns true if organism a is better than organism b by the NSGA-II crowded-comparison operator, i.e.
+// it belongs to the better Pareto front, or to the same front but with larger crowding distance.
+func crowdedBetter(a, b *Organism) bool {
+	if a.paretoRank != b.paretoRank {
+		return a.paretoRank < b.paretoRank
+	}
+	return a.crowdingDistance > b.crowdingDistance
+}
+
+// paretoFitness Returns the scalar fitness reflecting the crowded-comparison order of the organism given the total
+// number of Pareto fronts. The organisms from better fronts always get higher fitness, and within the front the ones
+// with larger crowding distance get higher fitness.
+func paretoFitness(org *Organism, frontsCount int) float64 {
+	crowding := 0.5
+	if !math.IsInf(org.crowdingDistance, 1) {
+		crowding = 0.5 * org.crowdingDistance / (1.0 + org.crowdingDistance)
+	}
+	return float64(frontsCount-org.paretoRank) + crowding
+}
+
+// rankObjectives is to rank organisms by Pareto front and crowding distance of their objectives and to set their
+// Pareto fitness to the scalar reflecting this ranking. The Pareto fitness drives the fitness sharing within species
+// and distribution of offspring among species, while the objective fitness is kept to track the progress of evolution.
+func rankObjectives(organisms []*Organism) error {
+	if len(organisms) == 0 {
+		return nil
+	}
+	objectivesCount := len(organisms[0].Objectives)
+	for _, o := range organisms {
+		if len(o.Objectives) == 0 {
+			return fmt.Errorf("organism with genome ID: %d has no objectives", o.Genotype.Id)
+		}
+		if len(o.Objectives) != objectivesCount {
+			return fmt.Errorf("objectives size mismatch: %d != %d, organism with genome ID: %d",
+				len(o.Objectives), objectivesCount, o.Genotype.Id)
+		}
+	}
+
+	fronts := nonDominatedSort(organisms)
+	for _, front := range fronts {
+		assignCrowdingDistance(front)
+	}
+	for _, o := range organisms {
+		o.ParetoFitness = paretoFitness(o, len(fronts))
+	}
+	return nil
+}
+
+// crowdedTournament Selects the organism from the pool by the binary tournament using the crowded-comparison operator
+func crowdedTournament(pool []*Organism, rng *rand.Rand) *Organism {
+	first := pool[rng.Intn(len(pool))]
+	second := pool[rng.Intn(len(pool))]
+	if crowdedBetter(second, first) {
+		return second
+	}
+	return first
+}
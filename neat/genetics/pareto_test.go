@@ -0,0 +1,144 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	gomath "math"
+	"math/rand"
+	"testing"
+)
+
+func buildTestObjectivesOrganisms(objectives [][]float64) []*Organism {
+	organisms := make([]*Organism, len(objectives))
+	for i, obj := range objectives {
+		organisms[i] = &Organism{Genotype: buildTestGenome(i + 1), Objectives: obj}
+	}
+	return organisms
+}
+
+func TestDominates(t *testing.T) {
+	assert.True(t, dominates([]float64{2, 2}, []float64{1, 2}))
+	assert.False(t, dominates([]float64{1, 2}, []float64{2, 2}))
+	assert.False(t, dominates([]float64{2, 1}, []float64{1, 2}))
+	assert.False(t, dominates([]float64{1, 2}, []float64{1, 2}))
+}
+
+func TestNonDominatedSort(t *testing.T) {
+	organisms := buildTestObjectivesOrganisms([][]float64{
+		{1, 5}, {2, 2}, {5, 1}, {1, 1}, {3, 3}, {0, 0},
+	})
+	fronts := nonDominatedSort(organisms)
+	require.Len(t, fronts, 4)
+	assert.ElementsMatch(t, []*Organism{organisms[0], organisms[2], organisms[4]}, fronts[0])
+	assert.ElementsMatch(t, []*Organism{organisms[1]}, fronts[1])
+	assert.ElementsMatch(t, []*Organism{organisms[3]}, fronts[2])
+	assert.ElementsMatch(t, []*Organism{organisms[5]}, fronts[3])
+
+	expected := []int{0, 1, 0, 2, 0, 3}
+	for i, o := range organisms {
+		assert.Equal(t, expected[i], o.paretoRank, "wrong rank at: %d", i)
+	}
+}
+
+func TestAssignCrowdingDistance(t *testing.T) {
+	front := buildTestObjectivesOrganisms([][]float64{{0, 4}, {1, 3}, {3, 1}, {4, 0}})
+	assignCrowdingDistance(front)
+	assert.True(t, gomath.IsInf(front[0].crowdingDistance, 1))
+	assert.True(t, gomath.IsInf(front[3].crowdingDistance, 1))
+	assert.Equal(t, 1.5, front[1].crowdingDistance)
+	assert.Equal(t, 1.5, front[2].crowdingDistance)
+}
+
+func TestCrowdedBetter(t *testing.T) {
+	a := &Organism{paretoRank: 0, crowdingDistance: 0.1}
+	b := &Organism{paretoRank: 1, crowdingDistance: 10}
+	assert.True(t, crowdedBetter(a, b))
+	assert.False(t, crowdedBetter(b, a))
+
+	b.paretoRank = 0
+	assert.True(t, crowdedBetter(b, a))
+}
+
+func TestRankObjectives(t *testing.T) {
+	organisms := buildTestObjectivesOrganisms([][]float64{
+		{1, 5}, {2, 2}, {5, 1}, {1, 1}, {3, 3}, {0, 0},
+	})
+	err := rankObjectives(organisms)
+	require.NoError(t, err)
+
+	// the Pareto fitness must follow the crowded-comparison order
+	for _, a := range organisms {
+		for _, b := range organisms {
+			if crowdedBetter(a, b) {
+				assert.Greater(t, a.ParetoFitness, b.ParetoFitness)
+			}
+		}
+	}
+	assert.Equal(t, 4.5, organisms[0].ParetoFitness)
+	assert.Equal(t, 1.5, organisms[5].ParetoFitness)
+	// the objective fitness is kept
+	for _, o := range organisms {
+		assert.Zero(t, o.Fitness)
+	}
+}
+
+func TestRankObjectives_error(t *testing.T) {
+	organisms := buildTestObjectivesOrganisms([][]float64{{1, 5}, nil})
+	assert.Error(t, rankObjectives(organisms))
+
+	organisms = buildTestObjectivesOrganisms([][]float64{{1, 5}, {1}})
+	assert.Error(t, rankObjectives(organisms))
+}
+
+func TestCrowdedTournament(t *testing.T) {
+	rand.Seed(42)
+	best := &Organism{paretoRank: 0}
+	worst := &Organism{paretoRank: 1}
+	pool := Organisms{best, worst}
+
+	selected := map[*Organism]int{}
+	for i := 0; i < 1000; i++ {
+		selected[crowdedTournament(pool, neat.GlobalRand())]++
+	}
+	// the worst one is selected only if drawn twice in the tournament
+	assert.Greater(t, selected[best], selected[worst]*2)
+}
+
+func TestPopulationEpochExecutor_NextEpoch_multiObjective(t *testing.T) {
+	rand.Seed(42)
+	in, out, maxHidden, n := 3, 2, 15, 3
+	linkProb := 0.8
+	conf := &neat.Options{
+		CompatThreshold:         0.5,
+		DropOffAge:              1,
+		PopSize:                 30,
+		BabiesStolen:            10,
+		RecurOnlyProb:           0.2,
+		NodeActivators:          []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb:      []float64{1.0},
+		MultiObjectiveSelection: true,
+	}
+	neat.LogLevel = neat.LogLevelInfo
+	gen, err := newGenomeRand(1, in, out, n, maxHidden, false, linkProb, conf, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+
+	pop, err := NewPopulation(gen, conf)
+	require.NoError(t, err, "failed to create population")
+
+	executors := []PopulationEpochExecutor{&SequentialPopulationEpochExecutor{}, &ParallelPopulationEpochExecutor{}}
+	for _, ex := range executors {
+		for i := 0; i < 10; i++ {
+			for _, org := range pop.Organisms {
+				org.Objectives = []float64{rand.Float64(), -float64(len(org.Genotype.Genes))}
+			}
+			err = ex.NextEpoch(conf.NeatContext(), i+1, pop)
+			require.NoError(t, err, "failed at: %d epoch", i)
+		}
+	}
+
+	// organisms without objectives
+	err = (&SequentialPopulationEpochExecutor{}).NextEpoch(conf.NeatContext(), 21, pop)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package genetics
+
+import (
+	"fmt"
+	"github.com/yaricom/goNEAT/v4/neat"
+)
+
+// SearchPhase defines the phase of the phased search
+type SearchPhase byte
+
+const (
+	// ComplexifyingPhase the phase when genomes are complexified by regular NEAT mutations and mating
+	ComplexifyingPhase SearchPhase = iota
+	// SimplifyingPhase the phase when genomes are pruned by deletion mutations and mutated by non-structural ones only
+	SimplifyingPhase
+)
+
+func (s SearchPhase) String() string {
+	switch s {
+	case ComplexifyingPhase:
+		return "complexifying"
+	case SimplifyingPhase:
+		return "simplifying"
+	default:
+		return fmt.Sprintf("unknown search phase: %d", s)
+	}
+}
+
+// meanComplexity is to calculate the mean complexity of genomes in the population
+func (p *Population) meanComplexity() float64 {
+	if len(p.Organisms) == 0 {
+		return 0
+	}
+	total := 0
+	for _, org := range p.Organisms {
+		total += org.Genotype.Complexity()
+	}
+	return float64(total) / float64(len(p.Organisms))
+}
+
+// updateSearchPhase is to switch the population between complexifying and simplifying phases of the phased search.
+// The simplifying phase starts when the mean genome complexity exceeds the complexity ceiling and the population
+// fitness stagnated. The complexifying phase resumes when after minimal number of generations the mean genome
+// complexity stops falling, and the new complexity ceiling is set above the reached complexity level.
+func (p *Population) updateSearchPhase(generation int, opts *neat.PhasedSearchOptions) {
+	prevComplexity := p.MeanComplexity
+	p.MeanComplexity = p.meanComplexity()
+	if p.ComplexityCeiling == 0 {
+		// the first epoch - set the ceiling above the initial complexity
+		p.ComplexityCeiling = p.MeanComplexity + opts.ComplexityThreshold
+		p.PhaseStartGeneration = generation
+	}
+
+	switch p.SearchPhase {
+	case ComplexifyingPhase:
+		if p.MeanComplexity > p.ComplexityCeiling && p.EpochsHighestLastChanged >= opts.StagnationThreshold {
+			p.SearchPhase = SimplifyingPhase
+			p.PhaseStartGeneration = generation
+		}
+	case SimplifyingPhase:
+		if generation-p.PhaseStartGeneration >= opts.MinSimplifyingGenerations && p.MeanComplexity >= prevComplexity {
+			p.SearchPhase = ComplexifyingPhase
+			p.PhaseStartGeneration = generation
+			p.ComplexityCeiling = p.MeanComplexity + opts.ComplexityThreshold
+		}
+	}
+	if p.PhaseStartGeneration == generation {
+		neat.InfoLog(fmt.Sprintf("POPULATION: %s phase started at generation: %d, mean complexity: %f, ceiling: %f",
+			p.SearchPhase, generation, p.MeanComplexity, p.ComplexityCeiling))
+	}
+}
@@ -0,0 +1,154 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"math/rand"
+	"testing"
+)
+
+func TestSearchPhase_String(t *testing.T) {
+	assert.Equal(t, "complexifying", ComplexifyingPhase.String())
+	assert.Equal(t, "simplifying", SimplifyingPhase.String())
+	assert.Equal(t, "unknown search phase: 5", SearchPhase(5).String())
+}
+
+func TestPopulation_updateSearchPhase(t *testing.T) {
+	opts := &neat.PhasedSearchOptions{
+		ComplexityThreshold:       2,
+		StagnationThreshold:       3,
+		MinSimplifyingGenerations: 1,
+		DeleteLinkProb:            0.5,
+	}
+	pop := newPopulation()
+	for i := 0; i < 3; i++ {
+		org, err := NewOrganism(0, buildTestGenome(i+1), 1)
+		require.NoError(t, err)
+		pop.Organisms = append(pop.Organisms, org)
+	}
+	// changes complexity of all genomes in population
+	addNodes := func(count int) {
+		for _, org := range pop.Organisms {
+			for i := 0; i < count; i++ {
+				org.Genotype.Nodes = append(org.Genotype.Nodes, network.NewNNode(100+i, network.HiddenNeuron))
+			}
+		}
+	}
+	removeNode := func() {
+		for _, org := range pop.Organisms {
+			org.Genotype.Nodes = org.Genotype.Nodes[:len(org.Genotype.Nodes)-1]
+		}
+	}
+
+	// initial complexity is 7 (4 nodes + 3 genes)
+	pop.updateSearchPhase(1, opts)
+	assert.Equal(t, ComplexifyingPhase, pop.SearchPhase)
+	assert.Equal(t, 7.0, pop.MeanComplexity)
+	assert.Equal(t, 9.0, pop.ComplexityCeiling)
+
+	// complexity ceiling exceeded, but population fitness is still improving
+	addNodes(3)
+	pop.updateSearchPhase(2, opts)
+	assert.Equal(t, ComplexifyingPhase, pop.SearchPhase)
+
+	// population fitness stagnated
+	pop.EpochsHighestLastChanged = 3
+	pop.updateSearchPhase(3, opts)
+	assert.Equal(t, SimplifyingPhase, pop.SearchPhase)
+	assert.Equal(t, 3, pop.PhaseStartGeneration)
+
+	// complexity is falling
+	removeNode()
+	pop.updateSearchPhase(4, opts)
+	assert.Equal(t, SimplifyingPhase, pop.SearchPhase)
+	assert.Equal(t, 9.0, pop.MeanComplexity)
+
+	// complexity stopped falling - the next complexifying phase
+	pop.updateSearchPhase(5, opts)
+	assert.Equal(t, ComplexifyingPhase, pop.SearchPhase)
+	assert.Equal(t, 5, pop.PhaseStartGeneration)
+	assert.Equal(t, 11.0, pop.ComplexityCeiling)
+}
+
+func TestPopulation_mutationRegistry_simplifying(t *testing.T) {
+	noop := func(_ *Genome, _ *MutationEnvironment) (bool, error) {
+		return false, nil
+	}
+	pop := newPopulation()
+	pop.Mutations = NewMutationRegistry()
+	require.NoError(t, pop.Mutations.Register(MutationAddNode, StructuralMutation, FixedProbability(1), noop))
+	require.NoError(t, pop.Mutations.Register(MutationDeleteLink, StructuralMutation, FixedProbability(0), noop))
+	require.NoError(t, pop.Mutations.Register("custom", NonStructuralMutation, FixedProbability(1), noop))
+	assert.Equal(t, pop.Mutations, pop.mutationRegistry())
+
+	// the custom registry is filtered to its deletion and non-structural mutations
+	pop.SearchPhase = SimplifyingPhase
+	mutations := pop.mutationRegistry().Mutations()
+	require.Len(t, mutations, 2)
+	assert.Equal(t, MutationDeleteLink, mutations[0].Name)
+	assert.Equal(t, 0.7, mutations[0].Probability(&neat.Options{PhasedSearch: &neat.PhasedSearchOptions{DeleteLinkProb: 0.7}}))
+	assert.Equal(t, "custom", mutations[1].Name)
+
+	// the default registry is used if not set
+	pop.Mutations = nil
+	mutations = pop.mutationRegistry().Mutations()
+	require.True(t, len(mutations) > 2)
+	assert.Equal(t, MutationDeleteNode, mutations[0].Name)
+	assert.Equal(t, MutationDeleteLink, mutations[1].Name)
+	for _, m := range mutations[2:] {
+		assert.Equal(t, NonStructuralMutation, m.Kind, "unexpected structural mutation: %s", m.Name)
+	}
+}
+
+func TestMutationRegistry_Simplifying_noDeletion(t *testing.T) {
+	mutated := false
+	r := NewMutationRegistry()
+	require.NoError(t, r.Register(MutationDeleteNode, StructuralMutation, FixedProbability(1), func(_ *Genome, _ *MutationEnvironment) (bool, error) {
+		return false, nil
+	}))
+	require.NoError(t, r.Register(MutationLinkWeights, NonStructuralMutation, FixedProbability(1), func(_ *Genome, _ *MutationEnvironment) (bool, error) {
+		mutated = true
+		return true, nil
+	}))
+
+	// if no deletion applies the non-structural mutations are applied
+	opts := &neat.Options{PhasedSearch: &neat.PhasedSearchOptions{DeleteNodeProb: 1}}
+	structural, err := r.Simplifying().Mutate(buildTestGenome(1), &MutationEnvironment{Options: opts})
+	require.NoError(t, err)
+	assert.False(t, structural)
+	assert.True(t, mutated)
+}
+
+func TestPopulationEpochExecutor_NextEpoch_phasedSearch(t *testing.T) {
+	rand.Seed(42)
+	opts := speciationTestOptions()
+	opts.PhasedSearch = &neat.PhasedSearchOptions{
+		ComplexityThreshold:       10,
+		MinSimplifyingGenerations: 5,
+		DeleteLinkProb:            1.0,
+		DeleteNodeProb:            0.5,
+	}
+	gen, err := newGenomeRand(1, 3, 2, 5, 15, false, 0.8, opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	pop, err := NewPopulation(gen, opts)
+	require.NoError(t, err, "failed to create population")
+
+	pop.SearchPhase = SimplifyingPhase
+	maxComplexity := 0
+	for _, org := range pop.Organisms {
+		if c := org.Genotype.Complexity(); c > maxComplexity {
+			maxComplexity = c
+		}
+	}
+	meanComplexity := pop.meanComplexity()
+
+	err = (&SequentialPopulationEpochExecutor{}).NextEpoch(opts.NeatContext(), 1, pop)
+	require.NoError(t, err)
+	assert.Equal(t, SimplifyingPhase, pop.SearchPhase)
+	for _, org := range pop.Organisms {
+		assert.True(t, org.Genotype.Complexity() <= maxComplexity, "genome grown during simplifying phase")
+	}
+	assert.True(t, pop.meanComplexity() < meanComplexity, "mean complexity expected to decrease")
+}
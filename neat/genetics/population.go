@@ -5,9 +5,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/yaricom/goNEAT/v4/neat"
-	"math"
 	"math/rand"
-	"sync"
 	"sync/atomic"
 )
 
@@ -35,23 +33,43 @@ type Population struct {
 	Variance    float64
 	StandardDev float64
 
-	// For holding the genetic innovations of the newest generation
-	innovations []Innovation
+	// The archive of novel behaviors, if novelty search is enabled
+	NoveltyArchive *NoveltyArchive
+
+	// The strategy to assign organisms to species. If not set, the strategy defined by speciation method
+	// of the NEAT options is used.
+	SpeciationStrategy SpeciationStrategy
+	// The strategy to select parents for reproduction within species. If not set, the strategy defined by parent
+	// selection method of the NEAT options is used.
+	ParentSelection ParentSelectionStrategy
+	// The registry of mutations applied to the offspring during reproduction. If not set, the default registry with
+	// built-in mutations is used.
+	Mutations *MutationRegistry
+
+	// The current phase of the phased search, if enabled
+	SearchPhase SearchPhase
+	// The generation when the current phase of the phased search started
+	PhaseStartGeneration int
+	// The mean complexity of genomes in the population at the last epoch
+	MeanComplexity float64
+	// The mean complexity of genomes above which the population switches to the simplifying phase
+	ComplexityCeiling float64
+
+	// The compatibility threshold to assign organisms to species. It's seeded from the NEAT options when population
+	// is created and adjusted every epoch if the target number of species is set, leaving the options unchanged.
+	CompatThreshold float64
+
+	// For holding the genetic innovations of the newest generation or of the whole run if innovations are kept
+	// across generations
+	innovations *InnovationStore
 	// The next innovation number for population
 	nextInnovNum int64
 	// The next ID for new node in population
 	nextNodeId int32
-
-	// The mutex to guard against concurrent modifications
-	mutex *sync.Mutex
-}
-
-// The auxiliary data type to hold results of parallel reproduction sent over the wires
-type reproductionResult struct {
-	babiesStored int    // the number of offsprings saved
-	babies       []byte // the encoded offsprings
-	err          error  // error occurred during reproduction if any
-	speciesId    int    // the ID of species used for reproduction
+	// The population which innovations database and counters are shared with this one, if any
+	sharedInnovations *Population
+	// The flag to indicate that innovations database of this population is shared with other populations
+	innovationsShared bool
 }
 
 // NewPopulation constructs off of a single spawning Genome
@@ -60,8 +78,23 @@ func NewPopulation(g *Genome, opts *neat.Options) (*Population, error) {
 		return nil, fmt.Errorf("wrong population size in the context: %d", opts.PopSize)
 	}
 
+	return NewPopulationContext(opts.NeatContext(), g)
+}
+
+// NewPopulationContext constructs off of a single spawning Genome using the NEAT options and the random numbers
+// generator from provided context
+func NewPopulationContext(ctx context.Context, g *Genome) (*Population, error) {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return nil, neat.ErrNEATOptionsNotFound
+	}
+	if opts.PopSize <= 0 {
+		return nil, fmt.Errorf("wrong population size in the context: %d", opts.PopSize)
+	}
+
 	pop := newPopulation()
-	err := pop.spawn(g, opts)
+	pop.CompatThreshold = opts.CompatThreshold
+	err := pop.spawn(ctx, g)
 	if err != nil {
 		return nil, err
 	}
@@ -72,13 +105,25 @@ func NewPopulation(g *Genome, opts *neat.Options) (*Population, error) {
 // NewGenomeRand(new_id, in, out, n, maxHidden int, recurrent bool, link_prob float64)
 // See the Genome constructor above for the argument specifications
 func NewPopulationRandom(in, out, maxHidden int, recurrent bool, linkProb float64, opts *neat.Options) (*Population, error) {
+	return NewPopulationRandomContext(opts.NeatContext(), in, out, maxHidden, recurrent, linkProb)
+}
+
+// NewPopulationRandomContext creates a population of random topologies the same way as NewPopulationRandom using
+// the NEAT options and the random numbers generator from provided context
+func NewPopulationRandomContext(ctx context.Context, in, out, maxHidden int, recurrent bool, linkProb float64) (*Population, error) {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return nil, neat.ErrNEATOptionsNotFound
+	}
 	if opts.PopSize <= 0 {
 		return nil, fmt.Errorf("wrong population size in the context: %d", opts.PopSize)
 	}
 
 	pop := newPopulation()
+	pop.CompatThreshold = opts.CompatThreshold
+	rng := neat.RandFromContext(ctx)
 	for count := 0; count < opts.PopSize; count++ {
-		gen, err := newGenomeRand(count, in, out, rand.Intn(maxHidden), maxHidden, recurrent, linkProb, opts)
+		gen, err := newGenomeRand(count, in, out, rng.Intn(maxHidden), maxHidden, recurrent, linkProb, opts, rng)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create random population")
 		}
@@ -91,7 +136,7 @@ func NewPopulationRandom(in, out, maxHidden int, recurrent bool, linkProb float6
 	pop.nextNodeId = int32(in + out + maxHidden + 1)
 	pop.nextInnovNum = int64((in+out+maxHidden)*(in+out+maxHidden) + 1)
 
-	err := pop.speciate(opts.NeatContext(), pop.Organisms)
+	err := pop.speciate(ctx, pop.Organisms)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +157,77 @@ func (p *Population) Verify() (bool, error) {
 	return res, nil
 }
 
+// RandomOrganisms Returns given number of organisms randomly selected from this population without replacement using
+// provided random numbers generator. If population has fewer organisms, all of them are returned in random order.
+func (p *Population) RandomOrganisms(count int, rng *rand.Rand) []*Organism {
+	return sampleOrganisms(p.Organisms, count, rng)
+}
+
+// compatThreshold Returns the compatibility threshold of this population or the one of the options if population
+// threshold is not set
+func (p *Population) compatThreshold(opts *neat.Options) float64 {
+	if p.CompatThreshold > 0 {
+		return p.CompatThreshold
+	}
+	return opts.CompatThreshold
+}
+
+// adjustCompatThreshold is to adjust the compatibility threshold of the population to steer the number of species
+// towards the target. The threshold is lowered if there are too few species and raised if there are too many.
+func (p *Population) adjustCompatThreshold(opts *neat.Options) {
+	if opts.SpeciesCountTarget <= 0 {
+		return
+	}
+	threshold := p.compatThreshold(opts)
+	speciesCount := len(p.Species)
+	if speciesCount < opts.SpeciesCountTarget {
+		threshold -= opts.CompatThresholdModifier
+	} else if speciesCount > opts.SpeciesCountTarget {
+		threshold += opts.CompatThresholdModifier
+	}
+	if threshold < opts.CompatThresholdMin {
+		threshold = opts.CompatThresholdMin
+	}
+	p.CompatThreshold = threshold
+
+	if neat.LogLevel == neat.LogLevelDebug {
+		neat.DebugLog(fmt.Sprintf("POPULATION: # of species: %d, target: %d, compatibility threshold adjusted to: %f\n",
+			speciesCount, opts.SpeciesCountTarget, p.CompatThreshold))
+	}
+}
+
+// parentSelectionStrategy Returns the strategy to select parents for reproduction
+func (p *Population) parentSelectionStrategy(opts *neat.Options) (ParentSelectionStrategy, error) {
+	if p.ParentSelection != nil {
+		return p.ParentSelection, nil
+	}
+	return NewParentSelectionStrategy(opts)
+}
+
+// mutationRegistry Returns the registry of mutations applied to the offspring with respect to the search phase
+func (p *Population) mutationRegistry() *MutationRegistry {
+	registry := p.Mutations
+	if registry == nil {
+		registry = NewDefaultMutationRegistry()
+	}
+	if p.SearchPhase == SimplifyingPhase {
+		return registry.Simplifying()
+	}
+	return registry
+}
+
+// evaluateNovelty is to estimate novelty of organisms' behaviors, which is blended with their objective fitness to drive
+// the selection
+func (p *Population) evaluateNovelty(generation int, opts *neat.NoveltySearchOptions) error {
+	if p.NoveltyArchive == nil {
+		p.NoveltyArchive = NewNoveltyArchive(opts)
+	}
+	if err := p.NoveltyArchive.EvaluatePopulation(p.Organisms, generation); err != nil {
+		return errors.Wrap(err, "failed to evaluate novelty of organisms")
+	}
+	return nil
+}
+
 // Default private constructor
 func newPopulation() *Population {
 	return &Population{
@@ -120,31 +236,48 @@ func newPopulation() *Population {
 		EpochsHighestLastChanged: 0,
 		Species:                  make([]*Species, 0),
 		Organisms:                make([]*Organism, 0),
-		mutex:                    &sync.Mutex{},
+		innovations:              NewInnovationStore(),
 	}
 }
 
 func (p *Population) NextNodeId() int {
+	if p.sharedInnovations != nil {
+		return p.sharedInnovations.NextNodeId()
+	}
 	return int(atomic.AddInt32(&p.nextNodeId, 1))
 }
 
 func (p *Population) NextInnovationNumber() int64 {
+	if p.sharedInnovations != nil {
+		return p.sharedInnovations.NextInnovationNumber()
+	}
 	return atomic.AddInt64(&p.nextInnovNum, 1)
 }
 
 func (p *Population) StoreInnovation(innovation Innovation) {
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	p.innovations = append(p.innovations, innovation)
+	p.innovations.Store(innovation)
 }
 
 func (p *Population) Innovations() []Innovation {
-	return p.innovations
+	return p.innovations.Innovations()
+}
+
+func (p *Population) FindLinkInnovation(inNodeId, outNodeId int, recurrent bool) (Innovation, bool) {
+	return p.innovations.FindLinkInnovation(inNodeId, outNodeId, recurrent)
+}
+
+func (p *Population) FindNodeInnovation(inNodeId, outNodeId int, oldInnovNum int64) (Innovation, bool) {
+	return p.innovations.FindNodeInnovation(inNodeId, outNodeId, oldInnovNum)
 }
 
 // spawn creates a population from Genome g. The new Population will have the same topology as g
 // with link weights slightly perturbed from g's
-func (p *Population) spawn(g *Genome, opts *neat.Options) (err error) {
+func (p *Population) spawn(ctx context.Context, g *Genome) (err error) {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	rng := neat.RandFromContext(ctx)
 	for count := 0; count < opts.PopSize; count++ {
 		// make genome duplicate for new organism
 		newGenome, err := g.duplicate(count)
@@ -152,7 +285,7 @@ func (p *Population) spawn(g *Genome, opts *neat.Options) (err error) {
 			return err
 		}
 		// introduce initial mutations
-		if _, err = newGenome.mutateLinkWeights(1.0, 1.0, gaussianMutator); err != nil {
+		if _, err = newGenome.mutateLinkWeights(1.0, 1.0, gaussianMutator, rng); err != nil {
 			return err
 		}
 		// create organism for new genome
@@ -176,7 +309,7 @@ func (p *Population) spawn(g *Genome, opts *neat.Options) (err error) {
 	}
 
 	// Separate the new Population into species
-	err = p.speciate(opts.NeatContext(), p.Organisms)
+	err = p.speciate(ctx, p.Organisms)
 
 	return err
 }
@@ -208,8 +341,8 @@ func (p *Population) checkBestSpeciesAlive(bestSpeciesId int, bestSpeciesReprodu
 	return nil
 }
 
-// speciate separates given organisms into species of this population by checking compatibilities against a threshold.
-// Any organism that is not compatible with the first organism in any existing species becomes a new species.
+// speciate separates given organisms into species of this population using the speciation strategy of population
+// or the one defined by the speciation method of NEAT options.
 func (p *Population) speciate(ctx context.Context, organisms []*Organism) error {
 	if len(organisms) == 0 {
 		return errors.New("no organisms to speciate from")
@@ -220,55 +353,14 @@ func (p *Population) speciate(ctx context.Context, organisms []*Organism) error
 		return neat.ErrNEATOptionsNotFound
 	}
 
-	// Step through all given organisms and speciate them within the population
-	for _, currOrg := range organisms {
-		// check if context was canceled
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		if len(p.Species) == 0 {
-			// Create the first species
-			createFirstSpecies(p, currOrg)
-		} else {
-			if opts.CompatThreshold == 0 {
-				return errors.New("compatibility threshold is set to ZERO - will not find any compatible species")
-			}
-			// For each organism, search for a species it is compatible to
-			done := false
-			var bestCompatible *Species // the best compatible species
-			bestCompatValue := math.MaxFloat64
-			for _, currSpecies := range p.Species {
-				compOrg := currSpecies.firstOrganism()
-				// compare current organism with first organism in current specie
-				if compOrg != nil {
-					currCompat := currOrg.Genotype.compatibility(compOrg.Genotype, opts)
-					if currCompat < opts.CompatThreshold && currCompat < bestCompatValue {
-						bestCompatible = currSpecies
-						bestCompatValue = currCompat
-						done = true
-					}
-				}
-			}
-			if bestCompatible != nil && done {
-				if neat.LogLevel == neat.LogLevelDebug {
-					neat.DebugLog(fmt.Sprintf("POPULATION: Compatible species [%d] found for baby organism [%d]",
-						bestCompatible.Id, currOrg.Genotype.Id))
-				}
-				// Found compatible species, so add current organism to it
-				bestCompatible.addOrganism(currOrg)
-				// Point organism to its species
-				currOrg.Species = bestCompatible
-			} else {
-				// If we didn't find a match, create a new species
-				createFirstSpecies(p, currOrg)
-			}
+	strategy := p.SpeciationStrategy
+	if strategy == nil {
+		var err error
+		if strategy, err = NewSpeciationStrategy(opts.SpeciationMethod); err != nil {
+			return err
 		}
 	}
-
-	return nil
+	return strategy.Speciate(ctx, p, organisms)
 }
 
 // Removes zero offspring species from this population, i.e. species which will not have any offspring organism belonging to it
@@ -398,7 +490,7 @@ func (p *Population) deltaCoding(sortedSpecies []*Species, opts *neat.Options) {
 
 // The system can take expected offspring away from worse species and give them
 // to superior species depending on the system parameter BabiesStolen (when BabiesStolen > 0)
-func (p *Population) giveBabiesToTheBest(sortedSpecies []*Species, opts *neat.Options) {
+func (p *Population) giveBabiesToTheBest(sortedSpecies []*Species, opts *neat.Options, rng *rand.Rand) {
 	stolenBabies := 0 // Babies taken from the bad species and given to the champs
 
 	// Take away a constant number of expected offspring from the worst few species
@@ -440,7 +532,7 @@ func (p *Population) giveBabiesToTheBest(sortedSpecies []*Species, opts *neat.Op
 			stolenBabies -= stolenBlocks[blockIndex]
 		} else if blockIndex >= 3 {
 			// Give stolen to the rest in random ratios
-			if rand.Float64() > 0.1 {
+			if rng.Float64() > 0.1 {
 				// Randomize a little which species get boosted by a super champ
 				if stolenBabies > 3 {
 					currSpecies.Organisms[0].superChampOffspring = 3
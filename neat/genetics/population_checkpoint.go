@@ -0,0 +1,318 @@
+package genetics
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"io"
+)
+
+// The version of the population checkpoint format
+const checkpointVersion = 2
+
+// populationCheckpoint The complete evolutionary state of population stored in the checkpoint
+type populationCheckpoint struct {
+	Version int
+	// The generation when checkpoint was made
+	Generation int
+	// The state of the source of random numbers generator, if any
+	RandState []byte
+
+	Organisms   []organismCheckpoint
+	Species     []speciesCheckpoint
+	Innovations []innovationCheckpoint
+
+	LastSpecies              int
+	WinnerGen                int
+	FinalGen                 int
+	HighestFitness           float64
+	EpochsHighestLastChanged int
+	MeanFitness              float64
+	Variance                 float64
+	StandardDev              float64
+
+	SearchPhase          SearchPhase
+	PhaseStartGeneration int
+	MeanComplexity       float64
+	ComplexityCeiling    float64
+
+	NextInnovNum int64
+	NextNodeId   int32
+
+	// The compatibility threshold of the population, which can be adjusted during evolution
+	CompatThreshold float64
+
+	// The novelty archive if novelty search is enabled
+	NoveltyArchive *noveltyArchiveCheckpoint
+}
+
+// organismCheckpoint The state of organism stored in the checkpoint
+type organismCheckpoint struct {
+	// The genome encoded in YAML to preserve all gene attributes with full precision
+	Genome   []byte
+	Fitness  float64
+	Error    float64
+	IsWinner bool
+
+	Behavior   []float64
+	Novelty    float64
+	Objectives []float64
+
+	ExpectedOffspring float64
+	Generation        int
+	TimeAlive         int
+	Flag              int
+
+	HighestFitness            float64
+	IsPopulationChampionChild bool
+}
+
+// speciesCheckpoint The state of species stored in the checkpoint
+type speciesCheckpoint struct {
+	Id                   int
+	Age                  int
+	MaxFitnessEver       float64
+	ExpectedOffspring    int
+	IsNovel              bool
+	AgeOfLastImprovement int
+	// The indexes of species organisms in the list of population organisms in order of species membership
+	Organisms []int
+}
+
+// innovationCheckpoint The innovation stored in the checkpoint
+type innovationCheckpoint struct {
+	Innovation Innovation
+	// Whether it is new node innovation, otherwise it's new link innovation
+	NewNode bool
+}
+
+// noveltyArchiveCheckpoint The state of the novelty archive stored in the checkpoint
+type noveltyArchiveCheckpoint struct {
+	Items                  []*NoveltyItem
+	Threshold              float64
+	GenerationsWithoutAdds int
+}
+
+// WriteCheckpoint Writes the complete evolutionary state of this population into the checkpoint, which can be used
+// to resume evolution with ReadCheckpoint. The checkpoint holds organisms with their genomes and evaluation results,
+// species with their membership and ages, the population fitness statistics, the innovations history, the counters
+// of innovation numbers and node IDs, the adjusted compatibility threshold, and the given generation. If the context
+// carries the random numbers generator created by neat.NewRandSourceContext, the state of its source is stored as well,
+// so that the resumed run produces the same random sequence as this one. The running generator is not affected.
+//
+// The strategies of speciation, parent selection, and mutations registry as well as the distance function of the
+// novelty archive are not stored and should be set again after resume if customized.
+func (p *Population) WriteCheckpoint(ctx context.Context, w io.Writer, generation int) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	owner := p.innovationsOwner()
+	cp := populationCheckpoint{
+		Version:                  checkpointVersion,
+		Generation:               generation,
+		Organisms:                make([]organismCheckpoint, len(p.Organisms)),
+		Species:                  make([]speciesCheckpoint, len(p.Species)),
+		LastSpecies:              p.LastSpecies,
+		WinnerGen:                p.WinnerGen,
+		FinalGen:                 p.FinalGen,
+		HighestFitness:           p.HighestFitness,
+		EpochsHighestLastChanged: p.EpochsHighestLastChanged,
+		MeanFitness:              p.MeanFitness,
+		Variance:                 p.Variance,
+		StandardDev:              p.StandardDev,
+		SearchPhase:              p.SearchPhase,
+		PhaseStartGeneration:     p.PhaseStartGeneration,
+		MeanComplexity:           p.MeanComplexity,
+		ComplexityCeiling:        p.ComplexityCeiling,
+		NextInnovNum:             owner.nextInnovNum,
+		NextNodeId:               owner.nextNodeId,
+		CompatThreshold:          p.compatThreshold(opts),
+	}
+
+	orgIndexes := make(map[*Organism]int, len(p.Organisms))
+	for i, org := range p.Organisms {
+		var buf bytes.Buffer
+		if writer, err := NewGenomeWriter(&buf, YAMLGenomeEncoding); err != nil {
+			return err
+		} else if err = writer.WriteGenome(org.Genotype); err != nil {
+			return errors.Wrapf(err, "failed to write genome of organism: %d", org.Genotype.Id)
+		}
+		cp.Organisms[i] = organismCheckpoint{
+			Genome:                    buf.Bytes(),
+			Fitness:                   org.Fitness,
+			Error:                     org.Error,
+			IsWinner:                  org.IsWinner,
+			Behavior:                  org.Behavior,
+			Novelty:                   org.Novelty,
+			Objectives:                org.Objectives,
+			ExpectedOffspring:         org.ExpectedOffspring,
+			Generation:                org.Generation,
+			TimeAlive:                 org.TimeAlive,
+			Flag:                      org.Flag,
+			HighestFitness:            org.highestFitness,
+			IsPopulationChampionChild: org.isPopulationChampionChild,
+		}
+		orgIndexes[org] = i
+	}
+
+	for i, sp := range p.Species {
+		members := make([]int, len(sp.Organisms))
+		for j, org := range sp.Organisms {
+			index, ok := orgIndexes[org]
+			if !ok {
+				return errors.Errorf("organism: %d of species: %d is not in population", org.Genotype.Id, sp.Id)
+			}
+			members[j] = index
+		}
+		cp.Species[i] = speciesCheckpoint{
+			Id:                   sp.Id,
+			Age:                  sp.Age,
+			MaxFitnessEver:       sp.MaxFitnessEver,
+			ExpectedOffspring:    sp.ExpectedOffspring,
+			IsNovel:              sp.IsNovel,
+			AgeOfLastImprovement: sp.AgeOfLastImprovement,
+			Organisms:            members,
+		}
+	}
+
+	innovations := p.innovations.Innovations()
+	cp.Innovations = make([]innovationCheckpoint, len(innovations))
+	for i, innovation := range innovations {
+		cp.Innovations[i] = innovationCheckpoint{
+			Innovation: innovation,
+			NewNode:    innovation.innovationType == newNodeInnType,
+		}
+	}
+
+	if p.NoveltyArchive != nil {
+		cp.NoveltyArchive = &noveltyArchiveCheckpoint{
+			Items:                  p.NoveltyArchive.Items,
+			Threshold:              p.NoveltyArchive.Threshold,
+			GenerationsWithoutAdds: p.NoveltyArchive.generationsWithoutAdds,
+		}
+	}
+
+	if src, ok := neat.RandSourceFromContext(ctx); ok {
+		state, err := src.MarshalBinary()
+		if err != nil {
+			return errors.Wrap(err, "failed to save the state of random numbers generator")
+		}
+		cp.RandState = state
+	}
+
+	enc := gob.NewEncoder(w)
+	return enc.Encode(cp)
+}
+
+// ReadCheckpoint Reads population from the checkpoint written by WriteCheckpoint and restores the state of the source
+// of random numbers generator, if it was stored and the context carries the generator created by
+// neat.NewRandSourceContext. Returns restored population and the generation when checkpoint was made.
+// The NEAT options from the context are not modified, and the novelty search options are used to restore the novelty
+// archive if any.
+func ReadCheckpoint(ctx context.Context, r io.Reader) (*Population, int, error) {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return nil, 0, neat.ErrNEATOptionsNotFound
+	}
+	cp := populationCheckpoint{}
+	dec := gob.NewDecoder(r)
+	if err := dec.Decode(&cp); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to decode population checkpoint")
+	}
+	if cp.Version != checkpointVersion {
+		return nil, 0, errors.Errorf("unsupported population checkpoint version: %d", cp.Version)
+	}
+
+	pop := newPopulation()
+	pop.LastSpecies = cp.LastSpecies
+	pop.WinnerGen = cp.WinnerGen
+	pop.FinalGen = cp.FinalGen
+	pop.HighestFitness = cp.HighestFitness
+	pop.EpochsHighestLastChanged = cp.EpochsHighestLastChanged
+	pop.MeanFitness = cp.MeanFitness
+	pop.Variance = cp.Variance
+	pop.StandardDev = cp.StandardDev
+	pop.SearchPhase = cp.SearchPhase
+	pop.PhaseStartGeneration = cp.PhaseStartGeneration
+	pop.MeanComplexity = cp.MeanComplexity
+	pop.ComplexityCeiling = cp.ComplexityCeiling
+	pop.nextInnovNum = cp.NextInnovNum
+	pop.nextNodeId = cp.NextNodeId
+
+	for i, oc := range cp.Organisms {
+		reader, err := NewGenomeReader(bytes.NewBuffer(oc.Genome), YAMLGenomeEncoding)
+		if err != nil {
+			return nil, 0, err
+		}
+		genome, err := reader.Read()
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to read genome of organism at: %d", i)
+		}
+		org, err := NewOrganism(oc.Fitness, genome, oc.Generation)
+		if err != nil {
+			return nil, 0, err
+		}
+		org.Error = oc.Error
+		org.IsWinner = oc.IsWinner
+		org.Behavior = oc.Behavior
+		org.Novelty = oc.Novelty
+		org.Objectives = oc.Objectives
+		org.ExpectedOffspring = oc.ExpectedOffspring
+		org.TimeAlive = oc.TimeAlive
+		org.Flag = oc.Flag
+		org.highestFitness = oc.HighestFitness
+		org.isPopulationChampionChild = oc.IsPopulationChampionChild
+		pop.Organisms = append(pop.Organisms, org)
+	}
+
+	for _, sc := range cp.Species {
+		sp := newSpecies(sc.Id)
+		sp.Age = sc.Age
+		sp.MaxFitnessEver = sc.MaxFitnessEver
+		sp.ExpectedOffspring = sc.ExpectedOffspring
+		sp.IsNovel = sc.IsNovel
+		sp.AgeOfLastImprovement = sc.AgeOfLastImprovement
+		for _, index := range sc.Organisms {
+			if index < 0 || index >= len(pop.Organisms) {
+				return nil, 0, errors.Errorf("wrong index of organism: %d in species: %d", index, sc.Id)
+			}
+			org := pop.Organisms[index]
+			sp.addOrganism(org)
+			org.Species = sp
+		}
+		pop.Species = append(pop.Species, sp)
+	}
+
+	for _, ic := range cp.Innovations {
+		innovation := ic.Innovation
+		if ic.NewNode {
+			innovation.innovationType = newNodeInnType
+		} else {
+			innovation.innovationType = newLinkInnType
+		}
+		pop.innovations.Store(innovation)
+	}
+
+	if cp.NoveltyArchive != nil {
+		if opts.NoveltySearch == nil {
+			return nil, 0, errors.New("novelty search options required to restore novelty archive")
+		}
+		pop.NoveltyArchive = NewNoveltyArchive(opts.NoveltySearch)
+		pop.NoveltyArchive.Items = cp.NoveltyArchive.Items
+		pop.NoveltyArchive.Threshold = cp.NoveltyArchive.Threshold
+		pop.NoveltyArchive.generationsWithoutAdds = cp.NoveltyArchive.GenerationsWithoutAdds
+	}
+
+	pop.CompatThreshold = cp.CompatThreshold
+
+	if src, ok := neat.RandSourceFromContext(ctx); ok && cp.RandState != nil {
+		if err := src.UnmarshalBinary(cp.RandState); err != nil {
+			return nil, 0, errors.Wrap(err, "failed to restore the state of random numbers generator")
+		}
+	}
+
+	return pop, cp.Generation, nil
+}
@@ -0,0 +1,159 @@
+package genetics
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
+	"testing"
+)
+
+func checkpointTestOptions() *neat.Options {
+	return &neat.Options{
+		CompatThreshold:    0.5,
+		DropOffAge:         1,
+		PopSize:            30,
+		MutateAddNodeProb:  0.2,
+		MutateAddLinkProb:  0.3,
+		NewLinkTries:       20,
+		KeepInnovations:    true,
+		NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+}
+
+// evolveForCheckpoint is to run given number of epochs assigning random fitness to organisms
+func evolveForCheckpoint(ctx context.Context, pop *Population, from, epochs int) error {
+	ex := SequentialPopulationEpochExecutor{}
+	rng := neat.RandFromContext(ctx)
+	for i := from; i < from+epochs; i++ {
+		for _, org := range pop.Organisms {
+			org.Fitness = rng.Float64()
+			org.TimeAlive++
+		}
+		if err := ex.NextEpoch(ctx, i, pop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestPopulation_WriteCheckpoint(t *testing.T) {
+	rand.Seed(42)
+	opts := checkpointTestOptions()
+	gen, err := newGenomeRand(1, 3, 2, 3, 15, false, 0.8, opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	pop, err := NewPopulation(gen, opts)
+	require.NoError(t, err, "failed to create population")
+
+	src := neat.NewSource(42)
+	ctx := neat.NewRandSourceContext(opts.NeatContext(), src)
+	err = evolveForCheckpoint(ctx, pop, 1, 5)
+	require.NoError(t, err, "failed to evolve population")
+	for _, org := range pop.Organisms {
+		org.Fitness = rand.Float64()
+		org.Behavior = []float64{rand.Float64(), rand.Float64()}
+	}
+
+	// the adjusted compatibility threshold is kept by population
+	pop.CompatThreshold = 0.7
+
+	state, err := src.MarshalBinary()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = pop.WriteCheckpoint(ctx, &buf, 5)
+	require.NoError(t, err, "failed to write checkpoint")
+
+	// the running generator is not affected
+	after, err := src.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, state, after)
+
+	restored, generation, err := ReadCheckpoint(opts.NeatContext(), &buf)
+	require.NoError(t, err, "failed to read checkpoint")
+	assert.Equal(t, 5, generation)
+	assert.Equal(t, 0.7, restored.CompatThreshold)
+	assert.Equal(t, 0.5, opts.CompatThreshold, "options must not be changed")
+
+	assert.Equal(t, pop.LastSpecies, restored.LastSpecies)
+	assert.Equal(t, pop.WinnerGen, restored.WinnerGen)
+	assert.Equal(t, pop.HighestFitness, restored.HighestFitness)
+	assert.Equal(t, pop.EpochsHighestLastChanged, restored.EpochsHighestLastChanged)
+	assert.Equal(t, pop.nextInnovNum, restored.nextInnovNum)
+	assert.Equal(t, pop.nextNodeId, restored.nextNodeId)
+	assert.Equal(t, pop.innovations.Innovations(), restored.innovations.Innovations())
+
+	require.Len(t, restored.Organisms, len(pop.Organisms))
+	for i, org := range pop.Organisms {
+		rOrg := restored.Organisms[i]
+		assert.Equal(t, org.Genotype.Id, rOrg.Genotype.Id)
+		assert.Equal(t, org.Genotype.String(), rOrg.Genotype.String())
+		assert.Equal(t, org.Fitness, rOrg.Fitness)
+		assert.Equal(t, org.Behavior, rOrg.Behavior)
+		assert.Equal(t, org.Generation, rOrg.Generation)
+		assert.Equal(t, org.TimeAlive, rOrg.TimeAlive)
+		assert.Equal(t, org.Species.Id, rOrg.Species.Id)
+		assert.NotNil(t, rOrg.Phenotype)
+	}
+
+	require.Len(t, restored.Species, len(pop.Species))
+	for i, sp := range pop.Species {
+		rSp := restored.Species[i]
+		assert.Equal(t, sp.Id, rSp.Id)
+		assert.Equal(t, sp.Age, rSp.Age)
+		assert.Equal(t, sp.MaxFitnessEver, rSp.MaxFitnessEver)
+		assert.Equal(t, sp.AgeOfLastImprovement, rSp.AgeOfLastImprovement)
+		require.Len(t, rSp.Organisms, len(sp.Organisms))
+		for j, org := range sp.Organisms {
+			assert.Equal(t, org.Genotype.Id, rSp.Organisms[j].Genotype.Id)
+		}
+	}
+}
+
+func TestReadCheckpoint_resume(t *testing.T) {
+	rand.Seed(42)
+	opts := checkpointTestOptions()
+	gen, err := newGenomeRand(1, 3, 2, 3, 15, false, 0.8, opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	pop, err := NewPopulation(gen, opts)
+	require.NoError(t, err, "failed to create population")
+
+	ctx := neat.NewRandSourceContext(opts.NeatContext(), neat.NewSource(42))
+	err = evolveForCheckpoint(ctx, pop, 1, 5)
+	require.NoError(t, err, "failed to evolve population")
+
+	var buf bytes.Buffer
+	err = pop.WriteCheckpoint(ctx, &buf, 5)
+	require.NoError(t, err, "failed to write checkpoint")
+	checkpoint := buf.Bytes()
+
+	// continue the original run
+	err = evolveForCheckpoint(ctx, pop, 6, 3)
+	require.NoError(t, err, "failed to continue original population")
+
+	// resume from checkpoint with differently seeded generator
+	resumeCtx := neat.NewRandSourceContext(opts.NeatContext(), neat.NewSource(1))
+	restored, generation, err := ReadCheckpoint(resumeCtx, bytes.NewBuffer(checkpoint))
+	require.NoError(t, err, "failed to read checkpoint")
+	err = evolveForCheckpoint(resumeCtx, restored, generation+1, 3)
+	require.NoError(t, err, "failed to continue restored population")
+
+	// check that resumed run is identical to the original one
+	require.Len(t, restored.Organisms, len(pop.Organisms))
+	for i, org := range pop.Organisms {
+		assert.Equal(t, org.Genotype.String(), restored.Organisms[i].Genotype.String())
+	}
+	assert.Equal(t, len(pop.Species), len(restored.Species))
+	assert.Equal(t, pop.HighestFitness, restored.HighestFitness)
+	assert.Equal(t, pop.nextInnovNum, restored.nextInnovNum)
+	assert.Equal(t, pop.nextNodeId, restored.nextNodeId)
+}
+
+func TestReadCheckpoint_corrupted(t *testing.T) {
+	_, _, err := ReadCheckpoint(checkpointTestOptions().NeatContext(), bytes.NewBufferString("not a checkpoint"))
+	assert.Error(t, err)
+}
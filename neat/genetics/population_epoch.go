@@ -1,11 +1,10 @@
 package genetics
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
 	"fmt"
 	"github.com/yaricom/goNEAT/v4/neat"
+	"math/rand"
 	"sort"
 	"sync"
 )
@@ -50,6 +49,20 @@ func (s *SequentialPopulationEpochExecutor) prepareForReproduction(ctx context.C
 	// clear executor state from previous run
 	s.sortedSpecies = nil
 
+	// Estimate the novelty of organisms' behaviors to be blended with their objective fitness if novelty search enabled
+	if opts.NoveltySearch != nil {
+		if err := p.evaluateNovelty(generation, opts.NoveltySearch); err != nil {
+			return err
+		}
+	}
+
+	// Rank organisms by Pareto fronts of their objectives if multi-objective selection enabled
+	if opts.MultiObjectiveSelection {
+		if err := rankObjectives(p.Organisms); err != nil {
+			return err
+		}
+	}
+
 	// Use Species' ages to modify the objective fitness of organisms in other words, make it more fair for younger
 	// species, so they have a chance to take hold and also penalize stagnant species. Then adjust the fitness using
 	// the species size to "share" fitness within a species. Then, within each Species, mark for death those below
@@ -86,8 +99,8 @@ func (s *SequentialPopulationEpochExecutor) prepareForReproduction(ctx context.C
 	// Check for Population-level stagnation
 	currSpecies := s.sortedSpecies[0]
 	currSpecies.Organisms[0].isPopulationChampion = true // DEBUG marker of the best of pop
-	if currSpecies.Organisms[0].originalFitness > p.HighestFitness {
-		p.HighestFitness = currSpecies.Organisms[0].originalFitness
+	if best := currSpecies.maxOriginalFitness(); best > p.HighestFitness {
+		p.HighestFitness = best
 		p.EpochsHighestLastChanged = 0
 		if neat.LogLevel == neat.LogLevelDebug {
 			neat.DebugLog(fmt.Sprintf("POPULATION: NEW POPULATION RECORD FITNESS: %f of SPECIES with ID: %d\n", p.HighestFitness, s.bestSpeciesId))
@@ -99,6 +112,11 @@ func (s *SequentialPopulationEpochExecutor) prepareForReproduction(ctx context.C
 		}
 	}
 
+	// Switch between complexifying and simplifying phases if phased search enabled
+	if opts.PhasedSearch != nil {
+		p.updateSearchPhase(generation, opts.PhasedSearch)
+	}
+
 	// Check for stagnation - if there is stagnation, perform delta-coding
 	if p.EpochsHighestLastChanged >= opts.DropOffAge+5 {
 		// Population stagnated - trying to fix it by delta coding
@@ -106,7 +124,7 @@ func (s *SequentialPopulationEpochExecutor) prepareForReproduction(ctx context.C
 	} else if opts.BabiesStolen > 0 {
 		// STOLEN BABIES: The system can take expected offspring away from worse species and give them
 		// to superior species depending on the system parameter BabiesStolen (when BabiesStolen > 0)
-		p.giveBabiesToTheBest(s.sortedSpecies, opts)
+		p.giveBabiesToTheBest(s.sortedSpecies, opts, neat.RandFromContext(ctx))
 	}
 
 	// Kill off all Organisms marked for death. The remainder will be allowed to reproduce.
@@ -123,23 +141,21 @@ func (s *SequentialPopulationEpochExecutor) reproduce(ctx context.Context, gener
 	}
 
 	// Perform reproduction. Reproduction is done on a per-Species basis
-	babies := make([]*Organism, 0)
-
-	for _, sp := range p.Species {
-		repBabies, err := sp.reproduce(ctx, generation, p, s.sortedSpecies)
-		if err != nil {
+	reproductions := newSpeciesReproductions(ctx, p)
+	for _, r := range reproductions {
+		if err := r.reproduce(ctx, generation, p, s.sortedSpecies); err != nil {
 			return err
 		}
-		if sp.Id == s.bestSpeciesId {
+		if r.species.Id == s.bestSpeciesId {
 			// store flag if best species reproduced - it will be used to determine if best species
 			// produced offspring before died
 			s.bestSpeciesReproduced = true
 		}
-
-		// store babies
-		babies = append(babies, repBabies...)
 	}
 
+	// commit innovations of species in order and collect babies
+	babies := commitSpeciesReproductions(reproductions)
+
 	// sanity check - make sure that population size keep the same
 	if len(babies) != opts.PopSize {
 		return fmt.Errorf("progeny size after reproduction cycle dimished, expected: [%d], but got: [%d]",
@@ -155,7 +171,12 @@ func (s *SequentialPopulationEpochExecutor) reproduce(ctx context.Context, gener
 }
 
 // finalizeReproduction is to finalizeReproduction reproduction cycle
-func (s *SequentialPopulationEpochExecutor) finalizeReproduction(_ context.Context, pop *Population) error {
+func (s *SequentialPopulationEpochExecutor) finalizeReproduction(ctx context.Context, pop *Population) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+
 	// Destroy and remove the old generation from the organisms and species
 	err := pop.purgeOldGeneration(s.bestSpeciesId)
 	if err != nil {
@@ -166,8 +187,14 @@ func (s *SequentialPopulationEpochExecutor) finalizeReproduction(_ context.Conte
 	// As this happens, create master organism list for the new generation.
 	pop.purgeOrAgeSpecies()
 
-	// Remove the innovations of the current generation
-	pop.innovations = make([]Innovation, 0)
+	// Adjust compatibility threshold to steer the number of species of the next generation towards the target
+	pop.adjustCompatThreshold(opts)
+
+	// Remove the innovations of the current generation unless they are kept for the whole run or shared with other
+	// populations, which are not turned over yet
+	if !opts.KeepInnovations && !pop.sharesInnovations() {
+		pop.ResetInnovations()
+	}
 
 	// Check to see if the best species died somehow. We don't want this to happen!!!
 	err = pop.checkBestSpeciesAlive(s.bestSpeciesId, s.bestSpeciesReproduced)
@@ -218,71 +245,41 @@ func (p *ParallelPopulationEpochExecutor) reproduce(ctx context.Context, generat
 		return neat.ErrNEATOptionsNotFound
 	}
 
-	// Perform reproduction. Reproduction is done on a per-Species basis
-	spNum := len(pop.Species)
-	resChan := make(chan reproductionResult, spNum)
+	// Perform reproduction. Reproduction is done on a per-Species basis. Each species is reproduced in separate
+	// GO routine with its own stream of random numbers and journal of innovations, which store the offspring into
+	// the species reproduction, so there is no shared state modified concurrently.
+	reproductions := newSpeciesReproductions(ctx, pop)
+	errs := make([]error, len(reproductions))
 	// The wait group to wait for all GO routines
 	var wg sync.WaitGroup
 
-	for _, species := range pop.Species {
+	for i, r := range reproductions {
 		wg.Add(1)
 		// run in separate GO thread
-		go func(ctx context.Context, sp *Species, generation int, p *Population, sortedSpecies []*Species, resChan chan<- reproductionResult, wg *sync.WaitGroup) {
+		go func(index int, r *speciesReproduction) {
 			defer wg.Done()
-			babies, err := sp.reproduce(ctx, generation, p, sortedSpecies)
-
-			res := reproductionResult{}
-			if err == nil {
-				res.speciesId = sp.Id
-
-				// fill babies into result
-				var buf bytes.Buffer
-				enc := gob.NewEncoder(&buf)
-				for _, baby := range babies {
-					if err = enc.Encode(baby); err != nil {
-						break
-					}
-				}
-				if err == nil {
-					res.babies = buf.Bytes()
-					res.babiesStored = len(babies)
-				}
-			}
-			res.err = err
-
-			// write result to channel and signal to wait group
-			resChan <- res
-
-		}(ctx, species, generation, pop, p.sequential.sortedSpecies, resChan, &wg)
+			errs[index] = r.reproduce(ctx, generation, pop, p.sequential.sortedSpecies)
+		}(i, r)
 	}
 
 	// wait for reproduction results
 	wg.Wait()
-	close(resChan)
 
-	// read reproduction results, instantiate progeny and speciate over population
-	babies := make([]*Organism, 0)
-	for result := range resChan {
-		if result.err != nil {
-			return result.err
+	// check reproduction results in order of species
+	for i, r := range reproductions {
+		if errs[i] != nil {
+			return errs[i]
 		}
-		// read baby genome
-		dec := gob.NewDecoder(bytes.NewBuffer(result.babies))
-		for i := 0; i < result.babiesStored; i++ {
-			org := Organism{}
-			err := dec.Decode(&org)
-			if err != nil {
-				return fmt.Errorf("failed to decode baby organism, reason: %v", err)
-			}
-			babies = append(babies, &org)
-		}
-		if result.speciesId == p.sequential.bestSpeciesId {
+		if r.species.Id == p.sequential.bestSpeciesId {
 			// store flag if best species reproduced - it will be used to determine if best species
 			// produced offspring before died
-			p.sequential.bestSpeciesReproduced = babies != nil
+			p.sequential.bestSpeciesReproduced = true
 		}
 	}
 
+	// commit innovations of species in order and collect babies to be speciated over population
+	babies := commitSpeciesReproductions(reproductions)
+
 	// sanity check - make sure that population size keep the same
 	if len(babies) != opts.PopSize {
 		return fmt.Errorf("progeny size after reproduction cycle dimished, expected: [%d], but got: [%d]",
@@ -296,3 +293,44 @@ func (p *ParallelPopulationEpochExecutor) reproduce(ctx context.Context, generat
 
 	return err
 }
+
+// speciesReproduction The reproduction of a single species with its own stream of random numbers and journal of
+// innovations, so that species can be reproduced in any order with the same results
+type speciesReproduction struct {
+	species     *Species
+	rng         *rand.Rand
+	innovations *innovationsJournal
+	babies      []*Organism
+}
+
+// newSpeciesReproductions is to create reproductions of all species of the population. The streams of random numbers
+// are derived from the generator of the context in order of species.
+func newSpeciesReproductions(ctx context.Context, pop *Population) []*speciesReproduction {
+	rng := neat.RandFromContext(ctx)
+	reproductions := make([]*speciesReproduction, len(pop.Species))
+	for i, sp := range pop.Species {
+		reproductions[i] = &speciesReproduction{
+			species:     sp,
+			rng:         neat.DeriveRand(rng),
+			innovations: newInnovationsJournal(pop),
+		}
+	}
+	return reproductions
+}
+
+// reproduce is to produce offspring of the species
+func (r *speciesReproduction) reproduce(ctx context.Context, generation int, pop *Population, sortedSpecies []*Species) (err error) {
+	r.babies, err = r.species.reproduce(neat.NewRandContext(ctx, r.rng), generation, pop, sortedSpecies, r.innovations)
+	return err
+}
+
+// commitSpeciesReproductions is to commit innovations of reproduced species to the population in order of species and
+// to collect their offspring
+func commitSpeciesReproductions(reproductions []*speciesReproduction) []*Organism {
+	babies := make([]*Organism, 0)
+	for _, r := range reproductions {
+		r.innovations.commit(r.babies)
+		babies = append(babies, r.babies...)
+	}
+	return babies
+}
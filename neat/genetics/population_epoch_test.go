@@ -1,6 +1,7 @@
 package genetics
 
 import (
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,7 +50,7 @@ func TestPopulationEpochExecutor_NextEpoch(t *testing.T) {
 		NodeActivatorsProb: []float64{1.0},
 	}
 	neat.LogLevel = neat.LogLevelInfo
-	gen, err := newGenomeRand(1, in, out, n, maxHidden, false, linkProb, conf)
+	gen, err := newGenomeRand(1, in, out, n, maxHidden, false, linkProb, conf, neat.GlobalRand())
 	require.NoError(t, err, "failed to create random genome")
 
 	pop, err := NewPopulation(gen, conf)
@@ -64,3 +65,130 @@ func TestPopulationEpochExecutor_NextEpoch(t *testing.T) {
 	err = parallelExecutorNextEpoch(pop, conf)
 	assert.NoError(t, err, "failed to run parallel epoch executor")
 }
+
+func TestPopulationEpochExecutor_NextEpoch_keepInnovations(t *testing.T) {
+	for _, keep := range []bool{false, true} {
+		rand.Seed(42)
+		in, out, maxHidden, n := 3, 2, 15, 3
+		conf := &neat.Options{
+			CompatThreshold:    0.5,
+			DropOffAge:         1,
+			PopSize:            30,
+			MutateAddNodeProb:  0.2,
+			MutateAddLinkProb:  0.3,
+			NewLinkTries:       20,
+			KeepInnovations:    keep,
+			NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
+			NodeActivatorsProb: []float64{1.0},
+		}
+		neat.LogLevel = neat.LogLevelInfo
+		gen, err := newGenomeRand(1, in, out, n, maxHidden, false, 0.5, conf, neat.GlobalRand())
+		require.NoError(t, err, "failed to create random genome")
+
+		pop, err := NewPopulation(gen, conf)
+		require.NoError(t, err, "failed to create population")
+
+		ex := SequentialPopulationEpochExecutor{}
+		innovationsCount := 0
+		for i := 0; i < 5; i++ {
+			err = ex.NextEpoch(conf.NeatContext(), i+1, pop)
+			require.NoError(t, err, "failed at: %d epoch", i)
+			if keep {
+				assert.GreaterOrEqual(t, len(pop.Innovations()), innovationsCount, "innovations lost at: %d epoch", i)
+				innovationsCount = len(pop.Innovations())
+			} else {
+				assert.Empty(t, pop.Innovations(), "innovations kept at: %d epoch", i)
+			}
+		}
+		if keep {
+			assert.NotZero(t, innovationsCount, "no innovations kept")
+		}
+	}
+}
+
+func TestPopulationEpochExecutor_NextEpoch_speciesCountTarget(t *testing.T) {
+	rand.Seed(42)
+	in, out, maxHidden, n := 3, 2, 15, 3
+	linkProb := 0.8
+	conf := &neat.Options{
+		CompatThreshold:         0.5,
+		SpeciesCountTarget:      5,
+		CompatThresholdModifier: 0.1,
+		CompatThresholdMin:      0.1,
+		DropOffAge:              1,
+		PopSize:                 30,
+		BabiesStolen:            10,
+		RecurOnlyProb:           0.2,
+		NodeActivators:          []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb:      []float64{1.0},
+	}
+	neat.LogLevel = neat.LogLevelInfo
+	gen, err := newGenomeRand(1, in, out, n, maxHidden, false, linkProb, conf, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+
+	pop, err := NewPopulation(gen, conf)
+	require.NoError(t, err, "failed to create population")
+
+	ex := SequentialPopulationEpochExecutor{}
+	for i := 0; i < 10; i++ {
+		speciesCount, threshold := len(pop.Species), pop.CompatThreshold
+		err = ex.NextEpoch(conf.NeatContext(), i+1, pop)
+		require.NoError(t, err, "failed at: %d epoch", i)
+
+		// check that threshold moved in right direction
+		if len(pop.Species) < conf.SpeciesCountTarget {
+			assert.True(t, pop.CompatThreshold < threshold || pop.CompatThreshold == conf.CompatThresholdMin,
+				"threshold must be lowered at: %d epoch, species: %d -> %d", i, speciesCount, len(pop.Species))
+		} else if len(pop.Species) > conf.SpeciesCountTarget {
+			assert.Greater(t, pop.CompatThreshold, threshold, "threshold must be raised at: %d epoch", i)
+		}
+	}
+	// the threshold of the options is not changed, so that the next population starts from the same one
+	assert.Equal(t, 0.5, conf.CompatThreshold)
+}
+
+func TestPopulationEpochExecutor_NextEpoch_deterministic(t *testing.T) {
+	in, out, maxHidden, n := 3, 2, 15, 3
+	conf := &neat.Options{
+		CompatThreshold:    0.5,
+		DropOffAge:         1,
+		PopSize:            30,
+		BabiesStolen:       10,
+		MutateAddNodeProb:  0.2,
+		MutateAddLinkProb:  0.3,
+		NewLinkTries:       20,
+		RecurOnlyProb:      0.2,
+		NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+	neat.LogLevel = neat.LogLevelInfo
+
+	runEpochs := func(executor PopulationEpochExecutor) []string {
+		ctx := neat.NewRandContext(conf.NeatContext(), neat.NewRand(42))
+		rng := neat.RandFromContext(ctx)
+		gen, err := newGenomeRand(1, in, out, n, maxHidden, false, 0.5, conf, rng)
+		require.NoError(t, err, "failed to create random genome")
+
+		pop, err := NewPopulationContext(ctx, gen)
+		require.NoError(t, err, "failed to create population")
+
+		for i := 0; i < 10; i++ {
+			for _, org := range pop.Organisms {
+				org.Fitness = rng.Float64()
+			}
+			err = executor.NextEpoch(ctx, i+1, pop)
+			require.NoError(t, err, "failed at: %d epoch", i)
+		}
+
+		genomes := make([]string, len(pop.Organisms)+1)
+		for i, org := range pop.Organisms {
+			genomes[i] = org.Genotype.String()
+		}
+		genomes[len(pop.Organisms)] = fmt.Sprintf("species: %d", len(pop.Species))
+		return genomes
+	}
+
+	sequential := runEpochs(&SequentialPopulationEpochExecutor{})
+	parallel := runEpochs(&ParallelPopulationEpochExecutor{})
+	assert.Equal(t, sequential, parallel, "executors produced different populations from the same seed")
+}
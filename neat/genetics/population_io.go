@@ -13,6 +13,7 @@ import (
 // ReadPopulation reads population from provided reader
 func ReadPopulation(ir io.Reader, options *neat.Options) (pop *Population, err error) {
 	pop = newPopulation()
+	pop.CompatThreshold = options.CompatThreshold
 
 	// Loop until file is finished, parsing each line
 	scanner := bufio.NewScanner(ir)
@@ -81,6 +81,34 @@ func TestPopulation_Write(t *testing.T) {
 	}
 }
 
+func TestPopulation_Write_mutationPower(t *testing.T) {
+	conf := neat.Options{
+		CompatThreshold: 0.5,
+	}
+	pop, err := ReadPopulation(strings.NewReader(popStr), &conf)
+	require.NoError(t, err, "failed to create population")
+	for i, org := range pop.Organisms {
+		for j, gene := range org.Genotype.Genes {
+			gene.MutationPower = 0.1 * float64(i+j+1)
+		}
+	}
+
+	// the self-adapted mutation powers survive the round-trip
+	outBuf := bytes.NewBufferString("")
+	err = pop.Write(outBuf)
+	require.NoError(t, err, "failed to write population")
+	restored, err := ReadPopulation(outBuf, &conf)
+	require.NoError(t, err, "failed to read population")
+	require.Len(t, restored.Organisms, len(pop.Organisms))
+	for i, org := range pop.Organisms {
+		restoredGenes := restored.Organisms[i].Genotype.Genes
+		require.Len(t, restoredGenes, len(org.Genotype.Genes))
+		for j, gene := range org.Genotype.Genes {
+			assert.Equal(t, gene.MutationPower, restoredGenes[j].MutationPower, "wrong mutation power at: %d, %d", i, j)
+		}
+	}
+}
+
 func TestPopulation_Write_writeError(t *testing.T) { // first create population
 	conf := neat.Options{
 		CompatThreshold: 0.5,
@@ -1,6 +1,7 @@
 package genetics
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaricom/goNEAT/v4/neat"
@@ -35,6 +36,31 @@ func TestNewPopulationRandom(t *testing.T) {
 	}
 }
 
+func TestNewPopulationRandomContext(t *testing.T) {
+	in, out, maxHidden := 3, 2, 5
+	linkProb := 0.5
+	conf := &neat.Options{
+		CompatThreshold:    0.5,
+		PopSize:            10,
+		NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+	first, err := NewPopulationRandomContext(neat.NewRandContext(conf.NeatContext(), neat.NewRand(42)), in, out, maxHidden, false, linkProb)
+	require.NoError(t, err, "failed to create population")
+	// the global generator is not used
+	rand.Seed(1)
+	second, err := NewPopulationRandomContext(neat.NewRandContext(conf.NeatContext(), neat.NewRand(42)), in, out, maxHidden, false, linkProb)
+	require.NoError(t, err, "failed to create population")
+
+	require.Len(t, second.Organisms, len(first.Organisms))
+	for i, org := range first.Organisms {
+		assert.Equal(t, org.Genotype.String(), second.Organisms[i].Genotype.String(), "different genomes at: %d", i)
+	}
+
+	_, err = NewPopulationRandomContext(context.Background(), in, out, maxHidden, false, linkProb)
+	assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+}
+
 func TestNewPopulation(t *testing.T) {
 	rand.Seed(42)
 	in, out, nmax, n := 3, 2, 5, 3
@@ -45,7 +71,7 @@ func TestNewPopulation(t *testing.T) {
 		NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
 		NodeActivatorsProb: []float64{1.0},
 	}
-	gen, err := newGenomeRand(1, in, out, n, nmax, false, linkProb, conf)
+	gen, err := newGenomeRand(1, in, out, n, nmax, false, linkProb, conf, neat.GlobalRand())
 	require.NoError(t, err, "failed to create random genome")
 
 	pop, err := NewPopulation(gen, conf)
@@ -106,3 +132,61 @@ func TestPopulation_verify(t *testing.T) {
 	require.NoError(t, err, "failed to verify population")
 	assert.True(t, res, "Population verification failed, but must not")
 }
+
+func TestPopulation_adjustCompatThreshold(t *testing.T) {
+	opts := &neat.Options{
+		CompatThreshold:         3.0,
+		SpeciesCountTarget:      2,
+		CompatThresholdModifier: 0.5,
+		CompatThresholdMin:      2.0,
+	}
+	pop := newPopulation()
+	pop.Species = []*Species{NewSpecies(1)}
+
+	// too few species
+	pop.adjustCompatThreshold(opts)
+	assert.Equal(t, 2.5, pop.CompatThreshold)
+
+	// can not go below minimum
+	pop.adjustCompatThreshold(opts)
+	pop.adjustCompatThreshold(opts)
+	assert.Equal(t, 2.0, pop.CompatThreshold)
+
+	// on target
+	pop.Species = append(pop.Species, NewSpecies(2))
+	pop.adjustCompatThreshold(opts)
+	assert.Equal(t, 2.0, pop.CompatThreshold)
+
+	// too many species
+	pop.Species = append(pop.Species, NewSpecies(3))
+	pop.adjustCompatThreshold(opts)
+	assert.Equal(t, 2.5, pop.CompatThreshold)
+
+	// disabled
+	opts.SpeciesCountTarget = 0
+	pop.adjustCompatThreshold(opts)
+	assert.Equal(t, 2.5, pop.CompatThreshold)
+
+	// the options stay unchanged
+	assert.Equal(t, 3.0, opts.CompatThreshold)
+}
+
+func TestPopulation_RandomOrganisms(t *testing.T) {
+	rand.Seed(42)
+	pop := newPopulation()
+	for i := 0; i < 10; i++ {
+		org, err := NewOrganism(float64(i), buildTestGenome(i+1), 1)
+		require.NoError(t, err)
+		pop.Organisms = append(pop.Organisms, org)
+	}
+
+	sample := pop.RandomOrganisms(4, neat.GlobalRand())
+	require.Len(t, sample, 4)
+	seen := make(map[*Organism]bool)
+	for _, org := range sample {
+		assert.Contains(t, pop.Organisms, org)
+		assert.False(t, seen[org], "organism sampled twice")
+		seen[org] = true
+	}
+	assert.Len(t, pop.RandomOrganisms(20, neat.GlobalRand()), 10)
+}
@@ -0,0 +1,238 @@
+package genetics
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"math/rand"
+	"sort"
+)
+
+// ErrRealTimeOptionsNotFound The error to be raised when real-time NEAT options are missing in the NEAT options
+var ErrRealTimeOptionsNotFound = errors.New("real-time NEAT options not found in the NEAT options")
+
+// ReplaceWorst Performs one replacement of the real-time NEAT (rtNEAT), which is used instead of the generational
+// turnover of population when organisms are evaluated continuously within running simulation. It removes the worst
+// organism evaluated long enough (see RemoveWorst) and breeds a single replacement (see ReproduceOne). Returns removed
+// organism and its replacement, if there are no organisms eligible for removal, nothing is done and both are nil.
+// It should be invoked every number of simulation ticks estimated by neat.RealTimeOptions.ReplacementInterval.
+func (p *Population) ReplaceWorst(ctx context.Context, generation int) (removed, offspring *Organism, err error) {
+	if removed, err = p.RemoveWorst(ctx); err != nil || removed == nil {
+		return nil, nil, err
+	}
+	if offspring, err = p.ReproduceOne(ctx, generation); err != nil {
+		return nil, nil, err
+	}
+	return removed, offspring, nil
+}
+
+// RemoveWorst Removes from this population the organism with the lowest adjusted fitness, i.e. the fitness shared with
+// its species, among organisms which time alive is not less than the minimal evaluation age of real-time NEAT options.
+// The species left without organisms are removed as well. Returns removed organism or nil if there are no organisms
+// eligible for removal.
+func (p *Population) RemoveWorst(ctx context.Context) (*Organism, error) {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return nil, neat.ErrNEATOptionsNotFound
+	}
+	if opts.RealTime == nil {
+		return nil, ErrRealTimeOptionsNotFound
+	}
+
+	worstIndex := -1
+	worstFitness := 0.0
+	for i, org := range p.Organisms {
+		if org.TimeAlive < opts.RealTime.MinEvaluationAge {
+			continue
+		}
+		adjustedFitness := org.Fitness / float64(len(org.Species.Organisms))
+		if worstIndex < 0 || adjustedFitness < worstFitness {
+			worstIndex, worstFitness = i, adjustedFitness
+		}
+	}
+	if worstIndex < 0 {
+		return nil, nil
+	}
+
+	worst := p.Organisms[worstIndex]
+	if _, err := worst.Species.removeOrganism(worst); err != nil {
+		return nil, err
+	}
+	if len(worst.Species.Organisms) == 0 {
+		for i, sp := range p.Species {
+			if sp == worst.Species {
+				p.Species = append(p.Species[:i], p.Species[i+1:]...)
+				break
+			}
+		}
+	}
+	p.Organisms = append(p.Organisms[:worstIndex], p.Organisms[worstIndex+1:]...)
+
+	if neat.LogLevel == neat.LogLevelDebug {
+		neat.DebugLog(fmt.Sprintf("POPULATION: Removed the worst organism [%d] with fitness: %f of species [%d]",
+			worst.Genotype.Id, worst.Fitness, worst.Species.Id))
+	}
+	return worst, nil
+}
+
+// ReproduceOne Breeds a single offspring from the species chosen with probability proportional to its average
+// fitness among organisms evaluated long enough and assigns it to the species of this population. Only the organisms
+// which time alive is not less than the minimal evaluation age take part in mating, unless there are no such organisms.
+// The innovations of the replacement cycle are removed afterwards, unless neat.Options.KeepInnovations is set.
+// Returns created offspring, which is added to the organisms of this population.
+func (p *Population) ReproduceOne(ctx context.Context, generation int) (*Organism, error) {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return nil, neat.ErrNEATOptionsNotFound
+	}
+	if opts.RealTime == nil {
+		return nil, ErrRealTimeOptionsNotFound
+	}
+	if len(p.Species) == 0 {
+		return nil, errors.New("no species to reproduce from")
+	}
+
+	nextGenomeId := 0
+	for _, org := range p.Organisms {
+		org.originalFitness = org.Fitness
+		if org.Genotype.Id >= nextGenomeId {
+			nextGenomeId = org.Genotype.Id + 1
+		}
+	}
+	baby, parentSpecies, err := p.reproduceEvaluated(ctx, generation, opts.RealTime.MinEvaluationAge)
+	if err != nil {
+		return nil, err
+	}
+	baby.Genotype.Id = nextGenomeId
+
+	if err = p.speciate(ctx, []*Organism{baby}); err != nil {
+		return nil, err
+	}
+	p.Organisms = append(p.Organisms, baby)
+
+	// Remove the innovations of this replacement cycle
+	if !opts.KeepInnovations && !p.sharesInnovations() {
+		p.ResetInnovations()
+	}
+
+	if neat.LogLevel == neat.LogLevelDebug {
+		neat.DebugLog(fmt.Sprintf("POPULATION: Offspring [%d] of species [%d] assigned to species [%d]",
+			baby.Genotype.Id, parentSpecies.Id, baby.Species.Id))
+	}
+	return baby, nil
+}
+
+// reproduceEvaluated is to breed a single offspring from the mating pool of organisms evaluated long enough. During
+// reproduction the organisms of species are limited to the mating pool, and restored afterwards. If none of the
+// organisms was evaluated long enough, all organisms are in the mating pool. Returns created offspring and its parent
+// species.
+func (p *Population) reproduceEvaluated(ctx context.Context, generation, minEvaluationAge int) (*Organism, *Species, error) {
+	rng := neat.RandFromContext(ctx)
+	parentSpecies := p.chooseParentSpecies(minEvaluationAge, rng)
+	if parentSpecies == nil {
+		minEvaluationAge = 0
+		parentSpecies = p.chooseParentSpecies(minEvaluationAge, rng)
+	}
+
+	// Prepare species for reproduction: the organisms of mating pool sorted with most fit first, and the species with
+	// the best organism first
+	organisms := make(map[*Species]Organisms, len(p.Species))
+	sortedSpecies := make([]*Species, 0, len(p.Species))
+	for _, sp := range p.Species {
+		sort.Sort(sort.Reverse(sp.Organisms))
+		matingPool := sp.evaluatedOrganisms(minEvaluationAge)
+		if len(matingPool) == 0 {
+			continue
+		}
+		organisms[sp] = sp.Organisms
+		sp.Organisms = matingPool
+		sortedSpecies = append(sortedSpecies, sp)
+	}
+	defer func() {
+		for sp, orgs := range organisms {
+			sp.Organisms = orgs
+		}
+	}()
+	sort.Sort(sort.Reverse(byOrganismOrigFitness(sortedSpecies)))
+
+	parentSpecies.ExpectedOffspring = 1
+	babies, err := parentSpecies.reproduce(ctx, generation, p, sortedSpecies, p)
+	parentSpecies.ExpectedOffspring = 0
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(babies) != 1 {
+		return nil, nil, errors.Errorf("single offspring expected, but got: %d", len(babies))
+	}
+	return babies[0], parentSpecies, nil
+}
+
+// chooseParentSpecies is to choose the species to reproduce from with probability proportional to the average fitness
+// of its organisms evaluated long enough. If none of the species has positive average fitness, the species is
+// chosen uniformly at random among species having organisms evaluated long enough. Returns nil if there are no such
+// species.
+func (p *Population) chooseParentSpecies(minEvaluationAge int, rng *rand.Rand) *Species {
+	averages := make([]float64, len(p.Species))
+	candidates := make([]*Species, 0, len(p.Species))
+	total := 0.0
+	for i, sp := range p.Species {
+		if len(sp.evaluatedOrganisms(minEvaluationAge)) == 0 {
+			continue
+		}
+		candidates = append(candidates, sp)
+		if average := sp.estimateAverageFitness(minEvaluationAge); average > 0 {
+			averages[i] = average
+			total += average
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	if total <= 0 {
+		return candidates[rng.Intn(len(candidates))]
+	}
+
+	marble := rng.Float64() * total
+	spin := 0.0
+	var chosen *Species
+	for i, sp := range p.Species {
+		if averages[i] <= 0 {
+			continue
+		}
+		chosen = sp
+		spin += averages[i]
+		if marble < spin {
+			break
+		}
+	}
+	return chosen
+}
+
+// evaluatedOrganisms is to get organisms of this species, which time alive is not less than the minimal evaluation
+// age, in the order they are stored in this species
+func (s *Species) evaluatedOrganisms(minEvaluationAge int) Organisms {
+	evaluated := make(Organisms, 0, len(s.Organisms))
+	for _, org := range s.Organisms {
+		if org.TimeAlive >= minEvaluationAge {
+			evaluated = append(evaluated, org)
+		}
+	}
+	return evaluated
+}
+
+// estimateAverageFitness is to estimate the average fitness of organisms of this species, which time alive is not less
+// than the minimal evaluation age. Returns zero if there are no such organisms.
+func (s *Species) estimateAverageFitness(minEvaluationAge int) float64 {
+	total, count := 0.0, 0
+	for _, org := range s.Organisms {
+		if org.TimeAlive >= minEvaluationAge {
+			total += org.Fitness
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
@@ -0,0 +1,239 @@
+package genetics
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
+	"testing"
+)
+
+func buildTestRealTimePopulation(t *testing.T) (*Population, *neat.Options) {
+	rand.Seed(42)
+	conf := &neat.Options{
+		CompatThreshold:       0.5,
+		PopSize:               20,
+		MutateOnlyProb:        0.25,
+		MutateAddNodeProb:     0.1,
+		MutateAddLinkProb:     0.2,
+		MutateLinkWeightsProb: 0.9,
+		WeightMutPower:        2.5,
+		MateMultipointProb:    0.6,
+		MateOnlyProb:          0.2,
+		NewLinkTries:          20,
+		NodeActivators:        []math.NodeActivationType{math.SigmoidSteepenedActivation},
+		NodeActivatorsProb:    []float64{1.0},
+		RealTime: &neat.RealTimeOptions{
+			MinEvaluationAge:   10,
+			IneligibleFraction: 0.5,
+		},
+	}
+	gen, err := newGenomeRand(1, 3, 2, 2, 5, false, 0.5, conf, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	pop, err := NewPopulation(gen, conf)
+	require.NoError(t, err, "failed to create population")
+	return pop, conf
+}
+
+func checkRealTimePopulation(t *testing.T, pop *Population, size int) {
+	require.Len(t, pop.Organisms, size)
+	count := 0
+	ids := make(map[int]bool)
+	for _, sp := range pop.Species {
+		require.NotEmpty(t, sp.Organisms, "empty species: %d", sp.Id)
+		count += len(sp.Organisms)
+	}
+	assert.Equal(t, size, count, "organisms of species don't match population")
+	for _, org := range pop.Organisms {
+		require.Contains(t, pop.Species, org.Species)
+		assert.False(t, ids[org.Genotype.Id], "duplicate genome ID: %d", org.Genotype.Id)
+		ids[org.Genotype.Id] = true
+	}
+}
+
+func TestPopulation_RemoveWorst(t *testing.T) {
+	pop, conf := buildTestRealTimePopulation(t)
+	ctx := conf.NeatContext()
+
+	// no organisms evaluated long enough
+	removed, err := pop.RemoveWorst(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, removed)
+
+	// only half of organisms evaluated long enough
+	for i, org := range pop.Organisms {
+		org.Fitness = float64(i + 1)
+		if i%2 == 1 {
+			org.TimeAlive = conf.RealTime.MinEvaluationAge
+		}
+	}
+	expected := pop.Organisms[1]
+	removed, err = pop.RemoveWorst(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, removed)
+	assert.Same(t, expected, removed)
+	assert.NotContains(t, pop.Organisms, removed)
+	assert.NotContains(t, removed.Species.Organisms, removed)
+	checkRealTimePopulation(t, pop, conf.PopSize-1)
+}
+
+func TestPopulation_RemoveWorst_emptySpecies(t *testing.T) {
+	pop, conf := buildTestRealTimePopulation(t)
+	org := pop.Organisms[0]
+	org.TimeAlive = conf.RealTime.MinEvaluationAge
+
+	// move organism into its own species
+	_, err := org.Species.removeOrganism(org)
+	require.NoError(t, err)
+	createFirstSpecies(pop, org)
+	speciesCount := len(pop.Species)
+
+	removed, err := pop.RemoveWorst(conf.NeatContext())
+	require.NoError(t, err)
+	assert.Same(t, org, removed)
+	assert.Len(t, pop.Species, speciesCount-1)
+	assert.NotContains(t, pop.Species, org.Species)
+}
+
+func TestPopulation_ReproduceOne(t *testing.T) {
+	pop, conf := buildTestRealTimePopulation(t)
+	for i, org := range pop.Organisms {
+		org.Fitness = float64(i + 1)
+		org.TimeAlive = conf.RealTime.MinEvaluationAge
+	}
+
+	baby, err := pop.ReproduceOne(conf.NeatContext(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, baby)
+	assert.Contains(t, pop.Organisms, baby)
+	assert.Zero(t, baby.TimeAlive)
+	assert.Equal(t, conf.PopSize, baby.Genotype.Id)
+	checkRealTimePopulation(t, pop, conf.PopSize+1)
+}
+
+// poolRecordingSelection records the pools of organisms provided for parent selection
+type poolRecordingSelection struct {
+	pools []Organisms
+}
+
+func (p *poolRecordingSelection) SelectParent(pool Organisms, rng *rand.Rand) *Organism {
+	p.pools = append(p.pools, pool)
+	return pool[rng.Intn(len(pool))]
+}
+
+func TestPopulation_ReproduceOne_evaluatedMatingPool(t *testing.T) {
+	pop, conf := buildTestRealTimePopulation(t)
+	conf.InterspeciesMateRate = 0
+	selection := &poolRecordingSelection{}
+	pop.ParentSelection = selection
+	// only every third organism evaluated long enough, the rest are more fit but still not evaluated
+	evaluated := make(map[*Organism]bool)
+	for i, org := range pop.Organisms {
+		org.Fitness = float64(i + 100)
+		if i%3 == 0 {
+			org.Fitness = float64(i + 1)
+			org.TimeAlive = conf.RealTime.MinEvaluationAge
+			evaluated[org] = true
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		baby, err := pop.ReproduceOne(conf.NeatContext(), i+1)
+		require.NoError(t, err)
+		require.NotNil(t, baby)
+	}
+	require.NotEmpty(t, selection.pools)
+	for _, pool := range selection.pools {
+		for _, org := range pool {
+			assert.True(t, evaluated[org], "not evaluated organism in the mating pool: %d", org.Genotype.Id)
+		}
+	}
+	// organisms of species restored
+	checkRealTimePopulation(t, pop, conf.PopSize+10)
+}
+
+func TestPopulation_ReproduceOne_resetInnovations(t *testing.T) {
+	pop, conf := buildTestRealTimePopulation(t)
+	for i, org := range pop.Organisms {
+		org.Fitness = float64(i + 1)
+		org.TimeAlive = conf.RealTime.MinEvaluationAge
+	}
+	pop.StoreInnovation(*NewInnovationForLink(1, 4, 100, 0.5, 1))
+
+	conf.KeepInnovations = true
+	_, err := pop.ReproduceOne(conf.NeatContext(), 1)
+	require.NoError(t, err)
+	_, found := pop.FindLinkInnovation(1, 4, false)
+	assert.True(t, found)
+
+	conf.KeepInnovations = false
+	_, err = pop.ReproduceOne(conf.NeatContext(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, pop.Innovations())
+}
+
+func TestPopulation_ReplaceWorst(t *testing.T) {
+	pop, conf := buildTestRealTimePopulation(t)
+	ctx := conf.NeatContext()
+	interval := conf.RealTime.ReplacementInterval(conf.PopSize)
+
+	replacements := 0
+	for tick := 1; tick <= 1000; tick++ {
+		// simulate continuous evaluation
+		for _, org := range pop.Organisms {
+			org.TimeAlive++
+			org.Fitness = float64(org.Genotype.Complexity())
+		}
+		if tick%interval != 0 {
+			continue
+		}
+		removed, offspring, err := pop.ReplaceWorst(ctx, tick)
+		require.NoError(t, err, "failed at tick: %d", tick)
+		if removed != nil {
+			require.NotNil(t, offspring)
+			assert.GreaterOrEqual(t, removed.TimeAlive, conf.RealTime.MinEvaluationAge)
+			replacements++
+		}
+		checkRealTimePopulation(t, pop, conf.PopSize)
+	}
+	assert.Greater(t, replacements, 0)
+}
+
+func TestPopulation_RealTime_noOptions(t *testing.T) {
+	pop, conf := buildTestRealTimePopulation(t)
+	conf.RealTime = nil
+
+	_, err := pop.RemoveWorst(conf.NeatContext())
+	assert.ErrorIs(t, err, ErrRealTimeOptionsNotFound)
+	_, err = pop.ReproduceOne(conf.NeatContext(), 1)
+	assert.ErrorIs(t, err, ErrRealTimeOptionsNotFound)
+
+	_, _, err = pop.ReplaceWorst(context.Background(), 1)
+	assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+}
+
+func TestPopulation_chooseParentSpecies(t *testing.T) {
+	pop := newPopulation()
+	for i := 0; i < 3; i++ {
+		org, err := NewOrganism(0, buildTestGenome(i+1), 1)
+		require.NoError(t, err)
+		createFirstSpecies(pop, org)
+	}
+	// only evaluated organisms count
+	pop.Species[1].Organisms[0].Fitness = 10
+	pop.Species[1].Organisms[0].TimeAlive = 5
+	pop.Species[2].Organisms[0].Fitness = 100
+	for i := 0; i < 10; i++ {
+		assert.Same(t, pop.Species[1], pop.chooseParentSpecies(5, neat.GlobalRand()))
+	}
+
+	// only species with evaluated organisms are chosen, even if none of them has positive fitness
+	pop.Species[1].Organisms[0].Fitness = 0
+	for i := 0; i < 10; i++ {
+		assert.Same(t, pop.Species[1], pop.chooseParentSpecies(5, neat.GlobalRand()))
+	}
+	pop.Species[1].Organisms[0].TimeAlive = 0
+	assert.Nil(t, pop.chooseParentSpecies(5, neat.GlobalRand()))
+}
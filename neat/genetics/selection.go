@@ -0,0 +1,142 @@
+package genetics
+
+import (
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	neatmath "github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// The default number of the tournament participants
+	defaultTournamentSize = 2
+	// The default selection pressure of the linear rank selection
+	defaultRankSelectionPressure = 1.5
+)
+
+// ParentSelectionStrategy The strategy to select parents for reproduction among species survivors
+type ParentSelectionStrategy interface {
+	// SelectParent Selects the parent organism from the provided non-empty pool of organisms using given random
+	// numbers generator
+	SelectParent(pool Organisms, rng *rand.Rand) *Organism
+}
+
+// NewParentSelectionStrategy Creates the parent selection strategy defined by the parent selection method of
+// provided options. If method is omitted, the uniform selection is used, or the crowded tournament selection if
+// multi-objective selection enabled.
+func NewParentSelectionStrategy(opts *neat.Options) (ParentSelectionStrategy, error) {
+	switch opts.ParentSelectionMethod {
+	case "":
+		if opts.MultiObjectiveSelection {
+			return &CrowdedTournamentParentSelection{}, nil
+		}
+		return &UniformParentSelection{}, nil
+	case neat.ParentSelectionMethodUniform:
+		return &UniformParentSelection{}, nil
+	case neat.ParentSelectionMethodTournament:
+		size := opts.TournamentSize
+		if size == 0 {
+			size = defaultTournamentSize
+		}
+		return &TournamentParentSelection{Size: size}, nil
+	case neat.ParentSelectionMethodRoulette:
+		return &RouletteParentSelection{}, nil
+	case neat.ParentSelectionMethodRank:
+		pressure := opts.RankSelectionPressure
+		if pressure == 0 {
+			pressure = defaultRankSelectionPressure
+		}
+		return &RankParentSelection{Pressure: pressure}, nil
+	default:
+		return nil, errors.Errorf("unsupported parent selection method: [%s]", opts.ParentSelectionMethod)
+	}
+}
+
+// UniformParentSelection The parent selection strategy that selects parents uniformly at random
+type UniformParentSelection struct{}
+
+func (u *UniformParentSelection) SelectParent(pool Organisms, rng *rand.Rand) *Organism {
+	orgNum := rng.Int31n(int32(len(pool)))
+	return pool[orgNum]
+}
+
+// TournamentParentSelection The parent selection strategy that draws random tournament participants and selects
+// the fittest among them
+type TournamentParentSelection struct {
+	// The number of tournament participants
+	Size int
+}
+
+func (t *TournamentParentSelection) SelectParent(pool Organisms, rng *rand.Rand) *Organism {
+	best := pool[rng.Intn(len(pool))]
+	for i := 1; i < t.Size; i++ {
+		if org := pool[rng.Intn(len(pool))]; org.Fitness > best.Fitness {
+			best = org
+		}
+	}
+	return best
+}
+
+// RouletteParentSelection The fitness proportional parent selection strategy. If there are negative fitness values,
+// all values are shifted so that the least fit organism gets zero share of the wheel. If the total fitness is not
+// positive, the parent is selected uniformly at random.
+type RouletteParentSelection struct{}
+
+func (r *RouletteParentSelection) SelectParent(pool Organisms, rng *rand.Rand) *Organism {
+	minFitness := 0.0
+	for _, org := range pool {
+		if org.Fitness < minFitness {
+			minFitness = org.Fitness
+		}
+	}
+	fitness := make([]float64, len(pool))
+	total := 0.0
+	for i, org := range pool {
+		fitness[i] = org.Fitness - minFitness
+		total += fitness[i]
+	}
+	if total > 0 {
+		if index := neatmath.SingleRouletteThrowRng(rng, fitness); index >= 0 {
+			return pool[index]
+		}
+	}
+	// all organisms have the same fitness or something went wrong, e.g. NaN fitness - fallback to uniform selection
+	return pool[rng.Intn(len(pool))]
+}
+
+// RankParentSelection The linear rank parent selection strategy. The probability of selection depends linearly
+// on the rank of organism by fitness, where the selection pressure defines the expected number of selections of
+// the fittest organism.
+type RankParentSelection struct {
+	// The selection pressure in range [1, 2]
+	Pressure float64
+}
+
+func (r *RankParentSelection) SelectParent(pool Organisms, rng *rand.Rand) *Organism {
+	n := len(pool)
+	if n == 1 {
+		return pool[0]
+	}
+	sorted := make(Organisms, n)
+	copy(sorted, pool)
+	sort.Stable(sort.Reverse(sorted))
+
+	probabilities := make([]float64, n)
+	for rank := range sorted {
+		// the fittest organism has zero rank
+		probabilities[rank] = (r.Pressure - 2.0*(r.Pressure-1.0)*float64(rank)/float64(n-1)) / float64(n)
+	}
+	if index := neatmath.SingleRouletteThrowRng(rng, probabilities); index >= 0 {
+		return sorted[index]
+	}
+	return sorted[0]
+}
+
+// CrowdedTournamentParentSelection The NSGA-II binary tournament parent selection strategy using the crowded-comparison
+// operator, i.e. Pareto rank and crowding distance of organisms
+type CrowdedTournamentParentSelection struct{}
+
+func (c *CrowdedTournamentParentSelection) SelectParent(pool Organisms, rng *rand.Rand) *Organism {
+	return crowdedTournament(pool, rng)
+}
@@ -0,0 +1,192 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"math/rand"
+	"testing"
+)
+
+func buildSelectionTestPool(fitness ...float64) Organisms {
+	pool := make(Organisms, len(fitness))
+	for i, f := range fitness {
+		pool[i] = &Organism{Fitness: f, Genotype: buildTestGenome(i + 1)}
+	}
+	return pool
+}
+
+// countSelections Returns the number of times each organism of the pool was selected
+func countSelections(strategy ParentSelectionStrategy, pool Organisms, trials int) []int {
+	counts := make([]int, len(pool))
+	for i := 0; i < trials; i++ {
+		selected := strategy.SelectParent(pool, neat.GlobalRand())
+		for j, org := range pool {
+			if org == selected {
+				counts[j]++
+			}
+		}
+	}
+	return counts
+}
+
+func TestNewParentSelectionStrategy(t *testing.T) {
+	testCases := []struct {
+		opts     *neat.Options
+		expected ParentSelectionStrategy
+	}{
+		{opts: &neat.Options{}, expected: &UniformParentSelection{}},
+		{opts: &neat.Options{MultiObjectiveSelection: true}, expected: &CrowdedTournamentParentSelection{}},
+		{opts: &neat.Options{ParentSelectionMethod: neat.ParentSelectionMethodUniform}, expected: &UniformParentSelection{}},
+		{opts: &neat.Options{ParentSelectionMethod: neat.ParentSelectionMethodTournament},
+			expected: &TournamentParentSelection{Size: defaultTournamentSize}},
+		{opts: &neat.Options{ParentSelectionMethod: neat.ParentSelectionMethodTournament, TournamentSize: 4},
+			expected: &TournamentParentSelection{Size: 4}},
+		{opts: &neat.Options{ParentSelectionMethod: neat.ParentSelectionMethodRoulette}, expected: &RouletteParentSelection{}},
+		{opts: &neat.Options{ParentSelectionMethod: neat.ParentSelectionMethodRank},
+			expected: &RankParentSelection{Pressure: defaultRankSelectionPressure}},
+		{opts: &neat.Options{ParentSelectionMethod: neat.ParentSelectionMethodRank, RankSelectionPressure: 2},
+			expected: &RankParentSelection{Pressure: 2}},
+	}
+	for _, tc := range testCases {
+		strategy, err := NewParentSelectionStrategy(tc.opts)
+		require.NoError(t, err, "failed for: %s", tc.opts.ParentSelectionMethod)
+		assert.Equal(t, tc.expected, strategy)
+	}
+
+	strategy, err := NewParentSelectionStrategy(&neat.Options{ParentSelectionMethod: "unknown"})
+	assert.Error(t, err)
+	assert.Nil(t, strategy)
+}
+
+func TestUniformParentSelection_SelectParent(t *testing.T) {
+	rand.Seed(42)
+	pool := buildSelectionTestPool(1, 10)
+	counts := countSelections(&UniformParentSelection{}, pool, 1000)
+	assert.InDelta(t, 500, counts[0], 50)
+	assert.InDelta(t, 500, counts[1], 50)
+}
+
+func TestTournamentParentSelection_SelectParent(t *testing.T) {
+	rand.Seed(42)
+	pool := buildSelectionTestPool(1, 10)
+	counts := countSelections(&TournamentParentSelection{Size: 2}, pool, 1000)
+	// the least fit wins only if drawn twice
+	assert.InDelta(t, 250, counts[0], 50)
+	assert.InDelta(t, 750, counts[1], 50)
+
+	// single participant tournament is uniform selection
+	counts = countSelections(&TournamentParentSelection{Size: 1}, pool, 1000)
+	assert.InDelta(t, 500, counts[0], 50)
+}
+
+func TestRouletteParentSelection_SelectParent(t *testing.T) {
+	rand.Seed(42)
+	pool := buildSelectionTestPool(1, 3)
+	counts := countSelections(&RouletteParentSelection{}, pool, 1000)
+	assert.InDelta(t, 250, counts[0], 50)
+	assert.InDelta(t, 750, counts[1], 50)
+
+	// zero fitness organism never selected
+	pool = buildSelectionTestPool(0, 3)
+	counts = countSelections(&RouletteParentSelection{}, pool, 100)
+	assert.Equal(t, 0, counts[0])
+}
+
+func TestRouletteParentSelection_SelectParent_zeroFitness(t *testing.T) {
+	rand.Seed(42)
+	// all organisms have zero fitness - uniform selection
+	pool := buildSelectionTestPool(0, 0, 0)
+	counts := countSelections(&RouletteParentSelection{}, pool, 3000)
+	for i := range counts {
+		assert.InDelta(t, 1000, counts[i], 100)
+	}
+}
+
+func TestRouletteParentSelection_SelectParent_negativeFitness(t *testing.T) {
+	rand.Seed(42)
+	// the fitness values are shifted to: 0, 1, 3
+	pool := buildSelectionTestPool(-2, -1, 1)
+	counts := countSelections(&RouletteParentSelection{}, pool, 1000)
+	assert.Equal(t, 0, counts[0])
+	assert.InDelta(t, 250, counts[1], 50)
+	assert.InDelta(t, 750, counts[2], 50)
+
+	// all negative values are the same - uniform selection
+	pool = buildSelectionTestPool(-1, -1)
+	counts = countSelections(&RouletteParentSelection{}, pool, 1000)
+	assert.InDelta(t, 500, counts[0], 50)
+	assert.InDelta(t, 500, counts[1], 50)
+}
+
+func TestRankParentSelection_SelectParent(t *testing.T) {
+	rand.Seed(42)
+	// the selection depends only on rank, not on fitness values
+	pool := buildSelectionTestPool(1, 1000, 2)
+	counts := countSelections(&RankParentSelection{Pressure: 2}, pool, 3000)
+	// probabilities: best - 2/3, middle - 1/3, worst - 0
+	assert.Equal(t, 0, counts[0])
+	assert.InDelta(t, 2000, counts[1], 100)
+	assert.InDelta(t, 1000, counts[2], 100)
+
+	// no selection pressure - uniform selection
+	counts = countSelections(&RankParentSelection{Pressure: 1}, pool, 3000)
+	for i := range counts {
+		assert.InDelta(t, 1000, counts[i], 100)
+	}
+
+	// single organism
+	assert.Equal(t, pool[0], (&RankParentSelection{Pressure: 2}).SelectParent(pool[:1], neat.GlobalRand()))
+}
+
+type testParentSelectionStrategy struct {
+	calls int
+}
+
+func (s *testParentSelectionStrategy) SelectParent(pool Organisms, _ *rand.Rand) *Organism {
+	s.calls++
+	return pool[0]
+}
+
+func TestPopulationEpochExecutor_NextEpoch_parentSelection(t *testing.T) {
+	methods := []neat.ParentSelectionMethod{
+		neat.ParentSelectionMethodTournament, neat.ParentSelectionMethodRoulette, neat.ParentSelectionMethodRank,
+	}
+	for _, method := range methods {
+		t.Run(string(method), func(t *testing.T) {
+			rand.Seed(42)
+			opts := speciationTestOptions()
+			opts.ParentSelectionMethod = method
+			gen, err := newGenomeRand(1, 3, 2, 3, 15, false, 0.8, opts, neat.GlobalRand())
+			require.NoError(t, err, "failed to create random genome")
+
+			pop, err := NewPopulation(gen, opts)
+			require.NoError(t, err, "failed to create population")
+
+			executors := []PopulationEpochExecutor{&SequentialPopulationEpochExecutor{}, &ParallelPopulationEpochExecutor{}}
+			for _, ex := range executors {
+				for i := 0; i < 10; i++ {
+					for _, org := range pop.Organisms {
+						org.Fitness = rand.Float64()
+					}
+					err = ex.NextEpoch(opts.NeatContext(), i+1, pop)
+					require.NoError(t, err, "failed at: %d epoch", i)
+				}
+			}
+		})
+	}
+
+	// custom strategy
+	rand.Seed(42)
+	opts := speciationTestOptions()
+	gen, err := newGenomeRand(1, 3, 2, 3, 15, false, 0.8, opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	pop, err := NewPopulation(gen, opts)
+	require.NoError(t, err, "failed to create population")
+	strategy := &testParentSelectionStrategy{}
+	pop.ParentSelection = strategy
+
+	err = (&SequentialPopulationEpochExecutor{}).NextEpoch(opts.NeatContext(), 1, pop)
+	require.NoError(t, err)
+	assert.True(t, strategy.calls > 0)
+}
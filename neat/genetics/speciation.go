@@ -0,0 +1,408 @@
+package genetics
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"math"
+	"sync"
+)
+
+// The default maximal number of k-medoids clustering iterations
+const defaultKMedoidsIterations = 10
+
+// ErrZeroCompatThreshold The error to be raised when compatibility threshold is zero and no compatible species can be found
+var ErrZeroCompatThreshold = errors.New("compatibility threshold is set to ZERO - will not find any compatible species")
+
+// SpeciationStrategy The strategy to assign organisms to the species of population
+type SpeciationStrategy interface {
+	// Speciate Assigns each of provided organisms to the species of population, creating new species if needed
+	Speciate(ctx context.Context, pop *Population, organisms []*Organism) error
+}
+
+// NewSpeciationStrategy Creates the speciation strategy implementing given speciation method
+func NewSpeciationStrategy(method neat.SpeciationMethod) (SpeciationStrategy, error) {
+	switch method {
+	case "", neat.SpeciationMethodFirstFit:
+		return &FirstFitSpeciation{}, nil
+	case neat.SpeciationMethodKMedoids:
+		return &KMedoidsSpeciation{}, nil
+	case neat.SpeciationMethodMedoidRepresentative:
+		return &MedoidRepresentativeSpeciation{}, nil
+	default:
+		return nil, errors.Errorf("unsupported speciation method: [%s]", method)
+	}
+}
+
+// FirstFitSpeciation The speciation strategy that assigns organism to the most compatible species comparing it with
+// the first organism of each species as a representative. If there is no species within compatibility threshold,
+// the new species is created.
+type FirstFitSpeciation struct{}
+
+func (f *FirstFitSpeciation) Speciate(ctx context.Context, pop *Population, organisms []*Organism) error {
+	return speciateByRepresentatives(ctx, pop, organisms, func(sp *Species) *Organism {
+		return sp.firstOrganism()
+	})
+}
+
+// MedoidRepresentativeSpeciation The speciation strategy that is the same as FirstFitSpeciation, but uses the medoid
+// of each species, i.e. the organism with minimal total compatibility distance to other species members, as its
+// representative.
+type MedoidRepresentativeSpeciation struct{}
+
+func (m *MedoidRepresentativeSpeciation) Speciate(ctx context.Context, pop *Population, organisms []*Organism) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	medoids := make(map[*Species]*Organism, len(pop.Species))
+	for _, sp := range pop.Species {
+		if len(sp.Organisms) > 0 {
+			cache := newCompatibilityCache(sp.Organisms, opts)
+			medoids[sp] = sp.Organisms[cache.medoid(allIndexes(len(sp.Organisms)))]
+		}
+	}
+	return speciateByRepresentatives(ctx, pop, organisms, func(sp *Species) *Organism {
+		if medoid, ok := medoids[sp]; ok {
+			return medoid
+		}
+		// the species was created during this speciation
+		return sp.firstOrganism()
+	})
+}
+
+// KMedoidsSpeciation The speciation strategy that clusters organisms by k-medoids using genome compatibility as
+// a distance. The initial clusters are formed by the first fit speciation against representatives of the existing
+// species, after that organisms are iteratively reassigned to the cluster with the nearest medoid until clusters are
+// stable or the maximal number of iterations reached.
+type KMedoidsSpeciation struct{}
+
+// kMedoidsCluster is the cluster of organisms formed by k-medoids speciation
+type kMedoidsCluster struct {
+	// The species of the cluster, nil if it should be created
+	species *Species
+	// The index of the medoid organism among organisms to be speciated, -1 if it's not defined yet
+	medoid int
+}
+
+func (k *KMedoidsSpeciation) Speciate(ctx context.Context, pop *Population, organisms []*Organism) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	threshold := pop.compatThreshold(opts)
+	if threshold == 0 && len(pop.Species) > 0 {
+		return ErrZeroCompatThreshold
+	}
+
+	cache := newCompatibilityCache(organisms, opts)
+
+	// initial assignment against representatives of the existing species, which can be compared in parallel
+	clusters := make([]*kMedoidsCluster, 0, len(pop.Species))
+	representatives := make([]*Organism, 0, len(pop.Species))
+	for _, sp := range pop.Species {
+		if rep := sp.firstOrganism(); rep != nil {
+			clusters = append(clusters, &kMedoidsCluster{species: sp, medoid: -1})
+			representatives = append(representatives, rep)
+		}
+	}
+	compatible, err := findCompatibleRepresentatives(ctx, organisms, representatives, threshold, opts)
+	if err != nil {
+		return err
+	}
+	existingCount := len(clusters)
+	assignment := make([]int, len(organisms))
+	for i := range organisms {
+		// check if context was canceled
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// compare with medoids of clusters started during this assignment
+		best, bestCompat := compatible[i].index, compatible[i].compat
+		for c := existingCount; c < len(clusters); c++ {
+			if compat := cache.compatibility(i, clusters[c].medoid); compat < threshold && compat < bestCompat {
+				best, bestCompat = c, compat
+			}
+		}
+		if best < 0 {
+			// start new cluster with this organism as medoid
+			clusters = append(clusters, &kMedoidsCluster{medoid: i})
+			best = len(clusters) - 1
+		}
+		assignment[i] = best
+	}
+
+	// iteratively update medoids and reassign organisms to the nearest medoid
+	iterations := opts.KMedoidsIterations
+	if iterations <= 0 {
+		iterations = defaultKMedoidsIterations
+	}
+	for it := 0; it < iterations; it++ {
+		members := make([][]int, len(clusters))
+		for i, c := range assignment {
+			members[c] = append(members[c], i)
+		}
+		for c, cluster := range clusters {
+			if len(members[c]) > 0 {
+				cluster.medoid = cache.medoid(members[c])
+			} else {
+				cluster.medoid = -1
+			}
+		}
+
+		changed := false
+		for i := range organisms {
+			best := assignment[i]
+			bestCompat := cache.compatibility(i, clusters[best].medoid)
+			for c, cluster := range clusters {
+				if cluster.medoid >= 0 {
+					if compat := cache.compatibility(i, cluster.medoid); compat < bestCompat {
+						best, bestCompat = c, compat
+					}
+				}
+			}
+			if best != assignment[i] {
+				assignment[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// assign organisms to species
+	for i, org := range organisms {
+		cluster := clusters[assignment[i]]
+		if cluster.species == nil {
+			createFirstSpecies(pop, org)
+			cluster.species = org.Species
+		} else {
+			cluster.species.addOrganism(org)
+			org.Species = cluster.species
+		}
+	}
+	return nil
+}
+
+// speciateByRepresentatives is to assign each organism to the most compatible species comparing it with
+// the representative of each species. If no species found within compatibility threshold, the new species is created.
+// The compatibility with representatives of the species existing before speciation is computed in parallel, if enabled
+// by the NEAT options, and the species created during speciation are checked sequentially in order of organisms.
+func speciateByRepresentatives(ctx context.Context, pop *Population, organisms []*Organism,
+	representative func(*Species) *Organism) error {
+	opts, found := neat.FromContext(ctx)
+	if !found {
+		return neat.ErrNEATOptionsNotFound
+	}
+	threshold := pop.compatThreshold(opts)
+	if threshold == 0 && len(pop.Species) > 0 {
+		return ErrZeroCompatThreshold
+	}
+
+	// the representatives of non-empty species existing before speciation are not changed by speciation
+	existingCount := len(pop.Species)
+	representatives := make([]*Organism, existingCount)
+	for i, sp := range pop.Species {
+		representatives[i] = representative(sp)
+	}
+	compatible, err := findCompatibleRepresentatives(ctx, organisms, representatives, threshold, opts)
+	if err != nil {
+		return err
+	}
+
+	// Step through all given organisms and speciate them within the population
+	for i, currOrg := range organisms {
+		// check if context was canceled
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if len(pop.Species) == 0 {
+			// Create the first species
+			createFirstSpecies(pop, currOrg)
+		} else {
+			if threshold == 0 {
+				return ErrZeroCompatThreshold
+			}
+			// For each organism, search for a species it is compatible to, starting with the one found among
+			// species existing before speciation
+			bestIndex, bestCompatValue := compatible[i].index, compatible[i].compat
+			for s, currSpecies := range pop.Species {
+				if s < existingCount && representatives[s] != nil {
+					// already compared
+					continue
+				}
+				compOrg := representative(currSpecies)
+				// compare current organism with representative of current species
+				if compOrg != nil {
+					currCompat := currOrg.Genotype.compatibility(compOrg.Genotype, opts)
+					if currCompat < threshold &&
+						(currCompat < bestCompatValue || currCompat == bestCompatValue && s < bestIndex) {
+						bestIndex = s
+						bestCompatValue = currCompat
+					}
+				}
+			}
+			if bestIndex >= 0 {
+				bestCompatible := pop.Species[bestIndex]
+				if neat.LogLevel == neat.LogLevelDebug {
+					neat.DebugLog(fmt.Sprintf("POPULATION: Compatible species [%d] found for baby organism [%d]",
+						bestCompatible.Id, currOrg.Genotype.Id))
+				}
+				// Found compatible species, so add current organism to it
+				bestCompatible.addOrganism(currOrg)
+				// Point organism to its species
+				currOrg.Species = bestCompatible
+			} else {
+				// If we didn't find a match, create a new species
+				createFirstSpecies(pop, currOrg)
+			}
+		}
+	}
+	return nil
+}
+
+// compatibleRepresentative is the most compatible representative found for an organism
+type compatibleRepresentative struct {
+	// The index of representative, -1 if there is no representative within compatibility threshold
+	index int
+	// The compatibility distance to the representative
+	compat float64
+}
+
+// findCompatibleRepresentatives is to find for each organism the most compatible representative within compatibility
+// threshold. The nil representatives are skipped. The compatibility is computed by the bounded pool of workers if
+// the number of speciation workers set in the NEAT options. The results don't depend on the scheduling of workers,
+// and ties are resolved in favor of the representative with lower index, the same way as sequential speciation does.
+func findCompatibleRepresentatives(ctx context.Context, organisms, representatives []*Organism, threshold float64, opts *neat.Options) ([]compatibleRepresentative, error) {
+	results := make([]compatibleRepresentative, len(organisms))
+	find := func(i int) {
+		best := compatibleRepresentative{index: -1, compat: math.MaxFloat64}
+		for r, rep := range representatives {
+			if rep == nil {
+				continue
+			}
+			compat := organisms[i].Genotype.compatibility(rep.Genotype, opts)
+			if compat < threshold && compat < best.compat {
+				best = compatibleRepresentative{index: r, compat: compat}
+			}
+		}
+		results[i] = best
+	}
+
+	workers := opts.SpeciationWorkers
+	if workers > len(organisms) {
+		workers = len(organisms)
+	}
+	if workers <= 1 || len(representatives) == 0 {
+		for i := range organisms {
+			// check if context was canceled
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			find(i)
+		}
+		return results, nil
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				find(i)
+			}
+		}()
+	}
+	var err error
+jobsLoop:
+	for i := range organisms {
+		// check if context was canceled before publishing the job, because select chooses between ready cases randomly
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break jobsLoop
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// compatibilityCache is the lazily computed symmetric matrix of compatibility distances between organisms
+type compatibilityCache struct {
+	organisms []*Organism
+	opts      *neat.Options
+	values    []float64
+	computed  []bool
+}
+
+// newCompatibilityCache Creates new compatibility cache for given organisms
+func newCompatibilityCache(organisms []*Organism, opts *neat.Options) *compatibilityCache {
+	n := len(organisms)
+	return &compatibilityCache{
+		organisms: organisms,
+		opts:      opts,
+		values:    make([]float64, n*n),
+		computed:  make([]bool, n*n),
+	}
+}
+
+// compatibility Returns the compatibility distance between organisms with given indexes
+func (c *compatibilityCache) compatibility(i, j int) float64 {
+	if i == j {
+		return 0
+	}
+	if i > j {
+		i, j = j, i
+	}
+	idx := i*len(c.organisms) + j
+	if !c.computed[idx] {
+		c.values[idx] = c.organisms[i].Genotype.compatibility(c.organisms[j].Genotype, c.opts)
+		c.computed[idx] = true
+	}
+	return c.values[idx]
+}
+
+// medoid Returns the index of the medoid among organisms with given indexes, i.e. the one with minimal total
+// compatibility distance to others
+func (c *compatibilityCache) medoid(indexes []int) int {
+	best, bestTotal := -1, math.MaxFloat64
+	for _, i := range indexes {
+		total := 0.0
+		for _, j := range indexes {
+			total += c.compatibility(i, j)
+		}
+		if total < bestTotal {
+			best, bestTotal = i, total
+		}
+	}
+	return best
+}
+
+// allIndexes Returns the list of indexes [0, n)
+func allIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
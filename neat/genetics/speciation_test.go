@@ -0,0 +1,281 @@
+package genetics
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
+	"testing"
+)
+
+func speciationTestOptions() *neat.Options {
+	return &neat.Options{
+		CompatThreshold:    0.5,
+		DisjointCoeff:      1.0,
+		ExcessCoeff:        1.0,
+		MutdiffCoeff:       0.4,
+		PopSize:            30,
+		DropOffAge:         1,
+		BabiesStolen:       10,
+		RecurOnlyProb:      0.2,
+		NodeActivators:     []math.NodeActivationType{math.GaussianBipolarActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+}
+
+// buildSpeciationTestOrganisms creates two groups of organisms with distinct genomes
+func buildSpeciationTestOrganisms(t *testing.T, opts *neat.Options) []*Organism {
+	rand.Seed(42)
+	other, err := newGenomeRand(100, 3, 2, 5, 5, false, 0.9, opts, neat.GlobalRand())
+	require.NoError(t, err, "failed to create random genome")
+	require.True(t, buildTestGenome(1).compatibility(other, opts) > opts.CompatThreshold)
+
+	organisms := make([]*Organism, 0)
+	for i := 0; i < 3; i++ {
+		gnome, err := buildTestGenome(1).duplicate(i + 1)
+		require.NoError(t, err)
+		org, err := NewOrganism(0, gnome, 1)
+		require.NoError(t, err)
+		organisms = append(organisms, org)
+
+		gnome, err = other.duplicate(i + 10)
+		require.NoError(t, err)
+		org, err = NewOrganism(0, gnome, 1)
+		require.NoError(t, err)
+		organisms = append(organisms, org)
+	}
+	return organisms
+}
+
+func checkSpeciatedGroups(t *testing.T, pop *Population, organisms []*Organism) {
+	require.Len(t, pop.Species, 2)
+	for i, org := range organisms {
+		require.NotNil(t, org.Species, "organism not speciated at: %d", i)
+		// the organisms at even indexes are from the first group
+		assert.Equal(t, organisms[i%2].Species, org.Species, "wrong species at: %d", i)
+	}
+	assert.NotEqual(t, organisms[0].Species, organisms[1].Species)
+	assert.Len(t, pop.Species[0].Organisms, 3)
+	assert.Len(t, pop.Species[1].Organisms, 3)
+}
+
+func TestNewSpeciationStrategy(t *testing.T) {
+	testCases := []struct {
+		method   neat.SpeciationMethod
+		expected SpeciationStrategy
+	}{
+		{method: "", expected: &FirstFitSpeciation{}},
+		{method: neat.SpeciationMethodFirstFit, expected: &FirstFitSpeciation{}},
+		{method: neat.SpeciationMethodKMedoids, expected: &KMedoidsSpeciation{}},
+		{method: neat.SpeciationMethodMedoidRepresentative, expected: &MedoidRepresentativeSpeciation{}},
+	}
+	for _, tc := range testCases {
+		strategy, err := NewSpeciationStrategy(tc.method)
+		require.NoError(t, err, "failed for: %s", tc.method)
+		assert.IsType(t, tc.expected, strategy)
+	}
+
+	strategy, err := NewSpeciationStrategy("unknown")
+	assert.Error(t, err)
+	assert.Nil(t, strategy)
+}
+
+func TestSpeciationStrategy_Speciate(t *testing.T) {
+	strategies := map[string]SpeciationStrategy{
+		"first fit":             &FirstFitSpeciation{},
+		"k-medoids":             &KMedoidsSpeciation{},
+		"medoid representative": &MedoidRepresentativeSpeciation{},
+	}
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			opts := speciationTestOptions()
+			organisms := buildSpeciationTestOrganisms(t, opts)
+			pop := newPopulation()
+
+			err := strategy.Speciate(opts.NeatContext(), pop, organisms)
+			require.NoError(t, err)
+			checkSpeciatedGroups(t, pop, organisms)
+
+			// speciate next generation against existing species
+			next := buildSpeciationTestOrganisms(t, opts)
+			err = strategy.Speciate(opts.NeatContext(), pop, next)
+			require.NoError(t, err)
+			require.Len(t, pop.Species, 2)
+			assert.Equal(t, organisms[0].Species, next[0].Species)
+			assert.Equal(t, organisms[1].Species, next[1].Species)
+		})
+	}
+}
+
+func TestSpeciationStrategy_Speciate_errors(t *testing.T) {
+	strategies := []SpeciationStrategy{&FirstFitSpeciation{}, &KMedoidsSpeciation{}, &MedoidRepresentativeSpeciation{}}
+	for _, strategy := range strategies {
+		opts := speciationTestOptions()
+		organisms := buildSpeciationTestOrganisms(t, opts)
+		pop := newPopulation()
+
+		err := strategy.Speciate(context.Background(), pop, organisms)
+		assert.ErrorIs(t, err, neat.ErrNEATOptionsNotFound)
+
+		createFirstSpecies(pop, organisms[0])
+		opts.CompatThreshold = 0
+		err = strategy.Speciate(opts.NeatContext(), pop, organisms[1:])
+		assert.ErrorIs(t, err, ErrZeroCompatThreshold)
+	}
+}
+
+func TestSpeciationStrategy_Speciate_parallel(t *testing.T) {
+	strategies := map[string]SpeciationStrategy{
+		"first fit":             &FirstFitSpeciation{},
+		"k-medoids":             &KMedoidsSpeciation{},
+		"medoid representative": &MedoidRepresentativeSpeciation{},
+	}
+	// speciate random organisms in two generations and returns the indexes of species of organisms
+	speciate := func(t *testing.T, strategy SpeciationStrategy, workers int) []int {
+		opts := speciationTestOptions()
+		opts.CompatThreshold = 3.0
+		opts.SpeciationWorkers = workers
+		rng := neat.NewRand(42)
+		pop := newPopulation()
+		speciesIndexes := make([]int, 0)
+		for generation := 0; generation < 2; generation++ {
+			organisms := make([]*Organism, 40)
+			for i := range organisms {
+				gnome, err := newGenomeRand(i+1, 3, 2, 3, 5, false, 0.5, opts, rng)
+				require.NoError(t, err, "failed to create random genome")
+				organisms[i], err = NewOrganism(0, gnome, 1)
+				require.NoError(t, err)
+			}
+			err := strategy.Speciate(opts.NeatContext(), pop, organisms)
+			require.NoError(t, err)
+
+			for _, org := range organisms {
+				index := -1
+				for s, sp := range pop.Species {
+					if sp == org.Species {
+						index = s
+					}
+				}
+				require.True(t, index >= 0, "organism not speciated")
+				speciesIndexes = append(speciesIndexes, index)
+			}
+		}
+		assert.True(t, len(pop.Species) > 1, "expected several species")
+		return speciesIndexes
+	}
+
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			sequential := speciate(t, strategy, 0)
+			parallel := speciate(t, strategy, 4)
+			assert.Equal(t, sequential, parallel)
+		})
+	}
+}
+
+func TestFindCompatibleRepresentatives_canceled(t *testing.T) {
+	opts := speciationTestOptions()
+	opts.SpeciationWorkers = 2
+	organisms := buildSpeciationTestOrganisms(t, opts)
+
+	ctx, cancel := context.WithCancel(opts.NeatContext())
+	cancel()
+	_, err := findCompatibleRepresentatives(ctx, organisms, organisms[:2], opts.CompatThreshold, opts)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCompatibilityCache_medoid(t *testing.T) {
+	opts := speciationTestOptions()
+	organisms := buildSpeciationTestOrganisms(t, opts)
+	cache := newCompatibilityCache(organisms, opts)
+
+	assert.Equal(t, 0.0, cache.compatibility(0, 2))
+	assert.Equal(t, cache.compatibility(0, 1), cache.compatibility(1, 0))
+
+	// the medoid is from the group with the most members
+	assert.Equal(t, 0, cache.medoid([]int{1, 0, 2, 4}))
+	assert.Equal(t, 1, cache.medoid([]int{0, 1, 3, 5}))
+	assert.Equal(t, []int{0, 1, 2}, allIndexes(3))
+}
+
+func TestMedoidRepresentativeSpeciation_Speciate(t *testing.T) {
+	opts := speciationTestOptions()
+	organisms := buildSpeciationTestOrganisms(t, opts)
+	pop := newPopulation()
+
+	// the species has one outlier as first organism and two organisms of the first group
+	opts.CompatThreshold = 100
+	createFirstSpecies(pop, organisms[1])
+	pop.Species[0].addOrganism(organisms[0])
+	pop.Species[0].addOrganism(organisms[2])
+
+	// the new organism is compatible with medoid, but not with the first organism
+	opts.CompatThreshold = 0.5
+	gnome, err := buildTestGenome(1).duplicate(20)
+	require.NoError(t, err)
+	org, err := NewOrganism(0, gnome, 1)
+	require.NoError(t, err)
+
+	err = (&MedoidRepresentativeSpeciation{}).Speciate(opts.NeatContext(), pop, []*Organism{org})
+	require.NoError(t, err)
+	require.Len(t, pop.Species, 1)
+	assert.Equal(t, pop.Species[0], org.Species)
+
+	// the first fit strategy creates new species
+	org.Species = nil
+	err = (&FirstFitSpeciation{}).Speciate(opts.NeatContext(), pop, []*Organism{org})
+	require.NoError(t, err)
+	assert.Len(t, pop.Species, 2)
+}
+
+type testSpeciationStrategy struct {
+	organisms []*Organism
+}
+
+func (s *testSpeciationStrategy) Speciate(_ context.Context, pop *Population, organisms []*Organism) error {
+	s.organisms = organisms
+	for _, org := range organisms {
+		createFirstSpecies(pop, org)
+	}
+	return nil
+}
+
+func TestPopulation_speciate_customStrategy(t *testing.T) {
+	opts := speciationTestOptions()
+	organisms := buildSpeciationTestOrganisms(t, opts)
+	pop := newPopulation()
+	strategy := &testSpeciationStrategy{}
+	pop.SpeciationStrategy = strategy
+
+	err := pop.speciate(opts.NeatContext(), organisms)
+	require.NoError(t, err)
+	assert.Equal(t, organisms, strategy.organisms)
+	assert.Len(t, pop.Species, len(organisms))
+}
+
+func TestPopulationEpochExecutor_NextEpoch_speciationMethods(t *testing.T) {
+	methods := []neat.SpeciationMethod{neat.SpeciationMethodKMedoids, neat.SpeciationMethodMedoidRepresentative}
+	for _, method := range methods {
+		t.Run(string(method), func(t *testing.T) {
+			rand.Seed(42)
+			opts := speciationTestOptions()
+			opts.SpeciationMethod = method
+			gen, err := newGenomeRand(1, 3, 2, 3, 15, false, 0.8, opts, neat.GlobalRand())
+			require.NoError(t, err, "failed to create random genome")
+
+			pop, err := NewPopulation(gen, opts)
+			require.NoError(t, err, "failed to create population")
+
+			executors := []PopulationEpochExecutor{&SequentialPopulationEpochExecutor{}, &ParallelPopulationEpochExecutor{}}
+			for _, ex := range executors {
+				for i := 0; i < 10; i++ {
+					err = ex.NextEpoch(opts.NeatContext(), i+1, pop)
+					require.NoError(t, err, "failed at: %d epoch", i)
+				}
+			}
+			assert.Len(t, pop.Organisms, opts.PopSize)
+		})
+	}
+}
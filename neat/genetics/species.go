@@ -7,7 +7,6 @@ import (
 	"github.com/yaricom/goNEAT/v4/neat"
 	"io"
 	"math"
-	"math/rand"
 	"sort"
 )
 
@@ -124,6 +123,8 @@ func (s *Species) adjustFitness(opts *neat.Options) {
 	for _, org := range s.Organisms {
 		// Remember the original fitness before it gets modified
 		org.originalFitness = org.Fitness
+		// Share the fitness driving the selection, which may differ from the objective one
+		org.Fitness = org.selectionFitness(opts)
 
 		// Make fitness decrease after a stagnation point dropoff_age
 		// Added as if to keep species pristine until the dropoff point
@@ -150,10 +151,11 @@ func (s *Species) adjustFitness(opts *neat.Options) {
 	// Sort the population (most fit first) and mark for death those after : survival_thresh * pop_size
 	sort.Sort(sort.Reverse(s.Organisms))
 
-	// Update age_of_last_improvement here
-	if s.Organisms[0].originalFitness > s.MaxFitnessEver {
+	// Update age_of_last_improvement here. The first organism is not necessarily the best by the original fitness,
+	// if it differs from the fitness driving the selection.
+	if best := s.maxOriginalFitness(); best > s.MaxFitnessEver {
 		s.AgeOfLastImprovement = s.Age
-		s.MaxFitnessEver = s.Organisms[0].originalFitness
+		s.MaxFitnessEver = best
 	}
 
 	// Decide how many get to reproduce based on survival_thresh * pop_size
@@ -167,6 +169,15 @@ func (s *Species) adjustFitness(opts *neat.Options) {
 	}
 }
 
+// maxOriginalFitness Returns the maximal original fitness among organisms of this species
+func (s *Species) maxOriginalFitness() float64 {
+	best := s.Organisms[0].originalFitness
+	for _, org := range s.Organisms[1:] {
+		best = math.Max(best, org.originalFitness)
+	}
+	return best
+}
+
 // ComputeMaxAndAvgFitness Computes maximal and average fitness of species
 func (s *Species) ComputeMaxAndAvgFitness() (max, avg float64) {
 	total := 0.0
@@ -249,17 +260,37 @@ func (s *Species) findChampion() *Organism {
 }
 
 // Perform mating and mutation to form next generation. The sorted_species is ordered to have best species in the beginning.
-// Returns list of baby organisms as a result of reproduction of all organisms in this species.
-func (s *Species) reproduce(ctx context.Context, generation int, pop *Population, sortedSpecies []*Species) ([]*Organism, error) {
+// The new innovations and node IDs are tracked by provided innovations tracker and the random numbers generator is
+// taken from the context. Returns list of baby organisms as a result of reproduction of all organisms in this species.
+func (s *Species) reproduce(ctx context.Context, generation int, pop *Population, sortedSpecies []*Species, innovations innovationsTracker) ([]*Organism, error) {
 	opts, found := neat.FromContext(ctx)
 	if !found {
 		return nil, neat.ErrNEATOptionsNotFound
 	}
+	rng := neat.RandFromContext(ctx)
 	//Check for a mistake
 	if s.ExpectedOffspring > 0 && len(s.Organisms) == 0 {
 		return nil, errors.New("attempt to reproduce out of empty species")
 	}
 
+	// The strategy to select parents among organisms
+	selector, err := pop.parentSelectionStrategy(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The registry of mutations to apply to the babies
+	mutations := pop.mutationRegistry()
+	// During the simplifying phase of the phased search the babies are produced only by mutations
+	simplifying := pop.SearchPhase == SimplifyingPhase
+	mutationEnv := &MutationEnvironment{
+		Innovations:     innovations,
+		NodeIdGenerator: innovations,
+		Generation:      generation,
+		Options:         opts,
+		Rand:            rng,
+	}
+
 	// The number of Organisms in the old generation
 	poolSize := len(s.Organisms)
 	// The champion of the 'this' specie is the first element of the specie;
@@ -308,14 +339,14 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 			// Note: Superchamp offspring only occur with stolen babies!
 			//      Settings used for published experiments did not use this
 			if theChamp.superChampOffspring > 1 {
-				if rand.Float64() < 0.8 || opts.MutateAddLinkProb == 0.0 {
+				if simplifying || rng.Float64() < 0.8 || opts.MutateAddLinkProb == 0.0 {
 					// Make sure no links get added when the system has link adding disabled
-					if _, err = newGenome.mutateLinkWeights(opts.WeightMutPower, 1.0, gaussianMutator); err != nil {
+					if _, err = newGenome.perturbLinkWeights(opts.WeightMutPower, opts.WeightMutPowerAdaptive, 1.0, gaussianMutator, rng); err != nil {
 						return nil, err
 					}
 				} else {
 					// Sometimes we add a link to a superchamp
-					if _, err = newGenome.mutateAddLink(pop, generation, opts); err != nil {
+					if _, err = newGenome.mutateAddLink(innovations, generation, opts, rng); err != nil {
 						return nil, err
 					}
 					mutStructBaby = true
@@ -354,49 +385,19 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 				return nil, err
 			}
 
-		} else if rand.Float64() < opts.MutateOnlyProb || poolSize == 1 {
+		} else if simplifying || rng.Float64() < opts.MutateOnlyProb || poolSize == 1 {
 			neat.DebugLog("SPECIES: Reproduce by applying random mutation:")
 
 			// Apply mutations
-			orgNum := rand.Int31n(int32(poolSize)) // select random mom
-			mom := s.Organisms[orgNum]
+			mom := selector.SelectParent(s.Organisms, rng) // select random mom
 			newGenome, err := mom.Genotype.duplicate(count)
 			if err != nil {
 				return nil, err
 			}
 
 			// Do the mutation depending on probabilities of various mutations
-			if rand.Float64() < opts.MutateAddNodeProb {
-				neat.DebugLog("SPECIES: ---> mutateAddNode")
-
-				// Mutate add node
-				if _, err = newGenome.mutateAddNode(pop, pop, opts); err != nil {
-					return nil, err
-				}
-				mutStructBaby = true
-			} else if rand.Float64() < opts.MutateAddLinkProb {
-				neat.DebugLog("SPECIES: ---> mutateAddLink")
-
-				if _, err = newGenome.mutateAddLink(pop, generation, opts); err != nil {
-					return nil, err
-				}
-				mutStructBaby = true
-			} else if rand.Float64() < opts.MutateConnectSensors {
-				neat.DebugLog("SPECIES: ---> mutateConnectSensors")
-				if linkAdded, err := newGenome.mutateConnectSensors(pop, opts); err != nil {
-					return nil, err
-				} else {
-					mutStructBaby = linkAdded
-				}
-			}
-
-			if !mutStructBaby {
-				neat.DebugLog("SPECIES: ---> mutateAllNonstructural")
-
-				// If we didn't do a structural mutation, we do the other kinds
-				if _, err = newGenome.mutateAllNonstructural(opts); err != nil {
-					return nil, err
-				}
+			if mutStructBaby, err = mutations.Mutate(newGenome, mutationEnv); err != nil {
+				return nil, err
 			}
 
 			// Create the new baby organism
@@ -408,17 +409,15 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 			neat.DebugLog("SPECIES: Reproduce by mating:")
 
 			// Otherwise we should mate
-			orgNum := rand.Int31n(int32(poolSize)) // select random mom
-			mom := s.Organisms[orgNum]
+			mom := selector.SelectParent(s.Organisms, rng) // select random mom
 
 			// Choose random dad
 			var dad *Organism
-			if rand.Float64() > opts.InterspeciesMateRate {
+			if rng.Float64() > opts.InterspeciesMateRate {
 				neat.DebugLog("SPECIES: ---> mate within species")
 
 				// Mate within Species
-				orgNum = rand.Int31n(int32(poolSize))
-				dad = s.Organisms[orgNum]
+				dad = selector.SelectParent(s.Organisms, rng)
 			} else {
 				neat.DebugLog("SPECIES: ---> mate outside species")
 
@@ -429,7 +428,7 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 				giveup := 0
 				for randSpecies.Id == s.Id && giveup < 5 {
 					// Choose a random species tending towards better species
-					randMult := rand.Float64() / 4.0
+					randMult := rng.Float64() / 4.0
 					// This tends to select better species
 					randSpeciesNum := int(math.Floor(randMult * float64(len(sortedSpecies))))
 					randSpecies = sortedSpecies[randSpeciesNum]
@@ -442,26 +441,26 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 			// Perform mating based on probabilities of different mating types
 			var newGenome *Genome
 			var err error
-			if rand.Float64() < opts.MateMultipointProb {
+			if rng.Float64() < opts.MateMultipointProb {
 				neat.DebugLog("SPECIES: ------> mateMultipoint")
 
 				// mate multipoint baby
-				newGenome, err = mom.Genotype.mateMultipoint(dad.Genotype, count, mom.originalFitness, dad.originalFitness)
+				newGenome, err = mom.Genotype.mateMultipoint(dad.Genotype, count, mom.originalFitness, dad.originalFitness, rng)
 				if err != nil {
 					return nil, err
 				}
-			} else if rand.Float64() < opts.MateMultipointAvgProb/(opts.MateMultipointAvgProb+opts.MateSinglepointProb) {
+			} else if rng.Float64() < opts.MateMultipointAvgProb/(opts.MateMultipointAvgProb+opts.MateSinglepointProb) {
 				neat.DebugLog("SPECIES: ------> mateMultipointAvg")
 
 				// mate multipoint_avg baby
-				newGenome, err = mom.Genotype.mateMultipointAvg(dad.Genotype, count, mom.originalFitness, dad.originalFitness)
+				newGenome, err = mom.Genotype.mateMultipointAvg(dad.Genotype, count, mom.originalFitness, dad.originalFitness, rng)
 				if err != nil {
 					return nil, err
 				}
 			} else {
 				neat.DebugLog("SPECIES: ------> mateSinglePoint")
 
-				newGenome, err = mom.Genotype.mateSinglePoint(dad.Genotype, count)
+				newGenome, err = mom.Genotype.mateSinglePoint(dad.Genotype, count, rng)
 				if err != nil {
 					return nil, err
 				}
@@ -471,41 +470,14 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 
 			// Determine whether to mutate the baby's Genome
 			// This is done randomly or if the mom and dad are the same organism
-			if rand.Float64() > opts.MateOnlyProb ||
+			if rng.Float64() > opts.MateOnlyProb ||
 				dad.Genotype.Id == mom.Genotype.Id ||
 				dad.Genotype.compatibility(mom.Genotype, opts) == 0.0 {
 				neat.DebugLog("SPECIES: ------> Mutate baby genome:")
 
-				// Do the mutation depending on probabilities of  various mutations
-				if rand.Float64() < opts.MutateAddNodeProb {
-					neat.DebugLog("SPECIES: ---------> mutateAddNode")
-
-					// mutate_add_node
-					if _, err = newGenome.mutateAddNode(pop, pop, opts); err != nil {
-						return nil, err
-					}
-					mutStructBaby = true
-				} else if rand.Float64() < opts.MutateAddLinkProb {
-					neat.DebugLog("SPECIES: ---------> mutateAddLink")
-
-					if _, err = newGenome.mutateAddLink(pop, generation, opts); err != nil {
-						return nil, err
-					}
-					mutStructBaby = true
-				} else if rand.Float64() < opts.MutateConnectSensors {
-					neat.DebugLog("SPECIES: ---> mutateConnectSensors")
-					if mutStructBaby, err = newGenome.mutateConnectSensors(pop, opts); err != nil {
-						return nil, err
-					}
-				}
-
-				if !mutStructBaby {
-					neat.DebugLog("SPECIES: ---> mutateAllNonstructural")
-
-					// If we didn't do a structural mutation, we do the other kinds
-					if _, err = newGenome.mutateAllNonstructural(opts); err != nil {
-						return nil, err
-					}
+				// Do the mutation depending on probabilities of various mutations
+				if mutStructBaby, err = mutations.Mutate(newGenome, mutationEnv); err != nil {
+					return nil, err
 				}
 			}
 			// Create the new baby organism
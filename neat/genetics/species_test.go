@@ -116,6 +116,29 @@ func TestSpecies_adjustFitness(t *testing.T) {
 	assert.True(t, sp.Organisms[2].toEliminate)
 }
 
+func TestSpecies_adjustFitness_multiObjective(t *testing.T) {
+	sp, err := buildSpeciesWithOrganisms(1)
+	require.NoError(t, err, "failed to build species")
+	// the Pareto fitness orders organisms opposite to their objective fitness
+	for i, o := range sp.Organisms {
+		o.ParetoFitness = float64(3 - i)
+	}
+
+	conf := neat.Options{
+		DropOffAge:              5,
+		SurvivalThresh:          0.5,
+		AgeSignificance:         1,
+		MultiObjectiveSelection: true,
+	}
+	sp.adjustFitness(&conf)
+
+	// the selection follows the Pareto fitness, while the progress is tracked by the objective fitness
+	assert.Equal(t, 3.0/3.0, sp.Organisms[0].Fitness)
+	assert.Equal(t, 5.0, sp.Organisms[0].originalFitness)
+	assert.Equal(t, 15.0, sp.MaxFitnessEver)
+	assert.Equal(t, 1, sp.AgeOfLastImprovement)
+}
+
 // Tests Species countOffspring
 func TestSpecies_countOffspring(t *testing.T) {
 	sp, err := buildSpeciesWithOrganisms(1)
@@ -198,7 +221,7 @@ func TestSpecies_reproduce_fail(t *testing.T) {
 
 	opts := neat.Options{}
 
-	babies, err := sp.reproduce(opts.NeatContext(), 1, nil, nil)
+	babies, err := sp.reproduce(opts.NeatContext(), 1, nil, nil, nil)
 	assert.Empty(t, babies, "no offsprings expected")
 	assert.EqualError(t, err, "attempt to reproduce out of empty species")
 }
@@ -223,7 +246,7 @@ func TestSpecies_reproduce(t *testing.T) {
 	}
 	neat.LogLevel = neat.LogLevelInfo
 
-	gen, err := newGenomeRand(1, in, out, n, maxHidden, false, linkProb, opts)
+	gen, err := newGenomeRand(1, in, out, n, maxHidden, false, linkProb, opts, neat.GlobalRand())
 	require.NoError(t, err, "failed to create random genome")
 
 	pop, err := NewPopulation(gen, opts)
@@ -239,7 +262,7 @@ func TestSpecies_reproduce(t *testing.T) {
 
 	pop.Species[0].ExpectedOffspring = 11
 
-	babies, err := pop.Species[0].reproduce(opts.NeatContext(), 1, pop, sortedSpecies)
+	babies, err := pop.Species[0].reproduce(opts.NeatContext(), 1, pop, sortedSpecies, pop)
 	require.NoError(t, err, "failed to reproduce")
 	require.NotEmpty(t, babies, "offsprings expected")
 
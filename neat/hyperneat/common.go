@@ -0,0 +1,50 @@
+// Package hyperneat implements the HyperNEAT indirect encoding, where the evolved genome is treated as
+// Compositional Pattern Producing Network (CPPN), which is queried over the geometry of the substrate nodes to
+// produce connectivity pattern of the large phenotype network.
+package hyperneat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrEmptySubstrateInputs The error to be raised when substrate layout has no input nodes
+	ErrEmptySubstrateInputs = errors.New("substrate layout has no input nodes")
+	// ErrEmptySubstrateOutputs The error to be raised when substrate layout has no output nodes
+	ErrEmptySubstrateOutputs = errors.New("substrate layout has no output nodes")
+	// ErrHyperNEATOptionsNotFound The error to be raised when HyperNEAT options are missing in the NEAT options
+	ErrHyperNEATOptionsNotFound = errors.New("HyperNEAT options not found in the NEAT options")
+	// ErrESHyperNEATOptionsNotFound The error to be raised when ES-HyperNEAT options are missing in the HyperNEAT options
+	ErrESHyperNEATOptionsNotFound = errors.New("ES-HyperNEAT options not found in the HyperNEAT options")
+)
+
+// PointF is the point with floating point coordinates at the substrate plane
+type PointF struct {
+	X float64 `yaml:"x"`
+	Y float64 `yaml:"y"`
+}
+
+// NewPointF Creates new point with given coordinates
+func NewPointF(x, y float64) PointF {
+	return PointF{X: x, Y: y}
+}
+
+func (p PointF) String() string {
+	return fmt.Sprintf("(%f, %f)", p.X, p.Y)
+}
+
+// scaleWeight is to scale the CPPN output into the substrate link weight. Returns false if the magnitude of the
+// CPPN output is below the link expression threshold.
+func scaleWeight(cppnOutput, linkThreshold, weightRange float64) (float64, bool) {
+	magnitude := math.Min(math.Abs(cppnOutput), 1.0)
+	if magnitude <= linkThreshold {
+		return 0, false
+	}
+	weight := (magnitude - linkThreshold) / (1.0 - linkThreshold) * weightRange
+	if cppnOutput < 0 {
+		weight = -weight
+	}
+	return weight, true
+}
@@ -0,0 +1,60 @@
+package hyperneat
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"strings"
+	"testing"
+)
+
+// The CPPN genome with four coordinate inputs, bias and single linear output, which produces: 0.5 * x1 + 0.5 * x2
+const cppnGenomeStr = "genomestart 1\n" +
+	"trait 1 0.1 0 0 0 0 0 0 0\n" +
+	"node 1 0 1 1 NullActivation\n" + // x1
+	"node 2 0 1 1 NullActivation\n" + // y1
+	"node 3 0 1 1 NullActivation\n" + // x2
+	"node 4 0 1 1 NullActivation\n" + // y2
+	"node 5 0 1 3 NullActivation\n" + // BIAS
+	"node 6 0 0 2 LinearActivation\n" + // OUTPUT
+	"gene 1 1 6 0.5 false 1 0 true\n" +
+	"gene 1 3 6 0.5 false 2 0 true\n" +
+	"genomeend 1"
+
+func buildTestCPPNOrganism() (*genetics.Organism, error) {
+	r, err := genetics.NewGenomeReader(strings.NewReader(cppnGenomeStr), genetics.PlainGenomeEncoding)
+	if err != nil {
+		return nil, err
+	}
+	gnome, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	return genetics.NewOrganism(0, gnome, 1)
+}
+
+func TestScaleWeight(t *testing.T) {
+	testCases := []struct {
+		name      string
+		output    float64
+		weight    float64
+		expressed bool
+	}{
+		{name: "below threshold", output: 0.1, weight: 0, expressed: false},
+		{name: "at threshold", output: -0.2, weight: 0, expressed: false},
+		{name: "positive", output: 0.6, weight: 1.5, expressed: true},
+		{name: "negative", output: -0.6, weight: -1.5, expressed: true},
+		{name: "clamped", output: 5.0, weight: 3.0, expressed: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			weight, expressed := scaleWeight(tc.output, 0.2, 3.0)
+			assert.Equal(t, tc.expressed, expressed)
+			assert.InDelta(t, tc.weight, weight, 1e-9)
+		})
+	}
+}
+
+func TestPointF_String(t *testing.T) {
+	p := NewPointF(0.5, -1)
+	assert.Equal(t, "(0.500000, -1.000000)", p.String())
+}
@@ -0,0 +1,39 @@
+package hyperneat
+
+import (
+	"errors"
+	"github.com/yaricom/goNEAT/v4/neat/genetics"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+)
+
+// NewCPPN Creates the solver of the Compositional Pattern Producing Network (CPPN) from the phenotype of the
+// provided organism. The CPPN is expected to have four inputs (x1, y1, x2, y2) and optional bias. The fast network
+// solver is built unless the phenotype has plastic link weights, which are supported only by the phenotype itself.
+func NewCPPN(org *genetics.Organism) (network.Solver, error) {
+	phenotype, err := org.Phenotype()
+	if err != nil {
+		return nil, err
+	}
+	if phenotype.PlasticityRule != "" {
+		return phenotype, nil
+	}
+	return phenotype.FastNetworkSolver()
+}
+
+// QueryCPPN Queries the CPPN with coordinates of the source and the target substrate points and returns the CPPN
+// outputs.
+func QueryCPPN(cppn network.Solver, source, target PointF) ([]float64, error) {
+	if _, err := cppn.Flush(); err != nil {
+		return nil, err
+	}
+	coordinates := []float64{source.X, source.Y, target.X, target.Y}
+	if err := cppn.LoadSensors(coordinates); err != nil {
+		return nil, err
+	}
+	if res, err := cppn.RecursiveSteps(); err != nil {
+		return nil, err
+	} else if !res {
+		return nil, errors.New("failed to activate CPPN")
+	}
+	return cppn.ReadOutputs(), nil
+}
@@ -0,0 +1,48 @@
+package hyperneat
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"testing"
+)
+
+func TestQueryCPPN(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	testCases := []struct {
+		source, target PointF
+		expected       float64
+	}{
+		{source: NewPointF(-1, -1), target: NewPointF(0, 1), expected: -0.5},
+		{source: NewPointF(1, -1), target: NewPointF(0, 1), expected: 0.5},
+		{source: NewPointF(1, 0.5), target: NewPointF(1, -1), expected: 1.0},
+	}
+	for _, tc := range testCases {
+		outputs, err := QueryCPPN(cppn, tc.source, tc.target)
+		require.NoError(t, err, "failed to query CPPN")
+		require.Len(t, outputs, 1)
+		assert.InDelta(t, tc.expected, outputs[0], 1e-9, "wrong output for: %s -> %s", tc.source, tc.target)
+	}
+}
+
+func TestNewCPPN_plasticity(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	phenotype, err := org.Phenotype()
+	require.NoError(t, err, "failed to create phenotype")
+	phenotype.PlasticityRule = neat.PlasticityRuleHebbian
+
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+	assert.IsType(t, &network.Network{}, cppn)
+
+	outputs, err := QueryCPPN(cppn, NewPointF(1, -1), NewPointF(0, 1))
+	require.NoError(t, err, "failed to query CPPN")
+	require.Len(t, outputs, 1)
+	assert.InDelta(t, 0.5, outputs[0], 1e-9)
+}
@@ -0,0 +1,374 @@
+package hyperneat
+
+import (
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"math"
+)
+
+// EvolvableSubstrate is the substrate of the Evolvable-Substrate HyperNEAT (ES-HyperNEAT). Only the input and output
+// nodes of the substrate are defined by the layout, while positions of the hidden nodes are discovered by the
+// quadtree information extraction from the connectivity pattern produced by the CPPN. The hidden layers of the layout
+// are ignored.
+type EvolvableSubstrate struct {
+	// The layout of the substrate input and output nodes
+	Layout *SubstrateLayout
+}
+
+// NewEvolvableSubstrate Creates new evolvable substrate with given layout of the input and output nodes
+func NewEvolvableSubstrate(layout *SubstrateLayout) *EvolvableSubstrate {
+	return &EvolvableSubstrate{Layout: layout}
+}
+
+// substrateConnection is the connection between two substrate points discovered by the quadtree information extraction
+type substrateConnection struct {
+	source PointF
+	target PointF
+	weight float64
+}
+
+// quadPoint is the node of the quadtree, which samples the CPPN output at its center
+type quadPoint struct {
+	x, y     float64
+	width    float64
+	level    int
+	weight   float64
+	children []*quadPoint
+}
+
+// newQuadPoint Creates new quadtree node at given center with given half width and level
+func newQuadPoint(x, y, width float64, level int) *quadPoint {
+	return &quadPoint{x: x, y: y, width: width, level: level}
+}
+
+// variance Returns the variance of the CPPN outputs sampled at the leaves of this quadtree node
+func (q *quadPoint) variance() float64 {
+	if len(q.children) == 0 {
+		return 0
+	}
+	weights := q.leafWeights(nil)
+	mean := 0.0
+	for _, w := range weights {
+		mean += w
+	}
+	mean /= float64(len(weights))
+	variance := 0.0
+	for _, w := range weights {
+		variance += (w - mean) * (w - mean)
+	}
+	return variance / float64(len(weights))
+}
+
+// leafWeights is to collect the CPPN outputs sampled at the leaves of this quadtree node
+func (q *quadPoint) leafWeights(weights []float64) []float64 {
+	if len(q.children) == 0 {
+		return append(weights, q.weight)
+	}
+	for _, c := range q.children {
+		weights = c.leafWeights(weights)
+	}
+	return weights
+}
+
+// CreateNetwork Creates the phenotype network by discovering the hidden nodes and connections of this substrate from
+// the connectivity pattern of provided CPPN. The hidden nodes are discovered from the input nodes, then iteratively
+// from the discovered hidden nodes up to the iteration level, and finally connected to the output nodes. The hidden
+// nodes without path from the inputs and to the outputs are removed from the resulting network.
+func (s *EvolvableSubstrate) CreateNetwork(cppn network.Solver, opts *neat.HyperNEATOptions) (*network.Network, error) {
+	if opts == nil {
+		return nil, ErrHyperNEATOptionsNotFound
+	}
+	if opts.ESHyperNEAT == nil {
+		return nil, ErrESHyperNEATOptionsNotFound
+	}
+	if err := s.Layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	inputs := s.Layout.Inputs
+	if s.Layout.Bias != nil {
+		inputs = append(inputs[:len(inputs):len(inputs)], *s.Layout.Bias)
+	}
+	reserved := make(map[PointF]bool)
+	for _, p := range inputs {
+		reserved[p] = true
+	}
+	for _, p := range s.Layout.Outputs {
+		reserved[p] = true
+	}
+
+	hidden := make([]PointF, 0)
+	hiddenSet := make(map[PointF]bool)
+	connections := make([]substrateConnection, 0)
+	connectionsSet := make(map[[2]PointF]bool)
+	addConnection := func(c substrateConnection) {
+		key := [2]PointF{c.source, c.target}
+		if !connectionsSet[key] {
+			connectionsSet[key] = true
+			connections = append(connections, c)
+		}
+	}
+	// discovers outgoing connections and new hidden nodes from given source points
+	explore := func(sources []PointF) ([]PointF, error) {
+		discovered := make([]PointF, 0)
+		for _, source := range sources {
+			conns, err := findConnections(cppn, source, true, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range conns {
+				if reserved[c.target] {
+					continue
+				}
+				if !hiddenSet[c.target] {
+					hiddenSet[c.target] = true
+					hidden = append(hidden, c.target)
+					discovered = append(discovered, c.target)
+				}
+				addConnection(c)
+			}
+		}
+		return discovered, nil
+	}
+
+	// input to hidden nodes
+	unexplored, err := explore(inputs)
+	if err != nil {
+		return nil, err
+	}
+	// hidden to hidden nodes
+	for i := 0; i < opts.ESHyperNEAT.IterationLevel && len(unexplored) > 0; i++ {
+		if unexplored, err = explore(unexplored); err != nil {
+			return nil, err
+		}
+	}
+	// hidden to output nodes
+	for _, target := range s.Layout.Outputs {
+		conns, err := findConnections(cppn, target, false, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range conns {
+			if hiddenSet[c.source] {
+				addConnection(c)
+			}
+		}
+	}
+
+	// remove hidden nodes not contributing to the outputs
+	hidden, connections = pruneHiddenNodes(inputs, s.Layout.Outputs, hidden, connections)
+
+	return s.buildNetwork(hidden, connections, opts), nil
+}
+
+// CreateNetworkSolver Creates the fast network solver of the phenotype network produced by discovering the hidden
+// nodes and connections of this substrate from the connectivity pattern of provided CPPN. See CreateNetwork for details.
+func (s *EvolvableSubstrate) CreateNetworkSolver(cppn network.Solver, opts *neat.HyperNEATOptions) (network.Solver, error) {
+	net, err := s.CreateNetwork(cppn, opts)
+	if err != nil {
+		return nil, err
+	}
+	return net.FastNetworkSolver()
+}
+
+// findConnections is to find connections of the given substrate point by the quadtree information extraction. If
+// outgoing is true, the connections from the point will be returned, otherwise the connections to the point.
+func findConnections(cppn network.Solver, point PointF, outgoing bool,
+	opts *neat.HyperNEATOptions) ([]substrateConnection, error) {
+	root, err := divideAndInitialize(cppn, point, outgoing, opts.ESHyperNEAT)
+	if err != nil {
+		return nil, err
+	}
+	connections := make([]substrateConnection, 0)
+	if err = pruneAndExtract(cppn, point, root, outgoing, opts, &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// buildNetwork is to build the phenotype network from the discovered hidden nodes and connections. The node IDs are
+// assigned in order: inputs, bias, outputs, hidden.
+func (s *EvolvableSubstrate) buildNetwork(hidden []PointF, connections []substrateConnection,
+	opts *neat.HyperNEATOptions) *network.Network {
+	nodeId := 0
+	nextNodeId := func() int {
+		nodeId++
+		return nodeId
+	}
+
+	nodes := make(map[PointF]*network.NNode)
+	inList := make([]*network.NNode, 0, len(s.Layout.Inputs)+1)
+	for _, p := range s.Layout.Inputs {
+		node := network.NewSensorNode(nextNodeId(), false)
+		nodes[p] = node
+		inList = append(inList, node)
+	}
+	if s.Layout.Bias != nil {
+		node := network.NewSensorNode(nextNodeId(), true)
+		nodes[*s.Layout.Bias] = node
+		inList = append(inList, node)
+	}
+	outList := createNeurons(s.Layout.Outputs, network.OutputNeuron, opts, nextNodeId)
+	for i, p := range s.Layout.Outputs {
+		nodes[p] = outList[i]
+	}
+	hiddenList := createNeurons(hidden, network.HiddenNeuron, opts, nextNodeId)
+	for i, p := range hidden {
+		nodes[p] = hiddenList[i]
+	}
+
+	for _, c := range connections {
+		nodes[c.target].ConnectFrom(nodes[c.source], c.weight)
+	}
+
+	allList := make([]*network.NNode, 0, nodeId)
+	allList = append(allList, inList...)
+	allList = append(allList, outList...)
+	allList = append(allList, hiddenList...)
+
+	return network.NewNetwork(inList, outList, allList, 0)
+}
+
+// divideAndInitialize is to build the quadtree by sampling the CPPN outputs for connections of the given point. The
+// quadtree node is divided until the initial depth is reached, or while the variance of its children exceeds
+// the division threshold and maximal depth is not reached.
+func divideAndInitialize(cppn network.Solver, point PointF, outgoing bool, opts *neat.ESHyperNEATOptions) (*quadPoint, error) {
+	root := newQuadPoint(0, 0, 1, 1)
+	queue := []*quadPoint{root}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		halfWidth := p.width / 2
+		p.children = []*quadPoint{
+			newQuadPoint(p.x-halfWidth, p.y-halfWidth, halfWidth, p.level+1),
+			newQuadPoint(p.x-halfWidth, p.y+halfWidth, halfWidth, p.level+1),
+			newQuadPoint(p.x+halfWidth, p.y-halfWidth, halfWidth, p.level+1),
+			newQuadPoint(p.x+halfWidth, p.y+halfWidth, halfWidth, p.level+1),
+		}
+		for _, c := range p.children {
+			weight, err := queryConnection(cppn, point, NewPointF(c.x, c.y), outgoing)
+			if err != nil {
+				return nil, err
+			}
+			c.weight = weight
+		}
+
+		if p.level < opts.InitialDepth || (p.level < opts.MaximalDepth && p.variance() > opts.DivisionThreshold) {
+			queue = append(queue, p.children...)
+		}
+	}
+	return root, nil
+}
+
+// pruneAndExtract is to extract connections of the given point from the quadtree. The quadtree nodes with variance
+// above the variance threshold are explored further, otherwise the connection is expressed if the quadtree node lies
+// within the band, i.e. its CPPN output differs enough from its neighbors.
+func pruneAndExtract(cppn network.Solver, point PointF, p *quadPoint, outgoing bool, opts *neat.HyperNEATOptions,
+	connections *[]substrateConnection) error {
+	for _, c := range p.children {
+		if len(c.children) > 0 && c.variance() >= opts.ESHyperNEAT.VarianceThreshold {
+			if err := pruneAndExtract(cppn, point, c, outgoing, opts, connections); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// calculate the band value
+		neighbors := []PointF{
+			NewPointF(c.x-c.width, c.y), NewPointF(c.x+c.width, c.y),
+			NewPointF(c.x, c.y-c.width), NewPointF(c.x, c.y+c.width),
+		}
+		diffs := make([]float64, len(neighbors))
+		for i, n := range neighbors {
+			weight, err := queryConnection(cppn, point, n, outgoing)
+			if err != nil {
+				return err
+			}
+			diffs[i] = math.Abs(c.weight - weight)
+		}
+		band := math.Max(math.Min(diffs[0], diffs[1]), math.Min(diffs[2], diffs[3]))
+		if band <= opts.ESHyperNEAT.BandingThreshold {
+			continue
+		}
+
+		weight, expressed := scaleWeight(c.weight, opts.LinkThreshold, opts.WeightRange)
+		if !expressed {
+			continue
+		}
+		other := NewPointF(c.x, c.y)
+		if outgoing {
+			*connections = append(*connections, substrateConnection{source: point, target: other, weight: weight})
+		} else {
+			*connections = append(*connections, substrateConnection{source: other, target: point, weight: weight})
+		}
+	}
+	return nil
+}
+
+// queryConnection is to query the CPPN for the connection between the point and the other point. If outgoing is true
+// the point is the source of the connection, otherwise it is the target.
+func queryConnection(cppn network.Solver, point, other PointF, outgoing bool) (float64, error) {
+	var outputs []float64
+	var err error
+	if outgoing {
+		outputs, err = QueryCPPN(cppn, point, other)
+	} else {
+		outputs, err = QueryCPPN(cppn, other, point)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return outputs[0], nil
+}
+
+// pruneHiddenNodes is to remove hidden nodes, which are not reachable from the inputs or has no path to the outputs,
+// as well as their connections.
+func pruneHiddenNodes(inputs, outputs, hidden []PointF, connections []substrateConnection) ([]PointF, []substrateConnection) {
+	forward := make(map[PointF][]PointF)
+	backward := make(map[PointF][]PointF)
+	for _, c := range connections {
+		forward[c.source] = append(forward[c.source], c.target)
+		backward[c.target] = append(backward[c.target], c.source)
+	}
+	fromInputs := reachablePoints(inputs, forward)
+	toOutputs := reachablePoints(outputs, backward)
+
+	keep := make(map[PointF]bool)
+	for _, p := range inputs {
+		keep[p] = true
+	}
+	for _, p := range outputs {
+		keep[p] = true
+	}
+	prunedHidden := make([]PointF, 0, len(hidden))
+	for _, p := range hidden {
+		if fromInputs[p] && toOutputs[p] {
+			keep[p] = true
+			prunedHidden = append(prunedHidden, p)
+		}
+	}
+	prunedConnections := make([]substrateConnection, 0, len(connections))
+	for _, c := range connections {
+		if keep[c.source] && keep[c.target] {
+			prunedConnections = append(prunedConnections, c)
+		}
+	}
+	return prunedHidden, prunedConnections
+}
+
+// reachablePoints Returns the set of points reachable from the start points following the provided edges
+func reachablePoints(start []PointF, edges map[PointF][]PointF) map[PointF]bool {
+	visited := make(map[PointF]bool)
+	stack := append([]PointF{}, start...)
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[p] {
+			continue
+		}
+		visited[p] = true
+		stack = append(stack, edges[p]...)
+	}
+	return visited
+}
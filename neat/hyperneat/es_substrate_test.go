@@ -0,0 +1,138 @@
+package hyperneat
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"testing"
+)
+
+func testESHyperNEATOptions() *neat.HyperNEATOptions {
+	opts := testHyperNEATOptions()
+	opts.ESHyperNEAT = &neat.ESHyperNEATOptions{
+		InitialDepth:      2,
+		MaximalDepth:      2,
+		DivisionThreshold: 0.03,
+		VarianceThreshold: 0.03,
+		BandingThreshold:  0.2,
+		IterationLevel:    0,
+	}
+	return opts
+}
+
+func testEvolvableSubstrate() *EvolvableSubstrate {
+	return NewEvolvableSubstrate(&SubstrateLayout{
+		Inputs:  []PointF{NewPointF(-1, -1), NewPointF(1, -1)},
+		Outputs: []PointF{NewPointF(0, 1)},
+	})
+}
+
+func TestEvolvableSubstrate_CreateNetwork(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	net, err := testEvolvableSubstrate().CreateNetwork(cppn, testESHyperNEATOptions())
+	require.NoError(t, err, "failed to create substrate network")
+	require.NotNil(t, net)
+
+	// four hidden nodes discovered at the quadtree leaves
+	assert.Equal(t, 7, net.NodeCount())
+	assert.Equal(t, 12, net.LinkCount())
+	hiddenCount := 0
+	for _, n := range net.AllNodes() {
+		if n.NeuronType == network.HiddenNeuron {
+			hiddenCount++
+			assert.Len(t, n.Incoming, 2)
+			assert.Len(t, n.Outgoing, 1)
+		}
+	}
+	assert.Equal(t, 4, hiddenCount)
+}
+
+func TestEvolvableSubstrate_CreateNetwork_iterationLevel(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	opts := testESHyperNEATOptions()
+	opts.ESHyperNEAT.IterationLevel = 1
+	net, err := testEvolvableSubstrate().CreateNetwork(cppn, opts)
+	require.NoError(t, err, "failed to create substrate network")
+
+	// no new hidden nodes discovered, but connections between hidden nodes added
+	assert.Equal(t, 7, net.NodeCount())
+	assert.Equal(t, 20, net.LinkCount())
+}
+
+func TestEvolvableSubstrate_CreateNetwork_pruned(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	// the connections to the output are below link threshold, thus all hidden nodes should be removed
+	opts := testESHyperNEATOptions()
+	opts.LinkThreshold = 0.3
+	net, err := testEvolvableSubstrate().CreateNetwork(cppn, opts)
+	require.NoError(t, err, "failed to create substrate network")
+
+	assert.Equal(t, 3, net.NodeCount())
+	assert.Equal(t, 0, net.LinkCount())
+}
+
+func TestEvolvableSubstrate_CreateNetworkSolver(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	solver, err := testEvolvableSubstrate().CreateNetworkSolver(cppn, testESHyperNEATOptions())
+	require.NoError(t, err, "failed to create substrate network solver")
+	assert.Equal(t, 7, solver.NodeCount())
+	assert.Equal(t, 12, solver.LinkCount())
+
+	err = solver.LoadSensors([]float64{1.0, 1.0})
+	require.NoError(t, err)
+	res, err := solver.RecursiveSteps()
+	require.NoError(t, err)
+	require.True(t, res)
+	assert.Len(t, solver.ReadOutputs(), 1)
+}
+
+func TestEvolvableSubstrate_CreateNetwork_noOptions(t *testing.T) {
+	substrate := testEvolvableSubstrate()
+	net, err := substrate.CreateNetwork(nil, nil)
+	assert.EqualError(t, err, ErrHyperNEATOptionsNotFound.Error())
+	assert.Nil(t, net)
+
+	net, err = substrate.CreateNetwork(nil, testHyperNEATOptions())
+	assert.EqualError(t, err, ErrESHyperNEATOptionsNotFound.Error())
+	assert.Nil(t, net)
+}
+
+func TestQuadPoint_variance(t *testing.T) {
+	p := newQuadPoint(0, 0, 1, 1)
+	assert.Equal(t, 0.0, p.variance())
+
+	p.children = []*quadPoint{{weight: 1}, {weight: -1}, {weight: 1}, {weight: -1}}
+	assert.Equal(t, 1.0, p.variance())
+}
+
+func TestNewSubstrateLayout(t *testing.T) {
+	opts := &neat.SubstrateLayoutOptions{
+		Inputs:  []neat.SubstratePoint{{X: -1, Y: -1}, {X: 1, Y: -1}},
+		Bias:    &neat.SubstratePoint{X: 0, Y: -1},
+		Hidden:  [][]neat.SubstratePoint{{{X: 0, Y: 0}}},
+		Outputs: []neat.SubstratePoint{{X: 0, Y: 1}},
+	}
+	layout := NewSubstrateLayout(opts)
+	assert.Equal(t, []PointF{NewPointF(-1, -1), NewPointF(1, -1)}, layout.Inputs)
+	require.NotNil(t, layout.Bias)
+	assert.Equal(t, NewPointF(0, -1), *layout.Bias)
+	assert.Equal(t, [][]PointF{{NewPointF(0, 0)}}, layout.Hidden)
+	assert.Equal(t, []PointF{NewPointF(0, 1)}, layout.Outputs)
+}
@@ -0,0 +1,171 @@
+package hyperneat
+
+import (
+	"fmt"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+)
+
+// SubstrateLayout defines the geometric layout of the substrate nodes
+type SubstrateLayout struct {
+	// The coordinates of the input nodes
+	Inputs []PointF `yaml:"inputs"`
+	// The coordinates of the bias node. If omitted the substrate will have no bias node.
+	Bias *PointF `yaml:"bias"`
+	// The coordinates of the hidden nodes per layer. Each layer is fully connected to the next one.
+	Hidden [][]PointF `yaml:"hidden"`
+	// The coordinates of the output nodes
+	Outputs []PointF `yaml:"outputs"`
+}
+
+// NewSubstrateLayout Creates new substrate layout from its description in the HyperNEAT options
+func NewSubstrateLayout(opts *neat.SubstrateLayoutOptions) *SubstrateLayout {
+	layout := &SubstrateLayout{
+		Inputs:  pointsFromOptions(opts.Inputs),
+		Hidden:  make([][]PointF, len(opts.Hidden)),
+		Outputs: pointsFromOptions(opts.Outputs),
+	}
+	if opts.Bias != nil {
+		bias := NewPointF(opts.Bias.X, opts.Bias.Y)
+		layout.Bias = &bias
+	}
+	for i, layer := range opts.Hidden {
+		layout.Hidden[i] = pointsFromOptions(layer)
+	}
+	return layout
+}
+
+// Validate is to check that this layout can be used to build the substrate network
+func (l *SubstrateLayout) Validate() error {
+	if len(l.Inputs) == 0 {
+		return ErrEmptySubstrateInputs
+	}
+	if len(l.Outputs) == 0 {
+		return ErrEmptySubstrateOutputs
+	}
+	for i, layer := range l.Hidden {
+		if len(layer) == 0 {
+			return fmt.Errorf("substrate hidden layer: %d has no nodes", i)
+		}
+	}
+	return nil
+}
+
+// Substrate is the geometric arrangement of the phenotype network nodes. The connections between substrate
+// nodes are not evolved directly, but produced by querying the CPPN with coordinates of the connected nodes.
+type Substrate struct {
+	// The layout of the substrate nodes
+	Layout *SubstrateLayout
+}
+
+// NewSubstrate Creates new substrate with given nodes layout
+func NewSubstrate(layout *SubstrateLayout) *Substrate {
+	return &Substrate{Layout: layout}
+}
+
+// CreateNetwork Creates the phenotype network by querying provided CPPN for the connectivity pattern of this substrate.
+// The substrate layers are connected in order: inputs (and bias) -> hidden layers -> outputs. The link between two
+// nodes is expressed only if the magnitude of the CPPN output exceeds the link threshold of the HyperNEAT options.
+func (s *Substrate) CreateNetwork(cppn network.Solver, opts *neat.HyperNEATOptions) (*network.Network, error) {
+	if opts == nil {
+		return nil, ErrHyperNEATOptionsNotFound
+	}
+	if err := s.Layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	nodeId := 0
+	nextNodeId := func() int {
+		nodeId++
+		return nodeId
+	}
+
+	// create sensor nodes
+	inList := make([]*network.NNode, 0, len(s.Layout.Inputs)+1)
+	sourcePoints := make([]PointF, 0, len(s.Layout.Inputs)+1)
+	for _, p := range s.Layout.Inputs {
+		inList = append(inList, network.NewSensorNode(nextNodeId(), false))
+		sourcePoints = append(sourcePoints, p)
+	}
+	if s.Layout.Bias != nil {
+		inList = append(inList, network.NewSensorNode(nextNodeId(), true))
+		sourcePoints = append(sourcePoints, *s.Layout.Bias)
+	}
+
+	// create output and hidden nodes
+	outList := createNeurons(s.Layout.Outputs, network.OutputNeuron, opts, nextNodeId)
+	hiddenLayers := make([][]*network.NNode, len(s.Layout.Hidden))
+	for i, layer := range s.Layout.Hidden {
+		hiddenLayers[i] = createNeurons(layer, network.HiddenNeuron, opts, nextNodeId)
+	}
+
+	// connect layers in order
+	sources := inList
+	for i, layer := range hiddenLayers {
+		if err := connectLayers(cppn, sources, sourcePoints, layer, s.Layout.Hidden[i], opts); err != nil {
+			return nil, err
+		}
+		sources, sourcePoints = layer, s.Layout.Hidden[i]
+	}
+	if err := connectLayers(cppn, sources, sourcePoints, outList, s.Layout.Outputs, opts); err != nil {
+		return nil, err
+	}
+
+	// collect all nodes
+	allList := make([]*network.NNode, 0, nodeId)
+	allList = append(allList, inList...)
+	allList = append(allList, outList...)
+	for _, layer := range hiddenLayers {
+		allList = append(allList, layer...)
+	}
+
+	return network.NewNetwork(inList, outList, allList, 0), nil
+}
+
+// CreateNetworkSolver Creates the fast network solver of the phenotype network produced by querying provided CPPN
+// for the connectivity pattern of this substrate. See CreateNetwork for details.
+func (s *Substrate) CreateNetworkSolver(cppn network.Solver, opts *neat.HyperNEATOptions) (network.Solver, error) {
+	net, err := s.CreateNetwork(cppn, opts)
+	if err != nil {
+		return nil, err
+	}
+	return net.FastNetworkSolver()
+}
+
+// createNeurons is to create neuron nodes of given type for each provided substrate point
+func createNeurons(points []PointF, neuronType network.NodeNeuronType, opts *neat.HyperNEATOptions,
+	nextNodeId func() int) []*network.NNode {
+	nodes := make([]*network.NNode, len(points))
+	for i := range points {
+		nodes[i] = network.NewNNode(nextNodeId(), neuronType)
+		nodes[i].ActivationType = opts.SubstrateActivator
+	}
+	return nodes
+}
+
+// connectLayers is to connect each source node with each target node if the CPPN output for their coordinates
+// exceeds the link expression threshold
+func connectLayers(cppn network.Solver, sources []*network.NNode, sourcePoints []PointF,
+	targets []*network.NNode, targetPoints []PointF, opts *neat.HyperNEATOptions) error {
+	for t, target := range targets {
+		for s, source := range sources {
+			outputs, err := QueryCPPN(cppn, sourcePoints[s], targetPoints[t])
+			if err != nil {
+				return err
+			}
+			if weight, expressed := scaleWeight(outputs[0], opts.LinkThreshold, opts.WeightRange); expressed {
+				target.ConnectFrom(source, weight)
+			}
+		}
+	}
+	return nil
+}
+
+// pointsFromOptions is to convert the substrate points description into the list of points
+func pointsFromOptions(points []neat.SubstratePoint) []PointF {
+	res := make([]PointF, len(points))
+	for i, p := range points {
+		res[i] = NewPointF(p.X, p.Y)
+	}
+	return res
+}
@@ -0,0 +1,116 @@
+package hyperneat
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"github.com/yaricom/goNEAT/v4/neat/network"
+	"testing"
+)
+
+func testHyperNEATOptions() *neat.HyperNEATOptions {
+	return &neat.HyperNEATOptions{
+		LinkThreshold:      0.2,
+		WeightRange:        3.0,
+		SubstrateActivator: math.LinearActivation,
+	}
+}
+
+func TestSubstrateLayout_Validate(t *testing.T) {
+	layout := SubstrateLayout{Outputs: []PointF{NewPointF(0, 1)}}
+	assert.EqualError(t, layout.Validate(), ErrEmptySubstrateInputs.Error())
+
+	layout = SubstrateLayout{Inputs: []PointF{NewPointF(0, -1)}}
+	assert.EqualError(t, layout.Validate(), ErrEmptySubstrateOutputs.Error())
+
+	layout.Outputs = []PointF{NewPointF(0, 1)}
+	layout.Hidden = [][]PointF{{}}
+	assert.Error(t, layout.Validate())
+
+	layout.Hidden = [][]PointF{{NewPointF(0, 0)}}
+	assert.NoError(t, layout.Validate())
+}
+
+func TestSubstrate_CreateNetwork(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	bias := NewPointF(0, 0)
+	substrate := NewSubstrate(&SubstrateLayout{
+		Inputs:  []PointF{NewPointF(-1, -1), NewPointF(1, -1)},
+		Bias:    &bias,
+		Outputs: []PointF{NewPointF(0, 1)},
+	})
+	net, err := substrate.CreateNetwork(cppn, testHyperNEATOptions())
+	require.NoError(t, err, "failed to create substrate network")
+	require.NotNil(t, net)
+
+	assert.Equal(t, 4, net.NodeCount())
+	// the link from bias is not expressed due to zero CPPN output
+	assert.Equal(t, 2, net.LinkCount())
+
+	out := net.Outputs[0]
+	assert.Equal(t, network.OutputNeuron, out.NeuronType)
+	assert.Equal(t, math.LinearActivation, out.ActivationType)
+	require.Len(t, out.Incoming, 2)
+	assert.InDelta(t, -1.125, out.Incoming[0].ConnectionWeight, 1e-9)
+	assert.InDelta(t, 1.125, out.Incoming[1].ConnectionWeight, 1e-9)
+
+	// check activation
+	err = net.LoadSensors([]float64{1.0, 2.0})
+	require.NoError(t, err)
+	res, err := net.Activate()
+	require.NoError(t, err)
+	require.True(t, res)
+	assert.InDelta(t, 1.125, net.ReadOutputs()[0], 1e-9)
+}
+
+func TestSubstrate_CreateNetwork_hiddenLayers(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	substrate := NewSubstrate(&SubstrateLayout{
+		Inputs:  []PointF{NewPointF(-1, -1), NewPointF(1, -1)},
+		Hidden:  [][]PointF{{NewPointF(-1, 0), NewPointF(1, 0)}},
+		Outputs: []PointF{NewPointF(0, 1)},
+	})
+	net, err := substrate.CreateNetwork(cppn, testHyperNEATOptions())
+	require.NoError(t, err, "failed to create substrate network")
+
+	assert.Equal(t, 5, net.NodeCount())
+	// links between input and hidden nodes at opposite sides have zero CPPN output and not expressed
+	assert.Equal(t, 4, net.LinkCount())
+}
+
+func TestSubstrate_CreateNetworkSolver(t *testing.T) {
+	org, err := buildTestCPPNOrganism()
+	require.NoError(t, err, "failed to create CPPN organism")
+	cppn, err := NewCPPN(org)
+	require.NoError(t, err, "failed to create CPPN")
+
+	substrate := NewSubstrate(&SubstrateLayout{
+		Inputs:  []PointF{NewPointF(-1, -1), NewPointF(1, -1)},
+		Outputs: []PointF{NewPointF(0, 1)},
+	})
+	solver, err := substrate.CreateNetworkSolver(cppn, testHyperNEATOptions())
+	require.NoError(t, err, "failed to create substrate network solver")
+
+	err = solver.LoadSensors([]float64{1.0, 2.0})
+	require.NoError(t, err)
+	res, err := solver.RecursiveSteps()
+	require.NoError(t, err)
+	require.True(t, res)
+	assert.InDelta(t, 1.125, solver.ReadOutputs()[0], 1e-9)
+}
+
+func TestSubstrate_CreateNetwork_noOptions(t *testing.T) {
+	substrate := NewSubstrate(&SubstrateLayout{})
+	net, err := substrate.CreateNetwork(nil, nil)
+	assert.EqualError(t, err, ErrHyperNEATOptionsNotFound.Error())
+	assert.Nil(t, net)
+}
@@ -0,0 +1,122 @@
+package neat
+
+import (
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+)
+
+// HyperNEATOptions The options of the HyperNEAT indirect encoding, i.e. how the evolved CPPN genome is queried over
+// the substrate to produce the phenotype network.
+type HyperNEATOptions struct {
+	// The minimal magnitude of the CPPN output to express the link between substrate nodes
+	LinkThreshold float64 `yaml:"link_threshold"`
+	// The maximal magnitude of the substrate link weight. The expressed CPPN output is scaled into [-WeightRange, WeightRange]
+	WeightRange float64 `yaml:"weight_range"`
+
+	// The name of activation function of the substrate hidden and output nodes
+	SubstrateActivatorName string `yaml:"substrate_activator"`
+	// The activation function of the substrate hidden and output nodes. If omitted, it's resolved by Validate from
+	// the name of activation function or set to the default one.
+	SubstrateActivator math.NodeActivationType `yaml:"-"`
+
+	// The description of the substrate nodes layout, if omitted the substrate should be defined by the experiment
+	Substrate *SubstrateLayoutOptions `yaml:"substrate"`
+	// The options of the Evolvable-Substrate HyperNEAT, if omitted the hidden nodes layout of the substrate is fixed
+	ESHyperNEAT *ESHyperNEATOptions `yaml:"es_hyperneat"`
+}
+
+// SubstratePoint The coordinates of the substrate node
+type SubstratePoint struct {
+	X float64 `yaml:"x"`
+	Y float64 `yaml:"y"`
+}
+
+// SubstrateLayoutOptions The description of the substrate nodes layout
+type SubstrateLayoutOptions struct {
+	// The coordinates of the input nodes
+	Inputs []SubstratePoint `yaml:"inputs"`
+	// The coordinates of the bias node. If omitted the substrate will have no bias node.
+	Bias *SubstratePoint `yaml:"bias"`
+	// The coordinates of the hidden nodes per layer. Ignored by the Evolvable-Substrate HyperNEAT.
+	Hidden [][]SubstratePoint `yaml:"hidden"`
+	// The coordinates of the output nodes
+	Outputs []SubstratePoint `yaml:"outputs"`
+}
+
+// ESHyperNEATOptions The options of the Evolvable-Substrate HyperNEAT (ES-HyperNEAT), which discovers positions of the
+// substrate hidden nodes by the quadtree information extraction from the CPPN connectivity pattern.
+type ESHyperNEATOptions struct {
+	// The minimal depth of the quadtree, i.e. the initial resolution of the CPPN sampling
+	InitialDepth int `yaml:"initial_depth"`
+	// The maximal depth of the quadtree, i.e. the maximal resolution of the CPPN sampling
+	MaximalDepth int `yaml:"maximal_depth"`
+	// The variance of the quadtree node above which it will be divided further until maximal depth reached
+	DivisionThreshold float64 `yaml:"division_threshold"`
+	// The variance of the quadtree node below which its children will not be explored for connections
+	VarianceThreshold float64 `yaml:"variance_threshold"`
+	// The minimal band value of the quadtree leaf to express the connection at its position
+	BandingThreshold float64 `yaml:"banding_threshold"`
+	// The number of iterations to discover hidden nodes from the already discovered hidden nodes
+	IterationLevel int `yaml:"iteration_level"`
+}
+
+// Validate is to check that HyperNEAT options has valid values. The omitted substrate activator is resolved
+// from its name.
+func (h *HyperNEATOptions) Validate() error {
+	if h.LinkThreshold < 0 || h.LinkThreshold >= 1 {
+		return errors.Errorf("HyperNEAT link threshold must be in range [0, 1), but got: %f", h.LinkThreshold)
+	}
+	if h.WeightRange <= 0 {
+		return errors.Errorf("HyperNEAT weight range must be positive, but got: %f", h.WeightRange)
+	}
+	if h.SubstrateActivator == 0 {
+		if err := h.initSubstrateActivator(); err != nil {
+			return errors.Wrap(err, "invalid HyperNEAT substrate activator")
+		}
+	}
+	if _, err := math.NodeActivators.ActivationNameFromType(h.SubstrateActivator); err != nil {
+		return errors.Wrap(err, "invalid HyperNEAT substrate activator")
+	}
+	if h.Substrate != nil {
+		if len(h.Substrate.Inputs) == 0 {
+			return errors.New("HyperNEAT substrate has no input nodes")
+		}
+		if len(h.Substrate.Outputs) == 0 {
+			return errors.New("HyperNEAT substrate has no output nodes")
+		}
+	}
+	if h.ESHyperNEAT != nil {
+		if err := h.ESHyperNEAT.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate is to check that ES-HyperNEAT options has valid values
+func (e *ESHyperNEATOptions) Validate() error {
+	if e.InitialDepth <= 0 {
+		return errors.Errorf("ES-HyperNEAT initial depth must be positive, but got: %d", e.InitialDepth)
+	}
+	if e.MaximalDepth < e.InitialDepth {
+		return errors.Errorf("ES-HyperNEAT maximal depth: %d must not be less than initial depth: %d",
+			e.MaximalDepth, e.InitialDepth)
+	}
+	if e.DivisionThreshold < 0 || e.VarianceThreshold < 0 || e.BandingThreshold < 0 {
+		return errors.New("ES-HyperNEAT thresholds must not be negative")
+	}
+	if e.IterationLevel < 0 {
+		return errors.Errorf("ES-HyperNEAT iteration level must not be negative, but got: %d", e.IterationLevel)
+	}
+	return nil
+}
+
+// set the substrate activator from its name or the default one
+func (h *HyperNEATOptions) initSubstrateActivator() (err error) {
+	if len(h.SubstrateActivatorName) == 0 {
+		h.SubstrateActivator = math.SigmoidSteepenedActivation
+		return nil
+	}
+	h.SubstrateActivator, err = math.NodeActivators.ActivationTypeFromName(h.SubstrateActivatorName)
+	return err
+}
@@ -0,0 +1,145 @@
+package neat
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"os"
+	"testing"
+)
+
+const hyperNEATOptionsYaml = `
+hyperneat:
+  link_threshold: 0.2
+  weight_range: 3.0
+  substrate_activator: LinearActivation
+  substrate:
+    inputs:
+      - {x: -1, y: -1}
+      - {x: 1, y: -1}
+    bias: {x: 0, y: -1}
+    outputs:
+      - {x: 0, y: 1}
+  es_hyperneat:
+    initial_depth: 2
+    maximal_depth: 4
+    division_threshold: 0.5
+    variance_threshold: 0.03
+    banding_threshold: 0.3
+    iteration_level: 1
+`
+
+func TestLoadYAMLOptions_HyperNEAT(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte(hyperNEATOptionsYaml)...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err, "failed to load options")
+	checkNeatOptions(opts, t)
+
+	require.NotNil(t, opts.HyperNEAT)
+	assert.Equal(t, 0.2, opts.HyperNEAT.LinkThreshold)
+	assert.Equal(t, 3.0, opts.HyperNEAT.WeightRange)
+	assert.Equal(t, math.LinearActivation, opts.HyperNEAT.SubstrateActivator)
+
+	substrate := opts.HyperNEAT.Substrate
+	require.NotNil(t, substrate)
+	assert.Equal(t, []SubstratePoint{{X: -1, Y: -1}, {X: 1, Y: -1}}, substrate.Inputs)
+	assert.Equal(t, &SubstratePoint{X: 0, Y: -1}, substrate.Bias)
+	assert.Empty(t, substrate.Hidden)
+	assert.Equal(t, []SubstratePoint{{X: 0, Y: 1}}, substrate.Outputs)
+
+	esOpts := opts.HyperNEAT.ESHyperNEAT
+	require.NotNil(t, esOpts)
+	assert.Equal(t, 2, esOpts.InitialDepth)
+	assert.Equal(t, 4, esOpts.MaximalDepth)
+	assert.Equal(t, 0.5, esOpts.DivisionThreshold)
+	assert.Equal(t, 0.03, esOpts.VarianceThreshold)
+	assert.Equal(t, 0.3, esOpts.BandingThreshold)
+	assert.Equal(t, 1, esOpts.IterationLevel)
+}
+
+func TestHyperNEATOptions_initSubstrateActivator(t *testing.T) {
+	opts := HyperNEATOptions{}
+	err := opts.initSubstrateActivator()
+	require.NoError(t, err)
+	assert.Equal(t, math.SigmoidSteepenedActivation, opts.SubstrateActivator)
+
+	opts.SubstrateActivatorName = "SineActivation"
+	err = opts.initSubstrateActivator()
+	require.NoError(t, err)
+	assert.Equal(t, math.SineActivation, opts.SubstrateActivator)
+
+	opts.SubstrateActivatorName = "NotExistingActivation"
+	err = opts.initSubstrateActivator()
+	assert.Error(t, err)
+}
+
+func TestHyperNEATOptions_Validate(t *testing.T) {
+	opts := HyperNEATOptions{
+		LinkThreshold:      0.2,
+		WeightRange:        3.0,
+		SubstrateActivator: math.SigmoidSteepenedActivation,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.LinkThreshold = 1.0
+	assert.Error(t, opts.Validate())
+
+	opts.LinkThreshold = 0.2
+	opts.WeightRange = 0
+	assert.Error(t, opts.Validate())
+
+	opts.WeightRange = 3.0
+	opts.SubstrateActivator = math.NodeActivationType(255)
+	assert.Error(t, opts.Validate())
+
+	opts.SubstrateActivator = math.SigmoidSteepenedActivation
+	opts.Substrate = &SubstrateLayoutOptions{Outputs: []SubstratePoint{{X: 0, Y: 1}}}
+	assert.Error(t, opts.Validate())
+
+	opts.Substrate.Inputs = []SubstratePoint{{X: 0, Y: -1}}
+	opts.ESHyperNEAT = &ESHyperNEATOptions{InitialDepth: 2, MaximalDepth: 1}
+	assert.Error(t, opts.Validate())
+
+	opts.ESHyperNEAT.MaximalDepth = 3
+	assert.NoError(t, opts.Validate())
+}
+
+func TestHyperNEATOptions_Validate_substrateActivator(t *testing.T) {
+	opts := HyperNEATOptions{LinkThreshold: 0.2, WeightRange: 3.0}
+	assert.NoError(t, opts.Validate())
+	assert.Equal(t, math.SigmoidSteepenedActivation, opts.SubstrateActivator)
+
+	opts = HyperNEATOptions{LinkThreshold: 0.2, WeightRange: 3.0, SubstrateActivatorName: "SineActivation"}
+	assert.NoError(t, opts.Validate())
+	assert.Equal(t, math.SineActivation, opts.SubstrateActivator)
+
+	opts = HyperNEATOptions{LinkThreshold: 0.2, WeightRange: 3.0, SubstrateActivatorName: "NotExistingActivation"}
+	assert.Error(t, opts.Validate())
+}
+
+func TestESHyperNEATOptions_Validate(t *testing.T) {
+	opts := ESHyperNEATOptions{
+		InitialDepth:      2,
+		MaximalDepth:      3,
+		DivisionThreshold: 0.5,
+		VarianceThreshold: 0.03,
+		BandingThreshold:  0.3,
+		IterationLevel:    1,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.InitialDepth = 0
+	assert.Error(t, opts.Validate())
+
+	opts.InitialDepth = 2
+	opts.BandingThreshold = -0.1
+	assert.Error(t, opts.Validate())
+
+	opts.BandingThreshold = 0.3
+	opts.IterationLevel = -1
+	assert.Error(t, opts.Validate())
+}
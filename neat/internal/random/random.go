@@ -0,0 +1,32 @@
+// Package random provides the random numbers generator backed by the global generator of math/rand package, which
+// is shared by the packages of NEAT algorithm
+package random
+
+import (
+	"math/rand"
+)
+
+// globalSource The source of random numbers backed by the global generator of math/rand package. It is safe for
+// concurrent use.
+type globalSource struct{}
+
+func (globalSource) Int63() int64 {
+	return rand.Int63()
+}
+
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64()
+}
+
+func (globalSource) Seed(seed int64) {
+	rand.Seed(seed)
+}
+
+// globalRand The random numbers generator backed by the global generator of math/rand package
+var globalRand = rand.New(globalSource{})
+
+// GlobalRand Returns the random numbers generator backed by the global generator of math/rand package. It produces
+// the same sequence of numbers as functions of math/rand package and is safe for concurrent use.
+func GlobalRand() *rand.Rand {
+	return globalRand
+}
@@ -0,0 +1,14 @@
+package random
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGlobalRand(t *testing.T) {
+	rand.Seed(42)
+	expected := []int64{rand.Int63(), rand.Int63()}
+	GlobalRand().Seed(42)
+	assert.Equal(t, expected, []int64{GlobalRand().Int63(), GlobalRand().Int63()})
+}
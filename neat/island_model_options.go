@@ -0,0 +1,66 @@
+package neat
+
+import "github.com/pkg/errors"
+
+// MigrationTopology defines the topology of migration routes between islands of the island model
+type MigrationTopology string
+
+const (
+	// MigrationTopologyRing each island sends its emigrants only to the next island in the ring
+	MigrationTopologyRing MigrationTopology = "ring"
+	// MigrationTopologyFullyConnected each island sends its emigrants to all other islands
+	MigrationTopologyFullyConnected MigrationTopology = "fully_connected"
+)
+
+// Validate is to check if this migration topology is supported by algorithm. The empty value is considered as
+// ring topology.
+func (m MigrationTopology) Validate() error {
+	if m != "" && m != MigrationTopologyRing && m != MigrationTopologyFullyConnected {
+		return errors.Errorf("unsupported migration topology: [%s]", m)
+	}
+	return nil
+}
+
+// IslandModelOptions The options of the island model, where several populations (islands) evolve independently and
+// periodically exchange their most fit organisms to maintain diversity and to avoid premature convergence.
+type IslandModelOptions struct {
+	// The number of islands, i.e. populations evolving in isolation from each other
+	IslandsCount int `yaml:"islands_count"`
+	// The number of generations between migrations
+	MigrationInterval int `yaml:"migration_interval"`
+	// The number of the most fit organisms sent by each island along each migration route
+	MigrantsCount int `yaml:"migrants_count"`
+	// The topology of migration routes between islands
+	Topology MigrationTopology `yaml:"topology"`
+}
+
+// Validate is to check that island model options has valid values
+func (i *IslandModelOptions) Validate() error {
+	if i.IslandsCount < 2 {
+		return errors.Errorf("island model requires at least two islands, but got: %d", i.IslandsCount)
+	}
+	if i.MigrationInterval <= 0 {
+		return errors.Errorf("island model migration interval must be positive, but got: %d", i.MigrationInterval)
+	}
+	if i.MigrantsCount < 0 {
+		return errors.Errorf("island model migrants count must not be negative, but got: %d", i.MigrantsCount)
+	}
+	return i.Topology.Validate()
+}
+
+// ImmigrantsCount Returns the number of migrants received by each island per migration with respect to the topology
+func (i *IslandModelOptions) ImmigrantsCount() int {
+	if i.Topology == MigrationTopologyFullyConnected {
+		return (i.IslandsCount - 1) * i.MigrantsCount
+	}
+	return i.MigrantsCount
+}
+
+// ValidatePopulationSize is to check that island with population of given size can receive all its migrants
+func (i *IslandModelOptions) ValidatePopulationSize(popSize int) error {
+	if immigrants := i.ImmigrantsCount(); immigrants >= popSize {
+		return errors.Errorf("island model migrants count: %d per island must be less than population size: %d",
+			immigrants, popSize)
+	}
+	return nil
+}
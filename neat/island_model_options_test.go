@@ -0,0 +1,75 @@
+package neat
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+const islandModelOptionsYaml = `
+island_model:
+  islands_count: 4
+  migration_interval: 10
+  migrants_count: 2
+  topology: fully_connected
+`
+
+func TestLoadYAMLOptions_IslandModel(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte(islandModelOptionsYaml)...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err, "failed to load options")
+	checkNeatOptions(opts, t)
+
+	im := opts.IslandModel
+	require.NotNil(t, im)
+	assert.Equal(t, 4, im.IslandsCount)
+	assert.Equal(t, 10, im.MigrationInterval)
+	assert.Equal(t, 2, im.MigrantsCount)
+	assert.Equal(t, MigrationTopologyFullyConnected, im.Topology)
+}
+
+func TestIslandModelOptions_Validate(t *testing.T) {
+	opts := IslandModelOptions{
+		IslandsCount:      2,
+		MigrationInterval: 5,
+		MigrantsCount:     1,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.IslandsCount = 1
+	assert.Error(t, opts.Validate())
+	opts.IslandsCount = 2
+
+	opts.MigrationInterval = 0
+	assert.Error(t, opts.Validate())
+	opts.MigrationInterval = 5
+
+	opts.MigrantsCount = -1
+	assert.Error(t, opts.Validate())
+	opts.MigrantsCount = 1
+
+	opts.Topology = "star"
+	assert.Error(t, opts.Validate())
+}
+
+func TestIslandModelOptions_ValidatePopulationSize(t *testing.T) {
+	opts := IslandModelOptions{
+		IslandsCount:      4,
+		MigrationInterval: 5,
+		MigrantsCount:     3,
+	}
+	assert.Equal(t, 3, opts.ImmigrantsCount())
+	assert.NoError(t, opts.ValidatePopulationSize(5))
+	assert.Error(t, opts.ValidatePopulationSize(3))
+
+	// each island receives migrants from all other islands
+	opts.Topology = MigrationTopologyFullyConnected
+	assert.Equal(t, 9, opts.ImmigrantsCount())
+	assert.Error(t, opts.ValidatePopulationSize(5))
+	assert.NoError(t, opts.ValidatePopulationSize(10))
+}
@@ -0,0 +1,26 @@
+package neat
+
+import "github.com/pkg/errors"
+
+// LocalSearchOptions The options of the local search stage, which trains weights of the acyclic phenotypes by
+// gradient descent against supervised dataset before the fitness is assigned.
+type LocalSearchOptions struct {
+	// The learning rate of the gradient descent
+	LearningRate float64 `yaml:"learning_rate"`
+	// The number of training epochs over the dataset
+	Epochs int `yaml:"epochs"`
+	// If true, the learned weights are written back into the genome (Lamarckian evolution), otherwise the learned
+	// weights only influence the fitness of the organism and are not inherited by offspring (Baldwinian evolution).
+	Lamarckian bool `yaml:"lamarckian"`
+}
+
+// Validate is to check that local search options has valid values
+func (l *LocalSearchOptions) Validate() error {
+	if l.LearningRate <= 0 {
+		return errors.Errorf("local search learning rate must be positive, but got: %f", l.LearningRate)
+	}
+	if l.Epochs <= 0 {
+		return errors.Errorf("local search epochs number must be positive, but got: %d", l.Epochs)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package neat
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+const localSearchOptionsYaml = `
+local_search:
+  learning_rate: 0.1
+  epochs: 20
+  lamarckian: true
+`
+
+func TestLoadYAMLOptions_LocalSearch(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte(localSearchOptionsYaml)...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err, "failed to load options")
+	checkNeatOptions(opts, t)
+
+	ls := opts.LocalSearch
+	require.NotNil(t, ls)
+	assert.Equal(t, 0.1, ls.LearningRate)
+	assert.Equal(t, 20, ls.Epochs)
+	assert.True(t, ls.Lamarckian)
+}
+
+func TestLocalSearchOptions_Validate(t *testing.T) {
+	opts := LocalSearchOptions{LearningRate: 0.1, Epochs: 20}
+	assert.NoError(t, opts.Validate())
+
+	opts.LearningRate = 0
+	assert.Error(t, opts.Validate())
+
+	opts.LearningRate, opts.Epochs = 0.1, 0
+	assert.Error(t, opts.Validate())
+}
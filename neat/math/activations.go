@@ -43,6 +43,9 @@ const (
 // ActivationFunction The neuron node activation function type
 type ActivationFunction func(float64, []float64) float64
 
+// ActivationDerivative The derivative of the neuron node activation function with respect to its input
+type ActivationDerivative func(float64, []float64) float64
+
 // ModuleActivationFunction The neurons module activation function type
 type ModuleActivationFunction func([]float64, []float64) []float64
 
@@ -55,6 +58,8 @@ type NodeActivatorsFactory struct {
 	activators map[NodeActivationType]ActivationFunction
 	// The map of registered neuron module activators by type
 	moduleActivators map[NodeActivationType]ModuleActivationFunction
+	// The map of registered derivatives of neuron node activators by type
+	derivatives map[NodeActivationType]ActivationDerivative
 
 	// The forward and inverse maps of activator type and function name
 	forward map[NodeActivationType]string
@@ -66,6 +71,7 @@ func NewNodeActivatorsFactory() *NodeActivatorsFactory {
 	af := &NodeActivatorsFactory{
 		activators:       make(map[NodeActivationType]ActivationFunction),
 		moduleActivators: make(map[NodeActivationType]ModuleActivationFunction),
+		derivatives:      make(map[NodeActivationType]ActivationDerivative),
 		forward:          make(map[NodeActivationType]string),
 		inverse:          make(map[string]NodeActivationType),
 	}
@@ -92,6 +98,29 @@ func NewNodeActivatorsFactory() *NodeActivatorsFactory {
 	af.Register(SineActivation, sineFunction, "SineActivation")
 	af.Register(StepActivation, stepFunction, "StepActivation")
 
+	// Register derivatives of neuron node activators to be used by gradient based learning
+	af.RegisterDerivative(SigmoidPlainActivation, plainSigmoidDerivative)
+	af.RegisterDerivative(SigmoidReducedActivation, reducedSigmoidDerivative)
+	af.RegisterDerivative(SigmoidSteepenedActivation, steepenedSigmoidDerivative)
+	af.RegisterDerivative(SigmoidBipolarActivation, bipolarSigmoidDerivative)
+	af.RegisterDerivative(SigmoidApproximationActivation, approximationSigmoidDerivative)
+	af.RegisterDerivative(SigmoidSteepenedApproximationActivation, approximationSteepenedSigmoidDerivative)
+	af.RegisterDerivative(SigmoidInverseAbsoluteActivation, inverseAbsoluteSigmoidDerivative)
+	af.RegisterDerivative(SigmoidLeftShiftedActivation, leftShiftedSigmoidDerivative)
+	af.RegisterDerivative(SigmoidLeftShiftedSteepenedActivation, leftShiftedSteepenedSigmoidDerivative)
+	af.RegisterDerivative(SigmoidRightShiftedSteepenedActivation, rightShiftedSteepenedSigmoidDerivative)
+
+	af.RegisterDerivative(TanhActivation, hyperbolicTangentDerivative)
+	af.RegisterDerivative(GaussianBipolarActivation, bipolarGaussianDerivative)
+	af.RegisterDerivative(GaussianActivation, gaussianDerivative)
+	af.RegisterDerivative(LinearActivation, linearDerivative)
+	af.RegisterDerivative(LinearAbsActivation, absoluteLinearDerivative)
+	af.RegisterDerivative(LinearClippedActivation, clippedLinearDerivative)
+	af.RegisterDerivative(NullActivation, zeroDerivative)
+	af.RegisterDerivative(SignActivation, zeroDerivative)
+	af.RegisterDerivative(SineActivation, sineFunctionDerivative)
+	af.RegisterDerivative(StepActivation, zeroDerivative)
+
 	// register neuron modules activators
 	af.RegisterModule(MultiplyModuleActivation, multiplyModule, "MultiplyModuleActivation")
 	af.RegisterModule(MaxModuleActivation, maxModule, "MaxModuleActivation")
@@ -129,6 +158,21 @@ func (a *NodeActivatorsFactory) Register(aType NodeActivationType, aFunc Activat
 	a.inverse[fName] = aType
 }
 
+// RegisterDerivative Registers the derivative of the neuron activation function with provided type into the factory
+func (a *NodeActivatorsFactory) RegisterDerivative(aType NodeActivationType, dFunc ActivationDerivative) {
+	a.derivatives[aType] = dFunc
+}
+
+// DerivativeByType is to calculate the derivative of activation function with specified type for given input and
+// auxiliary parameters. Will return error if no derivative registered for requested activation type.
+func (a *NodeActivatorsFactory) DerivativeByType(input float64, auxParams []float64, aType NodeActivationType) (float64, error) {
+	if fn, ok := a.derivatives[aType]; ok {
+		return fn(input, auxParams), nil
+	} else {
+		return 0, fmt.Errorf("no derivative registered for neuron activation type: %d", aType)
+	}
+}
+
 // RegisterModule Registers given neuron module activation function with provided type and name into the factory
 func (a *NodeActivatorsFactory) RegisterModule(aType NodeActivationType, aFunc ModuleActivationFunction, fName string) {
 	// store function
@@ -278,6 +322,92 @@ var (
 	}
 )
 
+// The derivatives of the activation functions
+var (
+	// The derivatives of sigmoid functions with respect to the input, expressed through the sigmoid value
+	plainSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		s := plainSigmoid(input, auxParams)
+		return s * (1 - s)
+	}
+	reducedSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		s := reducedSigmoid(input, auxParams)
+		return 0.5 * s * (1 - s)
+	}
+	steepenedSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		s := steepenedSigmoid(input, auxParams)
+		return 4.924273 * s * (1 - s)
+	}
+	bipolarSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		s := steepenedSigmoid(input, auxParams)
+		return 2.0 * 4.924273 * s * (1 - s)
+	}
+	approximationSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		if input < -4.0 || input >= 4.0 {
+			return 0.0
+		} else if input < 0.0 {
+			return (input + 4.0) * 0.0625
+		} else {
+			return (4.0 - input) * 0.0625
+		}
+	}
+	approximationSteepenedSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		if input < -1.0 || input >= 1.0 {
+			return 0.0
+		} else if input < 0.0 {
+			return input + 1.0
+		} else {
+			return 1.0 - input
+		}
+	}
+	inverseAbsoluteSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		d := 1.0 + math.Abs(input)
+		return 0.5 / (d * d)
+	}
+	leftShiftedSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		s := leftShiftedSigmoid(input, auxParams)
+		return s * (1 - s)
+	}
+	leftShiftedSteepenedSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		s := leftShiftedSteepenedSigmoid(input, auxParams)
+		return 4.924273 * s * (1 - s)
+	}
+	rightShiftedSteepenedSigmoidDerivative = func(input float64, auxParams []float64) float64 {
+		s := rightShiftedSteepenedSigmoid(input, auxParams)
+		return 4.924273 * s * (1 - s)
+	}
+
+	// The derivatives of other activation functions
+	hyperbolicTangentDerivative = func(input float64, auxParams []float64) float64 {
+		t := hyperbolicTangent(input, auxParams)
+		return 0.9 * (1 - t*t)
+	}
+	bipolarGaussianDerivative = func(input float64, auxParams []float64) float64 {
+		return -25.0 * input * math.Exp(-math.Pow(input*2.5, 2.0))
+	}
+	gaussianDerivative = func(input float64, auxParams []float64) float64 {
+		return -2.0 * input * math.Exp(-math.Pow(input, 2.0))
+	}
+	linearDerivative = func(input float64, auxParams []float64) float64 {
+		return 1.0
+	}
+	absoluteLinearDerivative = func(input float64, auxParams []float64) float64 {
+		return signFunction(input, auxParams)
+	}
+	clippedLinearDerivative = func(input float64, auxParams []float64) float64 {
+		if input < -1.0 || input > 1.0 {
+			return 0.0
+		}
+		return 1.0
+	}
+	sineFunctionDerivative = func(input float64, auxParams []float64) float64 {
+		return 2.0 * math.Cos(2.0*input)
+	}
+	// The derivative of constant and piecewise constant functions
+	zeroDerivative = func(input float64, auxParams []float64) float64 {
+		return 0.0
+	}
+)
+
 // The modular activators
 var (
 	// Multiplies input values and returns multiplication result
@@ -0,0 +1,50 @@
+package math
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNodeActivatorsFactory_DerivativeByType(t *testing.T) {
+	types := []NodeActivationType{
+		SigmoidPlainActivation, SigmoidReducedActivation, SigmoidBipolarActivation, SigmoidSteepenedActivation,
+		SigmoidApproximationActivation, SigmoidSteepenedApproximationActivation, SigmoidInverseAbsoluteActivation,
+		SigmoidLeftShiftedActivation, SigmoidLeftShiftedSteepenedActivation, SigmoidRightShiftedSteepenedActivation,
+		TanhActivation, GaussianBipolarActivation, GaussianActivation, LinearActivation, LinearAbsActivation,
+		LinearClippedActivation, NullActivation, SignActivation, SineActivation, StepActivation,
+	}
+	// the points away from the discontinuities of the piecewise functions
+	inputs := []float64{-5.1, -2.3, -0.7, -0.2, 0.3, 0.6, 2.1, 4.7}
+	eps := 1e-6
+	for _, aType := range types {
+		name, err := NodeActivators.ActivationNameFromType(aType)
+		require.NoError(t, err)
+		for _, x := range inputs {
+			plus, err := NodeActivators.ActivateByType(x+eps, nil, aType)
+			require.NoError(t, err)
+			minus, err := NodeActivators.ActivateByType(x-eps, nil, aType)
+			require.NoError(t, err)
+			expected := (plus - minus) / (2 * eps)
+
+			actual, err := NodeActivators.DerivativeByType(x, nil, aType)
+			require.NoError(t, err)
+			assert.InDelta(t, expected, actual, 1e-5, "wrong derivative of %s at: %f", name, x)
+		}
+	}
+}
+
+func TestNodeActivatorsFactory_DerivativeByType_unsupported(t *testing.T) {
+	_, err := NodeActivators.DerivativeByType(0.5, nil, MultiplyModuleActivation)
+	assert.Error(t, err)
+}
+
+func TestNodeActivatorsFactory_RegisterDerivative(t *testing.T) {
+	af := NewNodeActivatorsFactory()
+	af.RegisterDerivative(MultiplyModuleActivation, func(input float64, _ []float64) float64 {
+		return 2 * input
+	})
+	d, err := af.DerivativeByType(0.5, nil, MultiplyModuleActivation)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, d)
+}
@@ -2,12 +2,23 @@
 package math
 
 import (
+	"github.com/yaricom/goNEAT/v4/neat/internal/random"
 	"math/rand"
 )
 
-// RandSign Returns subsequent random positive or negative integer value (1 or -1) to randomize value sign
+// globalRand The random numbers generator backed by the global generator of math/rand package
+var globalRand = random.GlobalRand()
+
+// RandSign Returns subsequent random positive or negative integer value (1 or -1) to randomize value sign using
+// the global generator of math/rand package
 func RandSign() int32 {
-	v := rand.Int()
+	return RandSignRng(globalRand)
+}
+
+// RandSignRng Returns subsequent random positive or negative integer value (1 or -1) to randomize value sign using
+// provided random numbers generator
+func RandSignRng(rng *rand.Rand) int32 {
+	v := rng.Int()
 	if (v % 2) == 0 {
 		return -1
 	} else {
@@ -17,8 +28,14 @@ func RandSign() int32 {
 
 // SingleRouletteThrow Performs a single thrown onto a roulette wheel where the wheel's space is unevenly divided.
 // The probability that a segment will be selected is given by that segment's value in the probabilities array.
-// Returns segment index or -1 if something goes awfully wrong
+// Returns segment index or -1 if something goes awfully wrong. It uses the global generator of math/rand package.
 func SingleRouletteThrow(probabilities []float64) int {
+	return SingleRouletteThrowRng(globalRand, probabilities)
+}
+
+// SingleRouletteThrowRng Performs a single thrown onto a roulette wheel the same way as SingleRouletteThrow using
+// provided random numbers generator.
+func SingleRouletteThrowRng(rng *rand.Rand, probabilities []float64) int {
 	total := 0.0
 
 	// collect all probabilities
@@ -27,7 +44,7 @@ func SingleRouletteThrow(probabilities []float64) int {
 	}
 
 	// throw the ball and collect result
-	throwValue := rand.Float64() * total
+	throwValue := rng.Float64() * total
 
 	accumulator := 0.0
 	for i, v := range probabilities {
@@ -22,3 +22,29 @@ func TestSingleRouletteThrow(t *testing.T) {
 	}
 	t.Log(hist)
 }
+
+func TestSingleRouletteThrowRng(t *testing.T) {
+	probabilities := []float64{.1, .2, .4, .15, .15}
+	first, second := rand.New(rand.NewSource(42)), rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		index := SingleRouletteThrowRng(first, probabilities)
+		if index < 0 || index >= len(probabilities) {
+			t.Errorf("invalid segment index: %d at %d", index, i)
+			return
+		}
+		if expected := SingleRouletteThrowRng(second, probabilities); index != expected {
+			t.Errorf("segment index: %d differs from expected: %d at %d", index, expected, i)
+			return
+		}
+	}
+}
+
+func TestRandSignRng(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		if sign := RandSignRng(rng); sign != 1 && sign != -1 {
+			t.Errorf("invalid sign: %d at %d", sign, i)
+			return
+		}
+	}
+}
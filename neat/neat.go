@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/yaricom/goNEAT/v4/neat/math"
+	"math/rand"
 )
 
 var (
@@ -46,6 +47,73 @@ func (e EpochExecutorType) Validate() error {
 	return nil
 }
 
+// SpeciationMethod defines the method to assign organisms to species
+type SpeciationMethod string
+
+const (
+	// SpeciationMethodFirstFit assigns organism to the most compatible species by comparing it with the first organism
+	// of each species as a representative
+	SpeciationMethodFirstFit SpeciationMethod = "first_fit"
+	// SpeciationMethodKMedoids clusters organisms by k-medoids using genome compatibility as a distance
+	SpeciationMethodKMedoids SpeciationMethod = "k_medoids"
+	// SpeciationMethodMedoidRepresentative is the same as first fit, but the medoid of each species is its representative
+	SpeciationMethodMedoidRepresentative SpeciationMethod = "medoid_representative"
+)
+
+// Validate is to check if this speciation method is supported by algorithm. The empty value is considered as
+// first fit speciation.
+func (s SpeciationMethod) Validate() error {
+	if s != "" && s != SpeciationMethodFirstFit && s != SpeciationMethodKMedoids && s != SpeciationMethodMedoidRepresentative {
+		return errors.Errorf("unsupported speciation method: [%s]", s)
+	}
+	return nil
+}
+
+// ParentSelectionMethod defines the method to select parents for reproduction within species
+type ParentSelectionMethod string
+
+const (
+	// ParentSelectionMethodUniform selects parents uniformly at random among species survivors
+	ParentSelectionMethodUniform ParentSelectionMethod = "uniform"
+	// ParentSelectionMethodTournament selects the fittest among randomly drawn tournament participants
+	ParentSelectionMethodTournament ParentSelectionMethod = "tournament"
+	// ParentSelectionMethodRoulette selects parents with probability proportional to their fitness
+	ParentSelectionMethodRoulette ParentSelectionMethod = "roulette"
+	// ParentSelectionMethodRank selects parents with probability linearly depending on their rank by fitness
+	ParentSelectionMethodRank ParentSelectionMethod = "rank"
+)
+
+// Validate is to check if this parent selection method is supported by algorithm. The empty value is considered
+// as default selection method.
+func (p ParentSelectionMethod) Validate() error {
+	if p != "" && p != ParentSelectionMethodUniform && p != ParentSelectionMethodTournament &&
+		p != ParentSelectionMethodRoulette && p != ParentSelectionMethodRank {
+		return errors.Errorf("unsupported parent selection method: [%s]", p)
+	}
+	return nil
+}
+
+// PlasticityRule defines the rule of plastic update of the link weights during network activation
+type PlasticityRule string
+
+const (
+	// PlasticityRuleHebbian the plain Hebbian rule: dw = eta * pre * post
+	PlasticityRuleHebbian PlasticityRule = "hebbian"
+	// PlasticityRuleOja the Oja's rule, which is the normalized Hebbian rule: dw = eta * post * (pre - post * w)
+	PlasticityRuleOja PlasticityRule = "oja"
+	// PlasticityRuleABCD the generalized Hebbian rule: dw = eta * (A * pre * post + B * pre + C * post + D)
+	PlasticityRuleABCD PlasticityRule = "abcd"
+)
+
+// Validate is to check if this plasticity rule is supported by algorithm. The empty value is considered as no
+// plasticity.
+func (p PlasticityRule) Validate() error {
+	if p != "" && p != PlasticityRuleHebbian && p != PlasticityRuleOja && p != PlasticityRuleABCD {
+		return errors.Errorf("unsupported plasticity rule: [%s]", p)
+	}
+	return nil
+}
+
 // Options The NEAT algorithm options.
 type Options struct {
 	// Probability of mutating a single trait param
@@ -54,6 +122,18 @@ type Options struct {
 	TraitMutationPower float64 `yaml:"trait_mutation_power"`
 	// The power of a link weight mutation
 	WeightMutPower float64 `yaml:"weight_mut_power"`
+	// If true, each connection gene evolves its own power of a link weight mutation by log-normal self-adaptation.
+	// The global WeightMutPower is used as initial value of the gene's mutation power.
+	WeightMutPowerAdaptive bool `yaml:"weight_mut_power_adaptive"`
+	// The learning rate of the self-adaptation of gene's mutation power. If zero, the 1/sqrt(n) is used, where n is
+	// the number of genes in the genome.
+	WeightMutPowerTau float64 `yaml:"weight_mut_power_tau"`
+	// The minimal value of the self-adaptive gene's mutation power
+	WeightMutPowerMin float64 `yaml:"weight_mut_power_min"`
+	// The maximal value of the self-adaptive gene's mutation power
+	WeightMutPowerMax float64 `yaml:"weight_mut_power_max"`
+	// The power of a node bias mutation, if zero the WeightMutPower is used
+	NodeBiasMutPower float64 `yaml:"node_bias_mut_power"`
 
 	// These 3 global coefficients are used to determine the formula for
 	// computing the compatibility between 2 genomes.  The formula is:
@@ -69,6 +149,39 @@ type Options struct {
 	// This global tells compatibility threshold under which
 	// two Genomes are considered the same species
 	CompatThreshold float64 `yaml:"compat_threshold"`
+	// The target number of species. If positive, the compatibility threshold of the population, which starts from
+	// CompatThreshold, is adjusted each generation by CompatThresholdModifier to steer the number of species towards
+	// this target.
+	SpeciesCountTarget int `yaml:"species_count_target"`
+	// The step to adjust the compatibility threshold when the number of species differs from the target
+	CompatThresholdModifier float64 `yaml:"compat_threshold_modifier"`
+	// The minimal value of the compatibility threshold when it is dynamically adjusted
+	CompatThresholdMin float64 `yaml:"compat_threshold_min"`
+
+	// The method to assign organisms to species (first_fit, k_medoids, medoid_representative), first fit by default
+	SpeciationMethod SpeciationMethod `yaml:"speciation_method"`
+	// The maximal number of k-medoids clustering iterations when k_medoids speciation method is used
+	KMedoidsIterations int `yaml:"k_medoids_iterations"`
+	// The number of workers to compute compatibility of organisms with representatives of species in parallel during
+	// speciation. If zero or one, the compatibility is computed sequentially. The assignment of organisms to species
+	// doesn't depend on the number of workers.
+	SpeciationWorkers int `yaml:"speciation_workers"`
+
+	// The method to select parents for reproduction (uniform, tournament, roulette, rank). If omitted, the parents
+	// are selected uniformly, or by the crowded tournament if multi-objective selection enabled.
+	ParentSelectionMethod ParentSelectionMethod `yaml:"parent_selection"`
+	// The number of participants of the tournament parent selection, two if omitted
+	TournamentSize int `yaml:"tournament_size"`
+	// The selection pressure of the linear rank parent selection in range [1, 2], 1.5 if omitted
+	RankSelectionPressure float64 `yaml:"rank_selection_pressure"`
+
+	// The rule of plastic update of the phenotype link weights during activation (hebbian, oja, abcd). The rule is
+	// parameterized by the link traits: the first trait parameter is the learning rate, and the next four are the
+	// A, B, C, D coefficients of the ABCD rule mapped into the range [-1, 1]. If omitted, the link weights are not plastic.
+	// The rule is applied only to the network.Network phenotypes of organisms evaluated by the experiment drivers
+	// and by the distributed workers with genome payload, and the fast network solver can not be created for such
+	// phenotypes.
+	PlasticityRule PlasticityRule `yaml:"plasticity_rule"`
 
 	/* Globals involved in the epoch cycle - mating, reproduction, etc.. */
 
@@ -90,6 +203,14 @@ type Options struct {
 	MutateAddLinkProb      float64 `yaml:"mutate_add_link_prob"`
 	// probability of mutation involving disconnected inputs connection
 	MutateConnectSensors float64 `yaml:"mutate_connect_sensors"`
+	// probability of mutation removing a link gene and the hidden nodes left without connections
+	MutateDeleteLinkProb float64 `yaml:"mutate_delete_link_prob"`
+	// probability of mutation removing a hidden node with all its link genes
+	MutateDeleteNodeProb float64 `yaml:"mutate_delete_node_prob"`
+	// probability of mutation changing the activation function of a hidden node to another one among NodeActivators
+	MutateNodeActivationProb float64 `yaml:"mutate_node_activation_prob"`
+	// probability of mutation perturbing the biases of neuron nodes
+	MutateNodeBiasProb float64 `yaml:"mutate_node_bias_prob"`
 
 	// Probabilities of a mate being outside species
 	InterspeciesMateRate  float64 `yaml:"interspecies_mate_rate"`
@@ -108,6 +229,10 @@ type Options struct {
 	DropOffAge int `yaml:"dropoff_age"`
 	// Number of tries mutate_add_link will attempt to find an open link
 	NewLinkTries int `yaml:"newlink_tries"`
+	// If true, the innovations are kept across generations, so that identical structures get identical innovation
+	// numbers for the whole run. Otherwise, the innovations are matched only within the same generation, or the same
+	// replacement cycle of the real-time NEAT.
+	KeepInnovations bool `yaml:"keep_innovations"`
 
 	// Tells to print population to file every n generations
 	PrintEvery int `yaml:"print_every"`
@@ -126,6 +251,10 @@ type Options struct {
 	// The genome compatibility testing method to use (linear, fast (make sense for large genomes))
 	GenCompatMethod GenomeCompatibilityMethod `yaml:"genome_compat_method"`
 
+	// If true, the organisms are ranked by Pareto front and crowding distance of their objectives (NSGA-II) to select
+	// parents and to distribute offspring among species instead of the scalar fitness
+	MultiObjectiveSelection bool `yaml:"multi_objective_selection"`
+
 	// The neuron nodes activation functions list to choose from
 	NodeActivators []math.NodeActivationType `yaml:"-"`
 	// The probabilities of selection of the specific node activator function
@@ -136,10 +265,41 @@ type Options struct {
 
 	// LogLevel the log output details level
 	LogLevel string `yaml:"log_level"`
+
+	// HyperNEAT the options of the HyperNEAT substrate decoding, if omitted the direct encoding is assumed
+	HyperNEAT *HyperNEATOptions `yaml:"hyperneat"`
+
+	// NoveltySearch the options of the novelty search, if omitted only the objective fitness is used
+	NoveltySearch *NoveltySearchOptions `yaml:"novelty_search"`
+
+	// PhasedSearch the options of the phased search alternating complexification and simplification of genomes,
+	// if omitted the genomes are only complexified
+	PhasedSearch *PhasedSearchOptions `yaml:"phased_search"`
+
+	// LocalSearch the options of the gradient based training of phenotypes before fitness evaluation, if omitted
+	// the phenotypes are not trained
+	LocalSearch *LocalSearchOptions `yaml:"local_search"`
+
+	// IslandModel the options of the island model with several populations evolving in isolation and exchanging
+	// their most fit organisms, if omitted the single population is evolved. The populations of islands are evaluated
+	// and evolved one after another.
+	IslandModel *IslandModelOptions `yaml:"island_model"`
+
+	// Coevolution the options of the competitive coevolution, where organisms are evaluated against opponents
+	Coevolution *CoevolutionOptions `yaml:"coevolution"`
+
+	// RealTime the options of the real-time NEAT (rtNEAT) with steady-state replacement of organisms
+	RealTime *RealTimeOptions `yaml:"real_time"`
 }
 
 // RandomNodeActivationType Returns next random node activation type among registered with this context
 func (c *Options) RandomNodeActivationType() (math.NodeActivationType, error) {
+	return c.RandomNodeActivationTypeRng(globalRand)
+}
+
+// RandomNodeActivationTypeRng Returns next random node activation type among registered with this context using
+// provided random numbers generator
+func (c *Options) RandomNodeActivationTypeRng(rng *rand.Rand) (math.NodeActivationType, error) {
 	if len(c.NodeActivators) == 0 {
 		return 0, ErrNoActivatorsRegistered
 	}
@@ -152,13 +312,21 @@ func (c *Options) RandomNodeActivationType() (math.NodeActivationType, error) {
 	if len(c.NodeActivators) != len(c.NodeActivatorsProb) {
 		return 0, ErrActivatorsProbabilitiesNumberMismatch
 	}
-	index := math.SingleRouletteThrow(c.NodeActivatorsProb)
+	index := math.SingleRouletteThrowRng(rng, c.NodeActivatorsProb)
 	if index < 0 || index >= len(c.NodeActivators) {
 		return 0, fmt.Errorf("unexpected error when trying to find random node activator, activator index: %d", index)
 	}
 	return c.NodeActivators[index], nil
 }
 
+// NodeBiasMutationPower Returns the power of a node bias mutation
+func (c *Options) NodeBiasMutationPower() float64 {
+	if c.NodeBiasMutPower > 0 {
+		return c.NodeBiasMutPower
+	}
+	return c.WeightMutPower
+}
+
 // Validate is to validate that this options has valid values
 func (c *Options) Validate() error {
 	if err := c.EpochExecutorType.Validate(); err != nil {
@@ -169,6 +337,50 @@ func (c *Options) Validate() error {
 		return err
 	}
 
+	if err := c.SpeciationMethod.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.ParentSelectionMethod.Validate(); err != nil {
+		return err
+	}
+	if err := c.PlasticityRule.Validate(); err != nil {
+		return err
+	}
+	if c.SpeciationWorkers < 0 {
+		return errors.Errorf("number of speciation workers must not be negative, but got: %d", c.SpeciationWorkers)
+	}
+	if c.TournamentSize < 0 {
+		return errors.Errorf("tournament size must not be negative, but got: %d", c.TournamentSize)
+	}
+	if c.RankSelectionPressure != 0 && (c.RankSelectionPressure < 1 || c.RankSelectionPressure > 2) {
+		return errors.Errorf("rank selection pressure must be in range [1, 2], but got: %f", c.RankSelectionPressure)
+	}
+
+	// check self-adaptive weight mutation power
+	if c.WeightMutPowerAdaptive {
+		if c.WeightMutPowerTau < 0 {
+			return errors.Errorf("weight mutation power learning rate must not be negative, but got: %f", c.WeightMutPowerTau)
+		}
+		if c.WeightMutPowerMin <= 0 || c.WeightMutPowerMax < c.WeightMutPowerMin {
+			return errors.Errorf("invalid weight mutation power bounds: [%f, %f]", c.WeightMutPowerMin, c.WeightMutPowerMax)
+		}
+	}
+
+	if c.NodeBiasMutPower < 0 {
+		return errors.Errorf("node bias mutation power must not be negative, but got: %f", c.NodeBiasMutPower)
+	}
+
+	// check dynamic compatibility threshold
+	if c.SpeciesCountTarget > 0 {
+		if c.CompatThresholdModifier <= 0 {
+			return errors.Errorf("compatibility threshold modifier must be positive, but got: %f", c.CompatThresholdModifier)
+		}
+		if c.CompatThresholdMin <= 0 {
+			return errors.Errorf("minimal compatibility threshold must be positive, but got: %f", c.CompatThresholdMin)
+		}
+	}
+
 	// check activators
 	if len(c.NodeActivators) == 0 {
 		return ErrNoActivatorsRegistered
@@ -177,6 +389,58 @@ func (c *Options) Validate() error {
 		return ErrActivatorsProbabilitiesNumberMismatch
 	}
 
+	// check HyperNEAT options if any
+	if c.HyperNEAT != nil {
+		if err := c.HyperNEAT.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// check novelty search options if any
+	if c.NoveltySearch != nil {
+		if err := c.NoveltySearch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// check phased search options if any
+	if c.PhasedSearch != nil {
+		if err := c.PhasedSearch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// check local search options if any
+	if c.LocalSearch != nil {
+		if err := c.LocalSearch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// check island model options if any
+	if c.IslandModel != nil {
+		if err := c.IslandModel.Validate(); err != nil {
+			return err
+		}
+		if err := c.IslandModel.ValidatePopulationSize(c.PopSize); err != nil {
+			return err
+		}
+	}
+
+	// check coevolution options if any
+	if c.Coevolution != nil {
+		if err := c.Coevolution.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// check real-time NEAT options if any
+	if c.RealTime != nil {
+		if err := c.RealTime.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
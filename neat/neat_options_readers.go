@@ -34,6 +34,13 @@ func LoadYAMLOptions(r io.Reader) (*Options, error) {
 		return nil, errors.Wrap(err, "failed to read node activators")
 	}
 
+	// read HyperNEAT substrate activator
+	if opts.HyperNEAT != nil {
+		if err = opts.HyperNEAT.initSubstrateActivator(); err != nil {
+			return nil, errors.Wrap(err, "failed to read HyperNEAT substrate activator")
+		}
+	}
+
 	if err = opts.Validate(); err != nil {
 		return nil, errors.Wrap(err, "invalid NEAT options")
 	}
@@ -61,6 +68,16 @@ func LoadNeatOptions(r io.Reader) (*Options, error) {
 			c.TraitMutationPower = cast.ToFloat64(param)
 		case "weight_mut_power":
 			c.WeightMutPower = cast.ToFloat64(param)
+		case "weight_mut_power_adaptive":
+			c.WeightMutPowerAdaptive = cast.ToBool(param)
+		case "weight_mut_power_tau":
+			c.WeightMutPowerTau = cast.ToFloat64(param)
+		case "weight_mut_power_min":
+			c.WeightMutPowerMin = cast.ToFloat64(param)
+		case "weight_mut_power_max":
+			c.WeightMutPowerMax = cast.ToFloat64(param)
+		case "node_bias_mut_power":
+			c.NodeBiasMutPower = cast.ToFloat64(param)
 		case "disjoint_coeff":
 			c.DisjointCoeff = cast.ToFloat64(param)
 		case "excess_coeff":
@@ -69,6 +86,26 @@ func LoadNeatOptions(r io.Reader) (*Options, error) {
 			c.MutdiffCoeff = cast.ToFloat64(param)
 		case "compat_threshold":
 			c.CompatThreshold = cast.ToFloat64(param)
+		case "species_count_target":
+			c.SpeciesCountTarget = cast.ToInt(param)
+		case "compat_threshold_modifier":
+			c.CompatThresholdModifier = cast.ToFloat64(param)
+		case "compat_threshold_min":
+			c.CompatThresholdMin = cast.ToFloat64(param)
+		case "speciation_method":
+			c.SpeciationMethod = SpeciationMethod(param)
+		case "k_medoids_iterations":
+			c.KMedoidsIterations = cast.ToInt(param)
+		case "speciation_workers":
+			c.SpeciationWorkers = cast.ToInt(param)
+		case "parent_selection":
+			c.ParentSelectionMethod = ParentSelectionMethod(param)
+		case "tournament_size":
+			c.TournamentSize = cast.ToInt(param)
+		case "rank_selection_pressure":
+			c.RankSelectionPressure = cast.ToFloat64(param)
+		case "plasticity_rule":
+			c.PlasticityRule = PlasticityRule(param)
 		case "age_significance":
 			c.AgeSignificance = cast.ToFloat64(param)
 		case "survival_thresh":
@@ -93,6 +130,14 @@ func LoadNeatOptions(r io.Reader) (*Options, error) {
 			c.MutateAddLinkProb = cast.ToFloat64(param)
 		case "mutate_connect_sensors":
 			c.MutateConnectSensors = cast.ToFloat64(param)
+		case "mutate_delete_link_prob":
+			c.MutateDeleteLinkProb = cast.ToFloat64(param)
+		case "mutate_delete_node_prob":
+			c.MutateDeleteNodeProb = cast.ToFloat64(param)
+		case "mutate_node_activation_prob":
+			c.MutateNodeActivationProb = cast.ToFloat64(param)
+		case "mutate_node_bias_prob":
+			c.MutateNodeBiasProb = cast.ToFloat64(param)
 		case "interspecies_mate_rate":
 			c.InterspeciesMateRate = cast.ToFloat64(param)
 		case "mate_multipoint_prob":
@@ -111,6 +156,8 @@ func LoadNeatOptions(r io.Reader) (*Options, error) {
 			c.DropOffAge = cast.ToInt(param)
 		case "newlink_tries":
 			c.NewLinkTries = cast.ToInt(param)
+		case "keep_innovations":
+			c.KeepInnovations = cast.ToBool(param)
 		case "print_every":
 			c.PrintEvery = cast.ToInt(param)
 		case "babies_stolen":
@@ -125,6 +172,8 @@ func LoadNeatOptions(r io.Reader) (*Options, error) {
 			c.GenCompatMethod = GenomeCompatibilityMethod(param)
 		case "log_level":
 			c.LogLevel = param
+		case "multi_objective_selection":
+			c.MultiObjectiveSelection = cast.ToBool(param)
 		default:
 			return nil, errors.Errorf("unknown configuration parameter found: %s = %s", name, param)
 		}
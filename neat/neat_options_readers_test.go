@@ -1,6 +1,7 @@
 package neat
 
 import (
+	"bytes"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +34,156 @@ func TestLoadNeatOptions(t *testing.T) {
 	checkNeatOptions(opts, t)
 }
 
+func TestLoadNeatOptions_multiObjectiveSelection(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nmulti_objective_selection true\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	checkNeatOptions(opts, t)
+	assert.True(t, opts.MultiObjectiveSelection)
+}
+
+func TestLoadNeatOptions_deleteMutations(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nmutate_delete_link_prob 0.01\nmutate_delete_node_prob 0.005\nmutate_node_activation_prob 0.02\nmutate_node_bias_prob 0.1\nnode_bias_mut_power 0.3\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	checkNeatOptions(opts, t)
+	assert.Equal(t, 0.01, opts.MutateDeleteLinkProb)
+	assert.Equal(t, 0.005, opts.MutateDeleteNodeProb)
+	assert.Equal(t, 0.02, opts.MutateNodeActivationProb)
+	assert.Equal(t, 0.1, opts.MutateNodeBiasProb)
+	assert.Equal(t, 0.3, opts.NodeBiasMutationPower())
+
+	// the weight mutation power is used if omitted
+	opts.NodeBiasMutPower = 0
+	assert.Equal(t, opts.WeightMutPower, opts.NodeBiasMutationPower())
+
+	// check validation
+	opts.NodeBiasMutPower = -0.1
+	assert.Error(t, opts.Validate())
+}
+
+func TestLoadNeatOptions_keepInnovations(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nkeep_innovations true\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	checkNeatOptions(opts, t)
+	assert.True(t, opts.KeepInnovations)
+}
+
+func TestLoadNeatOptions_weightMutPowerAdaptive(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nweight_mut_power_adaptive true\nweight_mut_power_tau 0.2\nweight_mut_power_min 0.01\nweight_mut_power_max 5.0\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	checkNeatOptions(opts, t)
+	assert.True(t, opts.WeightMutPowerAdaptive)
+	assert.Equal(t, 0.2, opts.WeightMutPowerTau)
+	assert.Equal(t, 0.01, opts.WeightMutPowerMin)
+	assert.Equal(t, 5.0, opts.WeightMutPowerMax)
+
+	// check validation
+	opts.WeightMutPowerMax = 0.001
+	assert.Error(t, opts.Validate())
+}
+
+func TestLoadNeatOptions_speciesCountTarget(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nspecies_count_target 10\ncompat_threshold_modifier 0.3\ncompat_threshold_min 0.5\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, 10, opts.SpeciesCountTarget)
+	assert.Equal(t, 0.3, opts.CompatThresholdModifier)
+	assert.Equal(t, 0.5, opts.CompatThresholdMin)
+
+	// invalid modifier
+	content = append(content, []byte("compat_threshold_modifier 0\n")...)
+	_, err = LoadNeatOptions(bytes.NewReader(content))
+	assert.Error(t, err)
+}
+
+func TestLoadNeatOptions_speciationMethod(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nspeciation_method k_medoids\nk_medoids_iterations 5\nspeciation_workers 4\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, SpeciationMethodKMedoids, opts.SpeciationMethod)
+	assert.Equal(t, 5, opts.KMedoidsIterations)
+	assert.Equal(t, 4, opts.SpeciationWorkers)
+
+	// negative number of workers
+	invalid := append(append([]byte{}, content...), []byte("speciation_workers -1\n")...)
+	_, err = LoadNeatOptions(bytes.NewReader(invalid))
+	assert.Error(t, err)
+
+	// unsupported method
+	content = append(content, []byte("speciation_method unknown\n")...)
+	_, err = LoadNeatOptions(bytes.NewReader(content))
+	assert.Error(t, err)
+}
+
+func TestLoadNeatOptions_parentSelection(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nparent_selection rank\ntournament_size 3\nrank_selection_pressure 1.8\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, ParentSelectionMethodRank, opts.ParentSelectionMethod)
+	assert.Equal(t, 3, opts.TournamentSize)
+	assert.Equal(t, 1.8, opts.RankSelectionPressure)
+
+	// invalid selection pressure
+	content = append(content, []byte("rank_selection_pressure 2.5\n")...)
+	_, err = LoadNeatOptions(bytes.NewReader(content))
+	assert.Error(t, err)
+
+	// unsupported method
+	content = append(content, []byte("rank_selection_pressure 1.8\nparent_selection unknown\n")...)
+	_, err = LoadNeatOptions(bytes.NewReader(content))
+	assert.Error(t, err)
+}
+
+func TestLoadNeatOptions_plasticityRule(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFilePlain)
+	require.NoError(t, err)
+	content = append(content, []byte("\nplasticity_rule oja\n")...)
+
+	opts, err := LoadNeatOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, PlasticityRuleOja, opts.PlasticityRule)
+
+	// unsupported rule
+	content = append(content, []byte("plasticity_rule unknown\n")...)
+	_, err = LoadNeatOptions(bytes.NewReader(content))
+	assert.Error(t, err)
+}
+
+func TestLoadYAMLOptions_parentSelection(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte("\nparent_selection: tournament\ntournament_size: 4\n")...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, ParentSelectionMethodTournament, opts.ParentSelectionMethod)
+	assert.Equal(t, 4, opts.TournamentSize)
+}
+
 func TestLoadNeatOptions_readError(t *testing.T) {
 	errorReader := ErrorReader(1)
 	opts, err := LoadNeatOptions(&errorReader)
@@ -64,3 +64,18 @@ func TestOptions_RandomNodeActivationType(t *testing.T) {
 	res := activator == math.SigmoidApproximationActivation || activator == math.SigmoidBipolarActivation
 	assert.True(t, res)
 }
+
+func TestOptions_RandomNodeActivationTypeRng(t *testing.T) {
+	opts := &Options{
+		NodeActivators:     []math.NodeActivationType{math.SigmoidApproximationActivation, math.SigmoidBipolarActivation},
+		NodeActivatorsProb: []float64{0.5, 0.5},
+	}
+	first, second := NewRand(42), NewRand(42)
+	for i := 0; i < 10; i++ {
+		activator, err := opts.RandomNodeActivationTypeRng(first)
+		require.NoError(t, err)
+		expected, err := opts.RandomNodeActivationTypeRng(second)
+		require.NoError(t, err)
+		assert.Equal(t, expected, activator, "activators differ at: %d", i)
+	}
+}
@@ -0,0 +1,152 @@
+package network
+
+import (
+	"fmt"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+)
+
+// TrainingSample is the sample of the supervised training dataset
+type TrainingSample struct {
+	// The values to be loaded into the network sensors
+	Inputs []float64
+	// The expected values of the network outputs
+	Outputs []float64
+}
+
+// BackPropagate Trains weights of the links and biases of the neuron nodes of this acyclic network by the stochastic
+// gradient descent minimizing the squared error of the network outputs against provided supervised dataset. The weights
+// are updated after each sample with given learning rate during specified number of epochs. Returns the mean squared
+// error of the network outputs over the last training epoch. The derivatives of the node activation functions are
+// taken from the math.NodeActivators factory. Will return ErrNetworkNotFeedForward if network has recurrent links or modules.
+func (n *Network) BackPropagate(dataset []TrainingSample, learningRate float64, epochs int) (float64, error) {
+	order, err := n.feedForwardOrder()
+	if err != nil {
+		return 0, err
+	}
+	inputsCount := 0
+	for _, node := range n.inputs {
+		if node.NeuronType == InputNeuron {
+			inputsCount++
+		}
+	}
+	index := make(map[*NNode]int, len(order))
+	for i, node := range order {
+		index[node] = i
+	}
+
+	activations := make([]float64, len(order))
+	sums := make([]float64, len(order))
+	active := make([]bool, len(order))
+	errs := make([]float64, len(order))
+	loss := 0.0
+	for epoch := 0; epoch < epochs; epoch++ {
+		loss = 0.0
+		for _, sample := range dataset {
+			if len(sample.Inputs) != inputsCount && len(sample.Inputs) != len(n.inputs) {
+				return 0, ErrNetUnsupportedSensorsArraySize
+			}
+			if len(sample.Outputs) != len(n.Outputs) {
+				return 0, fmt.Errorf("expected outputs size: %d doesn't match network outputs: %d",
+					len(sample.Outputs), len(n.Outputs))
+			}
+			if err = n.LoadSensors(sample.Inputs); err != nil {
+				return 0, err
+			}
+
+			// the forward pass - only nodes reachable from sensors get activated as during regular activation
+			for i, node := range order {
+				errs[i] = 0.0
+				if node.IsSensor() {
+					activations[i], active[i] = node.Activation, true
+					continue
+				}
+				sums[i], active[i], activations[i] = node.Bias, false, 0.0
+				for _, link := range node.Incoming {
+					if j := index[link.InNode]; active[j] {
+						sums[i] += link.ConnectionWeight * activations[j]
+						active[i] = true
+					}
+				}
+				if active[i] {
+					if activations[i], err = math.NodeActivators.ActivateByType(sums[i], node.Params, node.ActivationType); err != nil {
+						return 0, err
+					}
+				}
+			}
+
+			// the errors of outputs
+			for k, out := range n.Outputs {
+				i := index[out]
+				diff := activations[i] - sample.Outputs[k]
+				errs[i] += diff
+				loss += diff * diff
+			}
+
+			// the backward pass - propagate errors in reverse order and update weights
+			for i := len(order) - 1; i >= 0; i-- {
+				node := order[i]
+				if node.IsSensor() || !active[i] || errs[i] == 0.0 {
+					continue
+				}
+				derivative, err := math.NodeActivators.DerivativeByType(sums[i], node.Params, node.ActivationType)
+				if err != nil {
+					return 0, err
+				}
+				delta := errs[i] * derivative
+				for _, link := range node.Incoming {
+					if j := index[link.InNode]; active[j] {
+						errs[j] += link.ConnectionWeight * delta
+						link.ConnectionWeight -= learningRate * delta * activations[j]
+					}
+				}
+				node.Bias -= learningRate * delta
+			}
+		}
+		if len(dataset) > 0 && len(n.Outputs) > 0 {
+			loss /= float64(len(dataset) * len(n.Outputs))
+		}
+	}
+
+	// reset the state of the network after training
+	if _, err = n.Flush(); err != nil {
+		return 0, err
+	}
+	return loss, nil
+}
+
+// feedForwardOrder is to sort nodes of this network in topological order, so that each node follows all nodes
+// linked into it. Will return ErrNetworkNotFeedForward if network is not acyclic or has modules.
+func (n *Network) feedForwardOrder() ([]*NNode, error) {
+	if len(n.controlNodes) > 0 {
+		return nil, ErrNetworkNotFeedForward
+	}
+	// count incoming links per node
+	inDegree := make(map[*NNode]int, len(n.allNodes))
+	for _, node := range n.allNodes {
+		for _, link := range node.Incoming {
+			if link.IsRecurrent || link.IsTimeDelayed {
+				return nil, ErrNetworkNotFeedForward
+			}
+		}
+		inDegree[node] = len(node.Incoming)
+	}
+	order := make([]*NNode, 0, len(n.allNodes))
+	for _, node := range n.allNodes {
+		if inDegree[node] == 0 {
+			order = append(order, node)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, link := range order[i].Outgoing {
+			inDegree[link.OutNode]--
+			if inDegree[link.OutNode] == 0 {
+				order = append(order, link.OutNode)
+			}
+		}
+	}
+	if len(order) != len(n.allNodes) {
+		// some nodes are in cycle
+		return nil, ErrNetworkNotFeedForward
+	}
+	return order, nil
+}
@@ -0,0 +1,97 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat/math"
+	"testing"
+)
+
+func TestNetwork_BackPropagate_linear(t *testing.T) {
+	in1, in2, bias := NewSensorNode(1, false), NewSensorNode(2, false), NewSensorNode(3, true)
+	out := NewNNode(4, OutputNeuron)
+	out.ActivationType = math.LinearActivation
+	out.ConnectFrom(in1, 0.0)
+	out.ConnectFrom(in2, 0.0)
+	out.ConnectFrom(bias, 0.0)
+	net := NewNetwork([]*NNode{in1, in2, bias}, []*NNode{out}, []*NNode{in1, in2, bias, out}, 0)
+
+	// y = 0.5 * x1 - 0.3 * x2 + 0.1
+	dataset := make([]TrainingSample, 0)
+	for _, x1 := range []float64{-1, -0.5, 0, 0.5, 1} {
+		for _, x2 := range []float64{-1, 0, 1} {
+			dataset = append(dataset, TrainingSample{Inputs: []float64{x1, x2}, Outputs: []float64{0.5*x1 - 0.3*x2 + 0.1}})
+		}
+	}
+
+	loss, err := net.BackPropagate(dataset, 0.1, 200)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, loss, 1e-6)
+	assert.InDelta(t, 0.5, out.Incoming[0].ConnectionWeight, 1e-3)
+	assert.InDelta(t, -0.3, out.Incoming[1].ConnectionWeight, 1e-3)
+	// the intercept is learned by both the link from the bias neuron and the bias of the node
+	assert.InDelta(t, 0.1, out.Incoming[2].ConnectionWeight+out.Bias, 1e-3)
+
+	// check that network state was reset
+	assert.Equal(t, int32(0), out.ActivationsCount)
+}
+
+func TestNetwork_BackPropagate_hidden(t *testing.T) {
+	net := buildNetwork()
+	// scale down weights to avoid saturation of sigmoid
+	for _, node := range net.allNodes {
+		for _, link := range node.Incoming {
+			link.ConnectionWeight *= 0.05
+		}
+	}
+	dataset := []TrainingSample{
+		{Inputs: []float64{0.5, 1.1}, Outputs: []float64{0.2, 0.9}},
+		{Inputs: []float64{-0.5, 0.3}, Outputs: []float64{0.7, 0.1}},
+	}
+	// the zero learning rate gives loss of the untrained network
+	initialLoss, err := net.BackPropagate(dataset, 0.0, 1)
+	require.NoError(t, err)
+
+	_, err = net.BackPropagate(dataset, 0.05, 100)
+	require.NoError(t, err)
+	loss, err := net.BackPropagate(dataset, 0.0, 1)
+	require.NoError(t, err)
+	assert.True(t, loss < initialLoss, "loss not decreased: %f >= %f", loss, initialLoss)
+
+	// check that trained network is properly activated
+	err = net.LoadSensors(dataset[0].Inputs)
+	require.NoError(t, err)
+	res, err := net.Activate()
+	require.NoError(t, err)
+	require.True(t, res)
+}
+
+func TestNetwork_BackPropagate_notFeedForward(t *testing.T) {
+	dataset := []TrainingSample{{Inputs: []float64{0.5, 1.1}, Outputs: []float64{0.2, 0.9}}}
+
+	// recurrent link
+	net := buildNetwork()
+	net.allNodes[3].ConnectFrom(net.allNodes[6], 1.0).IsRecurrent = true
+	_, err := net.BackPropagate(dataset, 0.1, 1)
+	assert.ErrorIs(t, err, ErrNetworkNotFeedForward)
+
+	// the cycle without recurrent flag
+	net = buildNetwork()
+	net.allNodes[3].ConnectFrom(net.allNodes[6], 1.0)
+	_, err = net.BackPropagate(dataset, 0.1, 1)
+	assert.ErrorIs(t, err, ErrNetworkNotFeedForward)
+
+	// modular network
+	net = buildModularNetwork()
+	_, err = net.BackPropagate(dataset, 0.1, 1)
+	assert.ErrorIs(t, err, ErrNetworkNotFeedForward)
+}
+
+func TestNetwork_BackPropagate_wrongSampleSize(t *testing.T) {
+	net := buildNetwork()
+	_, err := net.BackPropagate([]TrainingSample{{Inputs: []float64{0.5, 1.1}, Outputs: []float64{0.2}}}, 0.1, 1)
+	assert.Error(t, err)
+
+	_, err = net.BackPropagate([]TrainingSample{{Inputs: []float64{0.5}, Outputs: []float64{0.2, 0.9}}}, 0.1, 1)
+	assert.ErrorIs(t, err, ErrNetUnsupportedSensorsArraySize)
+}
@@ -17,6 +17,12 @@ var (
 	ErrMaximalNetDepthExceeded = errors.New("depth of the network exceeds maximum allowed, fallback to maximal")
 	// ErrZeroActivationStepsRequested the error to be raised when zero activation steps requested
 	ErrZeroActivationStepsRequested = errors.New("zero activation steps requested")
+	// ErrNetworkNotFeedForward the error to be raised when gradient based learning requested for the network which
+	// is not acyclic or has modules
+	ErrNetworkNotFeedForward = errors.New("gradient based learning supports only acyclic networks without modules")
+	// ErrPlasticityNotSupported the error to be raised when fast network solver requested for the network with
+	// plastic link weights
+	ErrPlasticityNotSupported = errors.New("plasticity of link weights is not supported by fast network solver")
 )
 
 // NodeType NNodeType defines the type of NNode to create
@@ -210,10 +210,15 @@ func (s *FastModularNetworkSolver) recursiveActivateNode(currentNode int) (res b
 	// This is no longer being calculated (for cycle detection)
 	s.inActivation[currentNode] = false
 
+	signal := s.neuronSignalsBeingProcessed[currentNode]
+	if len(s.biasList) > 0 {
+		// append BIAS value to the signal if appropriate
+		signal += s.biasList[currentNode]
+	}
+
 	// Set this signal after running it through the activation function
 	if s.neuronSignals[currentNode], err = neatmath.NodeActivators.ActivateByType(
-		s.neuronSignalsBeingProcessed[currentNode], nil,
-		s.activationFunctions[currentNode]); err != nil {
+		signal, nil, s.activationFunctions[currentNode]); err != nil {
 		// failed to activate
 		res = false
 	} else {
@@ -246,7 +251,7 @@ func (s *FastModularNetworkSolver) forwardStep(maxAllowedSignalDelta float64) (i
 	// Pass the signals through the single-valued activation functions
 	for i := s.sensorNeuronCount; i < s.totalNeuronCount; i++ {
 		signal := s.neuronSignalsBeingProcessed[i]
-		if s.biasNeuronCount > 0 {
+		if len(s.biasList) > 0 {
 			// append BIAS value to the signal if appropriate
 			signal += s.biasList[i]
 		}
@@ -324,16 +329,16 @@ func (s *FastModularNetworkSolver) NodeCount() int {
 	return s.totalNeuronCount + len(s.modules)
 }
 
+// LinkCount Returns the total number of links between nodes in the network. Each neuron with non-zero bias counts as
+// having one bias link, whether the bias is received from the bias neurons or is the bias of the node itself.
 func (s *FastModularNetworkSolver) LinkCount() int {
 	// count all connections
 	numLinks := len(s.connections)
 
-	// count all bias links if any
-	if s.biasNeuronCount > 0 {
-		for _, b := range s.biasList {
-			if b != 0 {
-				numLinks++
-			}
+	// count all bias links, which are activated as biases of neurons
+	for _, b := range s.biasList {
+		if b != 0 {
+			numLinks++
 		}
 	}
 
@@ -3,6 +3,7 @@ package network
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat/math"
 	"testing"
 )
 
@@ -65,6 +66,37 @@ func TestFastModularNetworkSolver_RecursiveSteps(t *testing.T) {
 	}
 }
 
+func TestFastModularNetworkSolver_RecursiveSteps_nodeBias(t *testing.T) {
+	in1, in2 := NewSensorNode(1, false), NewSensorNode(2, false)
+	hidden, out := NewNNode(3, HiddenNeuron), NewNNode(4, OutputNeuron)
+	hidden.ActivationType, out.ActivationType = math.LinearActivation, math.LinearActivation
+	hidden.Bias, out.Bias = 0.5, -0.25
+	hidden.ConnectFrom(in1, 1.0)
+	hidden.ConnectFrom(in2, 2.0)
+	out.ConnectFrom(hidden, 0.5)
+	net := NewNetwork([]*NNode{in1, in2}, []*NNode{out}, []*NNode{in1, in2, hidden, out}, 0)
+
+	data := []float64{0.5, 1.0}
+	fmm, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast network solver")
+	err = fmm.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	err = net.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+
+	res, err := net.ForwardSteps(2)
+	require.NoError(t, err, "error when trying to activate objective network")
+	require.True(t, res, "failed to activate objective network")
+	res, err = fmm.RecursiveSteps()
+	require.NoError(t, err, "error when trying to activate Fast Network Solver")
+	require.True(t, res, "recursive activation failed")
+
+	// out = (0.5 * 1.0 + 1.0 * 2.0 + 0.5) * 0.5 - 0.25
+	expected := []float64{1.25}
+	assert.Equal(t, expected, net.ReadOutputs())
+	assert.Equal(t, expected, fmm.ReadOutputs())
+}
+
 func TestFastModularNetworkSolver_ForwardSteps(t *testing.T) {
 	net := buildModularNetwork()
 
@@ -183,6 +215,17 @@ func TestFastModularNetworkSolver_LinkCount(t *testing.T) {
 	assert.Equal(t, 9, fmm.LinkCount())
 }
 
+func TestFastModularNetworkSolver_LinkCount_nodeBias(t *testing.T) {
+	// the network without bias neurons with bias of the output node
+	fmm := NewFastModularNetworkSolver(0, 2, 1, 3, []math.NodeActivationType{
+		math.NullActivation, math.NullActivation, math.SigmoidSteepenedActivation,
+	}, []*FastNetworkLink{
+		{SourceIndex: 0, TargetIndex: 2, Weight: 1.0},
+		{SourceIndex: 1, TargetIndex: 2, Weight: 1.0},
+	}, []float64{0, 0, 0.5}, nil)
+	assert.Equal(t, 3, fmm.LinkCount())
+}
+
 func countActiveSignals(impl *FastModularNetworkSolver) int {
 	active := 0
 	for i := impl.biasNeuronCount; i < impl.totalNeuronCount; i++ {
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"github.com/yaricom/goNEAT/v4/neat/math"
+	"github.com/yaricom/goNEAT/v4/neat"
+	neatmath "github.com/yaricom/goNEAT/v4/neat/math"
 	"gonum.org/v1/gonum/graph/path"
 	"io"
+	"math"
 )
 
 // Network is a collection of all nodes within an organism's phenotype, which effectively defines Neural Network topology.
@@ -19,6 +21,10 @@ type Network struct {
 	Name string
 	// NNodes that output from the network
 	Outputs []*NNode
+	// The rule of plastic update of the link weights after each activation step, parameterized by the link traits.
+	// If empty, the link weights are not plastic. The plasticity is applied only by this network, the fast network
+	// solver can not be created for the network with plastic link weights.
+	PlasticityRule neat.PlasticityRule
 
 	// The number of links in the net (-1 means not yet counted)
 	numLinks int
@@ -31,6 +37,8 @@ type Network struct {
 
 	// allNodesMIMO a list of all nodes in the network including MIMO control ones
 	allNodesMIMO []*NNode
+	// The original weights of the links changed by plasticity to be restored on flush
+	initialWeights map[*Link]float64
 }
 
 // NewNetwork Creates new network
@@ -55,8 +63,11 @@ func NewModularNetwork(in, out, all, control []*NNode, netId int) *Network {
 }
 
 // FastNetworkSolver Creates fast network solver based on the architecture of this network. It's primarily aimed for
-// big networks to improve processing speed.
+// big networks to improve processing speed. Returns ErrPlasticityNotSupported if plasticity rule of this network is set.
 func (n *Network) FastNetworkSolver() (Solver, error) {
+	if n.PlasticityRule != "" {
+		return nil, ErrPlasticityNotSupported
+	}
 	// calculate neurons per layer
 	outputNeuronCount := len(n.Outputs)
 	// build bias, input and hidden neurons lists
@@ -81,7 +92,7 @@ func (n *Network) FastNetworkSolver() (Solver, error) {
 	totalNeuronCount := len(n.allNodes)
 
 	// create activation functions array
-	activations := make([]math.NodeActivationType, totalNeuronCount)
+	activations := make([]neatmath.NodeActivationType, totalNeuronCount)
 	neuronLookup := make(map[int]int) // id:index
 
 	// walk through neuron nodes in order: bias, input, output, hidden
@@ -94,6 +105,13 @@ func (n *Network) FastNetworkSolver() (Solver, error) {
 	biases := make([]float64, totalNeuronCount)
 	connections := make([]*FastNetworkLink, 0)
 
+	// store biases of the neuron nodes
+	for _, ne := range n.allNodes {
+		if ne.IsNeuron() {
+			biases[neuronLookup[ne.Id]] = ne.Bias
+		}
+	}
+
 	if inConnects, err := n.processIncomingConnections(inList, biases, neuronLookup); err == nil {
 		connections = append(connections, inConnects...)
 	} else {
@@ -144,7 +162,7 @@ func (n *Network) FastNetworkSolver() (Solver, error) {
 	return solver, nil
 }
 
-func processList(startIndex int, nList []*NNode, activations []math.NodeActivationType, neuronLookup map[int]int) int {
+func processList(startIndex int, nList []*NNode, activations []neatmath.NodeActivationType, neuronLookup map[int]int) int {
 	for _, ne := range nList {
 		activations[startIndex] = ne.ActivationType
 		neuronLookup[ne.Id] = startIndex
@@ -194,6 +212,8 @@ func (n *Network) IsControlNode(nid int) bool {
 
 func (n *Network) Flush() (res bool, err error) {
 	res = true
+	// Restore weights changed by plasticity
+	n.restoreWeights()
 	// Flush back recursively
 	for _, node := range n.allNodes {
 		node.Flushback()
@@ -263,7 +283,7 @@ func (n *Network) ActivateSteps(maxSteps int) (bool, error) {
 		// For each neuron node, compute the sum of its incoming activation
 		for _, np := range n.allNodes {
 			if np.IsNeuron() {
-				np.ActivationSum = 0.0 // reset activation value
+				np.ActivationSum = np.Bias // reset activation value to the bias of the node
 
 				// For each node's incoming connection, add the activity from the connection to the activesum
 				for _, link := range np.Incoming {
@@ -287,7 +307,7 @@ func (n *Network) ActivateSteps(maxSteps int) (bool, error) {
 				// Only activate if some active input came in
 				if np.isActive {
 					// Now run the net activation through an activation function
-					err := ActivateNode(np, math.NodeActivators)
+					err := ActivateNode(np, neatmath.NodeActivators)
 					if err != nil {
 						return false, err
 					}
@@ -299,7 +319,7 @@ func (n *Network) ActivateSteps(maxSteps int) (bool, error) {
 		for _, cn := range n.controlNodes {
 			cn.isActive = false
 			// Activate control MIMO node as control module
-			err := ActivateModule(cn, math.NodeActivators)
+			err := ActivateModule(cn, neatmath.NodeActivators)
 			if err != nil {
 				return false, err
 			}
@@ -327,6 +347,7 @@ func (n *Network) ForwardSteps(steps int) (res bool, err error) {
 			// failure - no need to continue
 			return false, err
 		}
+		n.applyPlasticity()
 	}
 	return res, err
 }
@@ -339,8 +360,35 @@ func (n *Network) RecursiveSteps() (bool, error) {
 	return n.ForwardSteps(netDepth)
 }
 
-func (n *Network) Relax(_ int, _ float64) (bool, error) {
-	return false, errors.New("relax is not implemented")
+func (n *Network) Relax(maxSteps int, maxAllowedSignalDelta float64) (relaxed bool, err error) {
+	if maxSteps == 0 {
+		return false, ErrZeroActivationStepsRequested
+	}
+	activations := make([]float64, len(n.allNodes))
+	for i := 0; i < maxSteps; i++ {
+		for j, np := range n.allNodes {
+			activations[j] = np.Activation
+		}
+		if _, err = n.ActivateSteps(maxSteps); err != nil {
+			return false, err
+		}
+		n.applyPlasticity()
+
+		// check if the change of activation at any node is within allowed limits
+		relaxed = true
+		if maxAllowedSignalDelta > 0 {
+			for j, np := range n.allNodes {
+				if math.Abs(np.Activation-activations[j]) >= maxAllowedSignalDelta {
+					relaxed = false
+					break
+				}
+			}
+		}
+		if relaxed {
+			break // no need to iterate any further, already reached desired accuracy
+		}
+	}
+	return relaxed, nil
 }
 
 func (n *Network) LoadSensors(sensors []float64) error {
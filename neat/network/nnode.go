@@ -20,6 +20,8 @@ type NNode struct {
 	ActivationType math.NodeActivationType
 	// The neuron type for this node (HIDDEN, INPUT, OUTPUT, BIAS)
 	NeuronType NodeNeuronType
+	// The bias of the neuron node added to the sum of its incoming signals before activation
+	Bias float64
 
 	// The node's activation value
 	Activation float64
@@ -71,6 +73,7 @@ func NewNNodeCopy(n *NNode, t *neat.Trait) *NNode {
 	node.Id = n.Id
 	node.NeuronType = n.NeuronType
 	node.ActivationType = n.ActivationType
+	node.Bias = n.Bias
 	node.Trait = t
 	return node
 }
@@ -315,6 +318,7 @@ func (n *NNode) PrintDebug() string {
 	activation, _ := math.NodeActivators.ActivationNameFromType(n.ActivationType)
 	_, _ = fmt.Fprintf(b, "\tActivation Type: %s\n", activation)
 	_, _ = fmt.Fprintf(b, "\tNeuronType: %d\n", n.NeuronType)
+	_, _ = fmt.Fprintf(b, "\tBias: %f\n", n.Bias)
 	_, _ = fmt.Fprintf(b, "\tActivationsCount: %d\n", n.ActivationsCount)
 	_, _ = fmt.Fprintf(b, "\tActivationSum: %f\n", n.ActivationSum)
 	_, _ = fmt.Fprintf(b, "\tIncoming: %s\n", n.Incoming)
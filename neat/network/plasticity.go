@@ -0,0 +1,74 @@
+package network
+
+import (
+	"github.com/yaricom/goNEAT/v4/neat"
+)
+
+// The indexes of the link parameters, derived from the link trait, used by the plasticity rules. The coefficients
+// of the ABCD rule are mapped from the trait parameters range [0, 1] into the range [-1, 1].
+const (
+	plasticityLearningRateParam = iota
+	plasticityAParam
+	plasticityBParam
+	plasticityCParam
+	plasticityDParam
+)
+
+// applyPlasticity is to update weights of the network links according to the plasticity rule of this network using
+// the current activations of the connected nodes. The original link weights are stored to be restored on Flush.
+func (n *Network) applyPlasticity() {
+	if n.PlasticityRule == "" {
+		return
+	}
+	if n.initialWeights == nil {
+		n.initialWeights = make(map[*Link]float64)
+	}
+	for _, node := range n.allNodes {
+		for _, link := range node.Incoming {
+			if len(link.Params) == 0 {
+				// no trait - the link is not plastic
+				continue
+			}
+			if _, ok := n.initialWeights[link]; !ok {
+				n.initialWeights[link] = link.ConnectionWeight
+			}
+			link.ConnectionWeight += plasticWeightDelta(n.PlasticityRule, link)
+		}
+	}
+}
+
+// restoreWeights is to restore the original weights of the links changed by plasticity
+func (n *Network) restoreWeights() {
+	for link, weight := range n.initialWeights {
+		link.ConnectionWeight = weight
+	}
+	n.initialWeights = nil
+}
+
+// plasticWeightDelta is to calculate the change of the link weight according to the given plasticity rule
+func plasticWeightDelta(rule neat.PlasticityRule, link *Link) float64 {
+	pre, post := link.InNode.GetActiveOut(), link.OutNode.GetActiveOut()
+	eta := link.Params[plasticityLearningRateParam]
+	switch rule {
+	case neat.PlasticityRuleHebbian:
+		return eta * pre * post
+	case neat.PlasticityRuleOja:
+		return eta * post * (pre - post*link.ConnectionWeight)
+	case neat.PlasticityRuleABCD:
+		if len(link.Params) <= plasticityDParam {
+			return 0
+		}
+		a := coefficientFromParam(link.Params[plasticityAParam])
+		b := coefficientFromParam(link.Params[plasticityBParam])
+		c := coefficientFromParam(link.Params[plasticityCParam])
+		d := coefficientFromParam(link.Params[plasticityDParam])
+		return eta * (a*pre*post + b*pre + c*post + d)
+	default:
+		return 0
+	}
+}
+
+// coefficientFromParam is to map the trait parameter from the range [0, 1] into the range [-1, 1]
+func coefficientFromParam(param float64) float64 {
+	return 2.0*param - 1.0
+}
@@ -0,0 +1,134 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v4/neat"
+	"testing"
+)
+
+const plasticTestWeight = 0.5
+
+func buildPlasticNetwork(rule neat.PlasticityRule, params []float64) (*Network, *Link) {
+	input := NewNNode(1, InputNeuron)
+	output := NewNNode(2, OutputNeuron)
+	link := output.ConnectFrom(input, plasticTestWeight)
+	link.Params = params
+
+	net := NewNetwork([]*NNode{input}, []*NNode{output}, []*NNode{input, output}, 0)
+	net.PlasticityRule = rule
+	return net, link
+}
+
+func activatePlasticNetwork(t *testing.T, net *Network, input float64) float64 {
+	err := net.LoadSensors([]float64{input})
+	require.NoError(t, err, "failed to load sensors")
+	res, err := net.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate")
+	require.True(t, res)
+	return net.ReadOutputs()[0]
+}
+
+func TestNetwork_ForwardSteps_plasticityHebbian(t *testing.T) {
+	net, link := buildPlasticNetwork(neat.PlasticityRuleHebbian, []float64{0.1})
+
+	pre := 0.8
+	post := activatePlasticNetwork(t, net, pre)
+	assert.InDelta(t, plasticTestWeight+0.1*pre*post, link.ConnectionWeight, 1e-12)
+}
+
+func TestNetwork_ForwardSteps_plasticityOja(t *testing.T) {
+	net, link := buildPlasticNetwork(neat.PlasticityRuleOja, []float64{0.1})
+
+	pre := 0.8
+	post := activatePlasticNetwork(t, net, pre)
+	assert.InDelta(t, plasticTestWeight+0.1*post*(pre-post*plasticTestWeight), link.ConnectionWeight, 1e-12)
+}
+
+func TestNetwork_ForwardSteps_plasticityABCD(t *testing.T) {
+	// A = 1.0, B = -1.0, C = 0.0, D = 0.5
+	net, link := buildPlasticNetwork(neat.PlasticityRuleABCD, []float64{0.1, 1.0, 0.0, 0.5, 0.75})
+
+	pre := 0.8
+	post := activatePlasticNetwork(t, net, pre)
+	expected := plasticTestWeight + 0.1*(pre*post-pre+0.5)
+	assert.InDelta(t, expected, link.ConnectionWeight, 1e-12)
+}
+
+func TestNetwork_ForwardSteps_plasticityABCD_notEnoughParams(t *testing.T) {
+	net, link := buildPlasticNetwork(neat.PlasticityRuleABCD, []float64{0.1, 1.0})
+
+	activatePlasticNetwork(t, net, 0.8)
+	assert.Equal(t, plasticTestWeight, link.ConnectionWeight)
+}
+
+func TestNetwork_ForwardSteps_noPlasticity(t *testing.T) {
+	// no rule
+	net, link := buildPlasticNetwork("", []float64{0.1})
+	activatePlasticNetwork(t, net, 0.8)
+	assert.Equal(t, plasticTestWeight, link.ConnectionWeight)
+
+	// no link parameters
+	net, link = buildPlasticNetwork(neat.PlasticityRuleHebbian, nil)
+	activatePlasticNetwork(t, net, 0.8)
+	assert.Equal(t, plasticTestWeight, link.ConnectionWeight)
+}
+
+func TestNetwork_Flush_restoresPlasticWeights(t *testing.T) {
+	net, link := buildPlasticNetwork(neat.PlasticityRuleHebbian, []float64{0.1})
+
+	activatePlasticNetwork(t, net, 0.8)
+	activatePlasticNetwork(t, net, 0.8)
+	require.NotEqual(t, plasticTestWeight, link.ConnectionWeight)
+
+	res, err := net.Flush()
+	require.NoError(t, err)
+	require.True(t, res)
+	assert.Equal(t, plasticTestWeight, link.ConnectionWeight)
+}
+
+func TestNetwork_Relax(t *testing.T) {
+	net := buildPlainNetwork()
+	err := net.LoadSensors([]float64{0.5, 1.0, 1.0})
+	require.NoError(t, err, "failed to load sensors")
+
+	relaxed, err := net.Relax(10, 0.01)
+	assert.NoError(t, err)
+	assert.True(t, relaxed)
+	assert.Len(t, net.ReadOutputs(), 2)
+
+	// no relaxation check
+	relaxed, err = net.Relax(1, 0)
+	assert.NoError(t, err)
+	assert.True(t, relaxed)
+
+	// test zero steps
+	relaxed, err = net.Relax(0, 0.01)
+	assert.EqualError(t, err, ErrZeroActivationStepsRequested.Error())
+	assert.False(t, relaxed)
+}
+
+func TestNetwork_Relax_plasticity(t *testing.T) {
+	net, link := buildPlasticNetwork(neat.PlasticityRuleHebbian, []float64{0.1})
+	err := net.LoadSensors([]float64{0.8})
+	require.NoError(t, err, "failed to load sensors")
+
+	relaxed, err := net.Relax(3, 0)
+	assert.NoError(t, err)
+	assert.True(t, relaxed)
+	assert.Greater(t, link.ConnectionWeight, plasticTestWeight)
+}
+
+func TestNetwork_FastNetworkSolver_plasticity(t *testing.T) {
+	net, _ := buildPlasticNetwork(neat.PlasticityRuleHebbian, []float64{0.1})
+
+	solver, err := net.FastNetworkSolver()
+	assert.ErrorIs(t, err, ErrPlasticityNotSupported)
+	assert.Nil(t, solver)
+
+	// without plasticity
+	net.PlasticityRule = ""
+	solver, err = net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast network solver")
+	assert.NotNil(t, solver)
+}
@@ -0,0 +1,57 @@
+package neat
+
+import "github.com/pkg/errors"
+
+// NoveltySearchOptions The options of the novelty search, which rewards organisms for the novelty of their behavior
+// rather than for the progress towards the objective.
+type NoveltySearchOptions struct {
+	// The number of the nearest neighbors to estimate the sparseness of the behavior
+	KNearest int `yaml:"k_nearest"`
+	// The initial novelty threshold to add the organism's behavior to the archive
+	ArchiveThreshold float64 `yaml:"archive_threshold"`
+	// The minimal value of the dynamic novelty threshold
+	ArchiveThresholdMin float64 `yaml:"archive_threshold_min"`
+	// The multiplier to raise the novelty threshold when too many behaviors added to the archive per generation
+	ArchiveThresholdRaise float64 `yaml:"archive_threshold_raise"`
+	// The multiplier to lower the novelty threshold when no behaviors added to the archive for a while
+	ArchiveThresholdLower float64 `yaml:"archive_threshold_lower"`
+	// The maximal number of behaviors added to the archive per generation before the novelty threshold raised
+	ArchiveMaxAdds int `yaml:"archive_max_adds"`
+	// The number of generations without additions to the archive before the novelty threshold lowered
+	ArchiveStagnationTime int `yaml:"archive_stagnation_time"`
+	// The maximal size of the archive, if exceeded the oldest behaviors are removed. Zero means no limit.
+	ArchiveMaxSize int `yaml:"archive_max_size"`
+
+	// The weight of the objective fitness when blended with the novelty score: weight * fitness + (1 - weight) * novelty.
+	// Zero means pure novelty search.
+	ObjectiveWeight float64 `yaml:"objective_weight"`
+}
+
+// Validate is to check that novelty search options has valid values
+func (n *NoveltySearchOptions) Validate() error {
+	if n.KNearest <= 0 {
+		return errors.Errorf("novelty search k-nearest must be positive, but got: %d", n.KNearest)
+	}
+	if n.ArchiveThreshold < 0 || n.ArchiveThresholdMin < 0 {
+		return errors.New("novelty search archive thresholds must not be negative")
+	}
+	if n.ArchiveThresholdMin > n.ArchiveThreshold {
+		return errors.Errorf("novelty search minimal archive threshold: %f exceeds initial threshold: %f",
+			n.ArchiveThresholdMin, n.ArchiveThreshold)
+	}
+	if n.ArchiveThresholdRaise < 1 {
+		return errors.Errorf("novelty search archive threshold raise must not be less than 1, but got: %f",
+			n.ArchiveThresholdRaise)
+	}
+	if n.ArchiveThresholdLower <= 0 || n.ArchiveThresholdLower > 1 {
+		return errors.Errorf("novelty search archive threshold lower must be in range (0, 1], but got: %f",
+			n.ArchiveThresholdLower)
+	}
+	if n.ArchiveMaxAdds < 0 || n.ArchiveStagnationTime < 0 || n.ArchiveMaxSize < 0 {
+		return errors.New("novelty search archive limits must not be negative")
+	}
+	if n.ObjectiveWeight < 0 || n.ObjectiveWeight > 1 {
+		return errors.Errorf("novelty search objective weight must be in range [0, 1], but got: %f", n.ObjectiveWeight)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package neat
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+const noveltySearchOptionsYaml = `
+novelty_search:
+  k_nearest: 15
+  archive_threshold: 6.0
+  archive_threshold_min: 0.5
+  archive_threshold_raise: 1.2
+  archive_threshold_lower: 0.95
+  archive_max_adds: 4
+  archive_stagnation_time: 5
+  archive_max_size: 1000
+  objective_weight: 0.2
+`
+
+func TestLoadYAMLOptions_NoveltySearch(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte(noveltySearchOptionsYaml)...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err, "failed to load options")
+	checkNeatOptions(opts, t)
+
+	ns := opts.NoveltySearch
+	require.NotNil(t, ns)
+	assert.Equal(t, 15, ns.KNearest)
+	assert.Equal(t, 6.0, ns.ArchiveThreshold)
+	assert.Equal(t, 0.5, ns.ArchiveThresholdMin)
+	assert.Equal(t, 1.2, ns.ArchiveThresholdRaise)
+	assert.Equal(t, 0.95, ns.ArchiveThresholdLower)
+	assert.Equal(t, 4, ns.ArchiveMaxAdds)
+	assert.Equal(t, 5, ns.ArchiveStagnationTime)
+	assert.Equal(t, 1000, ns.ArchiveMaxSize)
+	assert.Equal(t, 0.2, ns.ObjectiveWeight)
+}
+
+func TestNoveltySearchOptions_Validate(t *testing.T) {
+	opts := NoveltySearchOptions{
+		KNearest:              15,
+		ArchiveThreshold:      6.0,
+		ArchiveThresholdMin:   0.5,
+		ArchiveThresholdRaise: 1.2,
+		ArchiveThresholdLower: 0.95,
+		ObjectiveWeight:       0.2,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.KNearest = 0
+	assert.Error(t, opts.Validate())
+
+	opts.KNearest = 15
+	opts.ArchiveThresholdMin = 7.0
+	assert.Error(t, opts.Validate())
+
+	opts.ArchiveThresholdMin = 0.5
+	opts.ArchiveThresholdRaise = 0.9
+	assert.Error(t, opts.Validate())
+
+	opts.ArchiveThresholdRaise = 1.2
+	opts.ArchiveThresholdLower = 0
+	assert.Error(t, opts.Validate())
+
+	opts.ArchiveThresholdLower = 0.95
+	opts.ArchiveMaxSize = -1
+	assert.Error(t, opts.Validate())
+
+	opts.ArchiveMaxSize = 0
+	opts.ObjectiveWeight = 1.5
+	assert.Error(t, opts.Validate())
+}
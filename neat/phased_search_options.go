@@ -0,0 +1,41 @@
+package neat
+
+import "github.com/pkg/errors"
+
+// PhasedSearchOptions The options of the phased search, where the population alternates between the complexifying
+// phase, when genomes grow by regular NEAT mutations, and the simplifying phase, when the deletion mutations are
+// the only structural mutations applied to prune the genomes.
+type PhasedSearchOptions struct {
+	// The growth of the mean genome complexity above the level reached at the end of the last simplifying phase
+	// (or the initial level) to switch the population into the simplifying phase
+	ComplexityThreshold float64 `yaml:"complexity_threshold"`
+	// The number of generations without improvement of the population fitness required to start the simplifying
+	// phase after the complexity threshold was exceeded. Zero means that fitness stagnation is not considered.
+	StagnationThreshold int `yaml:"stagnation_threshold"`
+	// The minimal number of generations of the simplifying phase. After that the population returns to the
+	// complexifying phase as soon as the mean genome complexity stops falling.
+	MinSimplifyingGenerations int `yaml:"min_simplifying_generations"`
+
+	// The probability of the link deletion mutation during the simplifying phase
+	DeleteLinkProb float64 `yaml:"delete_link_prob"`
+	// The probability of the node deletion mutation during the simplifying phase
+	DeleteNodeProb float64 `yaml:"delete_node_prob"`
+}
+
+// Validate is to check that phased search options has valid values
+func (p *PhasedSearchOptions) Validate() error {
+	if p.ComplexityThreshold <= 0 {
+		return errors.Errorf("phased search complexity threshold must be positive, but got: %f", p.ComplexityThreshold)
+	}
+	if p.StagnationThreshold < 0 || p.MinSimplifyingGenerations < 0 {
+		return errors.New("phased search generation thresholds must not be negative")
+	}
+	if p.DeleteLinkProb < 0 || p.DeleteLinkProb > 1 || p.DeleteNodeProb < 0 || p.DeleteNodeProb > 1 {
+		return errors.Errorf("phased search deletion probabilities must be in range [0, 1], but got link: %f, node: %f",
+			p.DeleteLinkProb, p.DeleteNodeProb)
+	}
+	if p.DeleteLinkProb == 0 && p.DeleteNodeProb == 0 {
+		return errors.New("phased search requires at least one deletion mutation probability to be set")
+	}
+	return nil
+}
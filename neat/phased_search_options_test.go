@@ -0,0 +1,58 @@
+package neat
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+const phasedSearchOptionsYaml = `
+phased_search:
+  complexity_threshold: 30
+  stagnation_threshold: 10
+  min_simplifying_generations: 5
+  delete_link_prob: 0.3
+  delete_node_prob: 0.1
+`
+
+func TestLoadYAMLOptions_PhasedSearch(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte(phasedSearchOptionsYaml)...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err, "failed to load options")
+	checkNeatOptions(opts, t)
+
+	ps := opts.PhasedSearch
+	require.NotNil(t, ps)
+	assert.Equal(t, 30.0, ps.ComplexityThreshold)
+	assert.Equal(t, 10, ps.StagnationThreshold)
+	assert.Equal(t, 5, ps.MinSimplifyingGenerations)
+	assert.Equal(t, 0.3, ps.DeleteLinkProb)
+	assert.Equal(t, 0.1, ps.DeleteNodeProb)
+}
+
+func TestPhasedSearchOptions_Validate(t *testing.T) {
+	opts := PhasedSearchOptions{
+		ComplexityThreshold: 30,
+		DeleteLinkProb:      0.3,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.ComplexityThreshold = 0
+	assert.Error(t, opts.Validate())
+	opts.ComplexityThreshold = 30
+
+	opts.StagnationThreshold = -1
+	assert.Error(t, opts.Validate())
+	opts.StagnationThreshold = 0
+
+	opts.DeleteNodeProb = 1.5
+	assert.Error(t, opts.Validate())
+
+	opts.DeleteNodeProb, opts.DeleteLinkProb = 0, 0
+	assert.Error(t, opts.Validate())
+}
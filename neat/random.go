@@ -0,0 +1,78 @@
+package neat
+
+import (
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"github.com/yaricom/goNEAT/v4/neat/internal/random"
+	"math/rand"
+)
+
+// globalRand The random numbers generator backed by the global generator of math/rand package
+var globalRand = random.GlobalRand()
+
+// GlobalRand Returns the random numbers generator backed by the global generator of math/rand package. It produces
+// the same sequence of numbers as functions of math/rand package and is safe for concurrent use.
+func GlobalRand() *rand.Rand {
+	return globalRand
+}
+
+// NewRand Creates new random numbers generator seeded with given value. The returned generator is not safe for
+// concurrent use.
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// Source The source of random numbers which state can be saved and restored, e.g., with population checkpoint. It
+// implements the SplitMix64 generator, which whole state is a single 64-bit value, so that saving and restoring
+// of the state takes constant time regardless of the number of values drawn. Note that it produces the sequence
+// different from the source returned by rand.NewSource with the same seed. It is not safe for concurrent use.
+type Source struct {
+	state uint64
+}
+
+// NewSource Creates new source of random numbers seeded with given value.
+func NewSource(seed int64) *Source {
+	s := &Source{}
+	s.Seed(seed)
+	return s
+}
+
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *Source) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *Source) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+// MarshalBinary Encodes the current state of this source.
+func (s *Source) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, s.state)
+	return data, nil
+}
+
+// UnmarshalBinary Restores the state of this source encoded by MarshalBinary.
+func (s *Source) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return errors.Errorf("wrong length of random source state: %d", len(data))
+	}
+	s.state = binary.BigEndian.Uint64(data)
+	return nil
+}
+
+// DeriveRand Creates new independent random numbers generator seeded from the given one. It is used to create
+// separate streams of random numbers for the concurrently executed tasks, such as reproduction of species or
+// evaluation of organisms. As long as the streams are derived in the same order, the same seed gives the same
+// results regardless of how the tasks are scheduled.
+func DeriveRand(rng *rand.Rand) *rand.Rand {
+	return NewRand(rng.Int63())
+}
@@ -0,0 +1,76 @@
+package neat
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestRandFromContext(t *testing.T) {
+	rng := NewRand(42)
+	ctx := NewRandContext(context.Background(), rng)
+	assert.Equal(t, rng, RandFromContext(ctx))
+
+	// fallback to the global generator
+	assert.Equal(t, GlobalRand(), RandFromContext(context.Background()))
+}
+
+func TestDeriveRand(t *testing.T) {
+	first, second := DeriveRand(NewRand(42)), DeriveRand(NewRand(42))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first.Int63(), second.Int63(), "derived streams differ at: %d", i)
+	}
+}
+
+func TestSource_MarshalBinary(t *testing.T) {
+	src := NewSource(42)
+	rng := rand.New(src)
+	for i := 0; i < 100; i++ {
+		rng.Float64()
+	}
+	state, err := src.MarshalBinary()
+	require.NoError(t, err)
+
+	restored := NewSource(1)
+	err = restored.UnmarshalBinary(state)
+	require.NoError(t, err)
+	restoredRng := rand.New(restored)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, rng.Int63(), restoredRng.Int63(), "restored stream differs at: %d", i)
+	}
+}
+
+func TestSource_Seed(t *testing.T) {
+	first, second := rand.New(NewSource(42)), rand.New(NewSource(42))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first.Uint64(), second.Uint64(), "stream differs at: %d", i)
+	}
+	assert.NotEqual(t, rand.New(NewSource(42)).Int63(), rand.New(NewSource(43)).Int63())
+
+	// SplitMix64 reference values for zero seed
+	src := NewSource(0)
+	assert.Equal(t, uint64(0xe220a8397b1dcdaf), src.Uint64())
+	assert.Equal(t, uint64(0x6e789e6aa1b965f4), src.Uint64())
+}
+
+func TestSource_UnmarshalBinary_wrongLength(t *testing.T) {
+	err := NewSource(42).UnmarshalBinary([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestRandSourceFromContext(t *testing.T) {
+	src := NewSource(42)
+	ctx := NewRandSourceContext(context.Background(), src)
+	found, ok := RandSourceFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, src, found)
+
+	// the generator replaced by another one
+	_, ok = RandSourceFromContext(NewRandContext(ctx, NewRand(42)))
+	assert.False(t, ok)
+
+	_, ok = RandSourceFromContext(context.Background())
+	assert.False(t, ok)
+}
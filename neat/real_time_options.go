@@ -0,0 +1,38 @@
+package neat
+
+import (
+	"github.com/pkg/errors"
+	"math"
+)
+
+// RealTimeOptions The options of the real-time NEAT (rtNEAT), where instead of generational turnover of population
+// the worst organism is periodically replaced by the offspring of the species chosen by their average fitness. It
+// allows to evolve organisms within continuously running simulation.
+type RealTimeOptions struct {
+	// The minimal time alive of organism, i.e. the number of simulation ticks, to be evaluated enough for removal
+	MinEvaluationAge int `yaml:"min_evaluation_age"`
+	// The fraction of population which is allowed to be ineligible for removal at any moment, because it is not
+	// evaluated enough. It is used to estimate the number of ticks between replacements.
+	IneligibleFraction float64 `yaml:"ineligible_fraction"`
+}
+
+// Validate is to check that real-time NEAT options has valid values
+func (r *RealTimeOptions) Validate() error {
+	if r.MinEvaluationAge < 0 {
+		return errors.Errorf("real-time minimal evaluation age must not be negative, but got: %d", r.MinEvaluationAge)
+	}
+	if r.IneligibleFraction <= 0 || r.IneligibleFraction > 1 {
+		return errors.Errorf("real-time ineligible fraction must be in range (0, 1], but got: %f", r.IneligibleFraction)
+	}
+	return nil
+}
+
+// ReplacementInterval Returns the number of simulation ticks between replacements of organisms in population of
+// given size: n = m / (|P| * I), where m is the minimal evaluation age and I is the ineligible fraction.
+func (r *RealTimeOptions) ReplacementInterval(popSize int) int {
+	interval := int(math.Ceil(float64(r.MinEvaluationAge) / (float64(popSize) * r.IneligibleFraction)))
+	if interval < 1 {
+		interval = 1
+	}
+	return interval
+}
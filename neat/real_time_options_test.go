@@ -0,0 +1,59 @@
+package neat
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+const realTimeOptionsYaml = `
+real_time:
+  min_evaluation_age: 500
+  ineligible_fraction: 0.5
+`
+
+func TestLoadYAMLOptions_RealTime(t *testing.T) {
+	content, err := os.ReadFile(xorOptionsFileYaml)
+	require.NoError(t, err)
+	content = append(content, []byte(realTimeOptionsYaml)...)
+
+	opts, err := LoadYAMLOptions(bytes.NewReader(content))
+	require.NoError(t, err, "failed to load options")
+	checkNeatOptions(opts, t)
+
+	rt := opts.RealTime
+	require.NotNil(t, rt)
+	assert.Equal(t, 500, rt.MinEvaluationAge)
+	assert.Equal(t, 0.5, rt.IneligibleFraction)
+}
+
+func TestRealTimeOptions_Validate(t *testing.T) {
+	opts := RealTimeOptions{
+		MinEvaluationAge:   500,
+		IneligibleFraction: 0.5,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.MinEvaluationAge = -1
+	assert.Error(t, opts.Validate())
+	opts.MinEvaluationAge = 500
+
+	opts.IneligibleFraction = 0
+	assert.Error(t, opts.Validate())
+	opts.IneligibleFraction = 1.5
+	assert.Error(t, opts.Validate())
+}
+
+func TestRealTimeOptions_ReplacementInterval(t *testing.T) {
+	opts := RealTimeOptions{
+		MinEvaluationAge:   500,
+		IneligibleFraction: 0.5,
+	}
+	assert.Equal(t, 20, opts.ReplacementInterval(50))
+	assert.Equal(t, 7, opts.ReplacementInterval(150))
+
+	opts.MinEvaluationAge = 0
+	assert.Equal(t, 1, opts.ReplacementInterval(50))
+}
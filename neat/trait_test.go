@@ -29,3 +29,15 @@ func TestNewTraitCopy(t *testing.T) {
 		assert.Equal(t, t1.Params[i], p, "Wrong parameter at: %d", i)
 	}
 }
+
+func TestTrait_MutateRng(t *testing.T) {
+	t1 := &Trait{Id: 1, Params: []float64{1, 2, 3, 4, 5, 6}}
+	t2 := NewTraitCopy(t1)
+
+	t1.MutateRng(NewRand(42), 1.0, 0.5)
+	t2.MutateRng(NewRand(42), 1.0, 0.5)
+	assert.Equal(t, t1.Params, t2.Params)
+	for i, p := range t1.Params {
+		assert.True(t, p >= 0, "negative parameter at: %d", i)
+	}
+}